@@ -2,32 +2,592 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"go-api-scheduler/internal/config"
 	"go-api-scheduler/internal/handler"
 	"go-api-scheduler/internal/logger"
+	"go-api-scheduler/internal/metrics"
+	"go-api-scheduler/internal/tracing"
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+	"go-api-scheduler/pkg/scheduler/amqpexec"
+	"go-api-scheduler/pkg/scheduler/boltstore"
+	_ "go-api-scheduler/pkg/scheduler/filestore"
+	"go-api-scheduler/pkg/scheduler/grpcexec"
+	"go-api-scheduler/pkg/scheduler/kafkaexec"
+	"go-api-scheduler/pkg/scheduler/redisstore"
+	_ "go-api-scheduler/pkg/scheduler/sqlitestore"
+	"go-api-scheduler/pkg/storage"
 )
 
 func main() {
-	// Initialize the logger.
+	// Every flag defaults to an environment variable, so the server can be
+	// configured from a container spec.
+	configPath := flag.String("config", env("API_SCHEDULER_CONFIG", ""), "path to the JSON server config file")
+	enableCommandJobs := flag.Bool("enable-command-jobs", envBool("API_SCHEDULER_ENABLE_COMMAND_JOBS"), "allow schedulers of type \"command\" to run local commands")
+	fileRoot := flag.String("file-root", env("API_SCHEDULER_FILE_ROOT", ""), "directory schedulers may read local payload and upload files from; empty allows none")
+	archiveRoot := flag.String("archive-root", env("API_SCHEDULER_ARCHIVE_ROOT", ""), "directory schedulers may archive responses below; empty allows none")
+	awsEnvCredentials := flag.Bool("aws-env-credentials", envBool("API_SCHEDULER_AWS_ENV_CREDENTIALS"), "let SigV4 signing without credentials use the AWS_* environment variables")
+	bind := flag.String("bind", env("API_SCHEDULER_BIND_ADDRESS", ""), "address to listen on; empty means every interface")
+	port := flag.String("port", env("PORT", "8080"), "port to listen on")
+	basePath := flag.String("base-path", env("API_SCHEDULER_BASE_PATH", ""), "URL path prefix to serve under, e.g. /scheduler")
+	profile := flag.String("profile", env("API_SCHEDULER_PROFILE", ""), "environment profile schedulers with a relative apiURL run against, overriding activeProfile")
+	enableClockControl := flag.Bool("enable-clock-control", envBool("API_SCHEDULER_ENABLE_CLOCK_CONTROL"), "run the scheduler on a virtual clock that /admin/clock/advance moves forward; for tests only")
+	selftestMode := flag.Bool("selftest", envBool("API_SCHEDULER_SELFTEST"), "start the server on a free local port, run the self-test scenarios against the fake server and exit")
+	shutdownGrace := flag.Duration("shutdown-grace", envDuration("API_SCHEDULER_SHUTDOWN_GRACE", 25*time.Second), "how long to wait for requests and executions on SIGTERM")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *basePath == "" {
+		*basePath = cfg.BasePath
+	}
+	if *profile != "" {
+		cfg.ActiveProfile = *profile
+	}
+	prefix, err := cleanBasePath(*basePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.Language != "" {
+		if err := i18n.SetDefault(cfg.Language); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Export traces of scheduled calls when an OTLP endpoint is configured
+	// in the environment.
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Create the scheduler manager.
+	var httpTimeout time.Duration
+	if cfg.HTTP.Timeout != "" {
+		httpTimeout, err = time.ParseDuration(cfg.HTTP.Timeout)
+		if err != nil {
+			log.Fatalf(i18n.T("http.timeout 설정 파싱 오류: %v"), err)
+		}
+	}
+	clients := scheduler.NewClientManager(cfg.HTTP.MaxIdleConnsPerHost, httpTimeout)
+	if err := clients.SetProxy(cfg.HTTP.Proxy, cfg.HTTP.NoProxy); err != nil {
+		log.Fatalf(i18n.T("http.proxy 설정 오류: %v"), err)
+	}
+	clients.SetMaxResponseSize(cfg.HTTP.MaxResponseSize)
+	opts := []scheduler.Option{
+		scheduler.WithMaxConcurrent(cfg.MaxConcurrentExecutions),
+		scheduler.WithClientManager(clients),
+		scheduler.WithFileRoot(*fileRoot),
+		scheduler.WithArchiveRoot(*archiveRoot),
+	}
+	if *awsEnvCredentials {
+		opts = append(opts, scheduler.WithAWSEnvCredentials())
+	}
+	redactor, err := scheduler.NewRedactor(cfg.Redaction.Headers, cfg.Redaction.Fields, cfg.Redaction.Patterns)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts = append(opts, scheduler.WithRedactor(redactor))
+	// The settings that may change when the config is reloaded are
+	// applied with Reconfigure once the scheduler exists.
+	settings, tokens, err := serverSettings(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	store, err := openStore(cfg.Storage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if store != nil {
+		defer store.Close()
+		opts = append(opts, scheduler.WithStore(store))
+	}
+	// A shared store lets several instances run behind a load balancer:
+	// each fire time is claimed by one instance and the log tail is shared.
+	var syncInterval time.Duration
+	if locker, ok := store.(scheduler.Locker); ok {
+		opts = append(opts, scheduler.WithLocker(locker))
+		if rs, ok := store.(*redisstore.Store); ok {
+			logger.SetBackend(&logger.RedisBackend{Client: rs.Client(), Key: redisPrefix(cfg.Storage) + ":logs"})
+		}
+
+		syncInterval = 5 * time.Second
+		if cfg.Storage.SyncInterval != "" {
+			syncInterval, err = time.ParseDuration(cfg.Storage.SyncInterval)
+			if err != nil || syncInterval <= 0 {
+				log.Fatalf(i18n.T("storage.syncInterval 설정 오류: %q"), cfg.Storage.SyncInterval)
+			}
+		}
+	}
+	// Tests can fast-forward a virtual clock instead of waiting for fire
+	// times.
+	var clock *scheduler.VirtualClock
+	if *enableClockControl {
+		clock = scheduler.NewVirtualClock()
+		opts = append(opts, scheduler.WithClock(clock))
+		log.Print(i18n.T("가상 시계로 실행합니다. 테스트 외에는 사용하지 마세요."))
+	}
+	// The embedded bbolt file keeps the log tail across restarts.
+	if bs, ok := store.(*boltstore.Store); ok {
+		logger.SetBackend(&logger.BoltBackend{DB: bs.DB(), Bucket: boltstore.BucketLogs})
+	}
+	sched := scheduler.New(opts...)
+	// The Telegram bot is registered before the settings, whose notify
+	// may use it.
+	if cfg.Telegram.BotToken != "" {
+		sched.RegisterNotifier(scheduler.ChannelTelegram, &scheduler.TelegramNotifier{
+			Clients: clients,
+			Token:   cfg.Telegram.BotToken,
+			APIURL:  cfg.Telegram.APIURL,
+		})
+	}
+	if err := sched.Reconfigure(settings); err != nil {
+		log.Fatal(err)
+	}
+	sched.RegisterExecutor(scheduler.JobTypeGRPC, &grpcexec.Executor{FileRoot: sched.FileRoot()})
+	if len(cfg.Kafka.Brokers) > 0 {
+		kafkaExec := kafkaexec.New(cfg.Kafka.Brokers, cfg.Kafka.ClientID)
+		defer kafkaExec.Close()
+		sched.RegisterExecutor(scheduler.JobTypeKafka, kafkaExec)
+	}
+	if cfg.AMQP.URL != "" {
+		amqpExec := amqpexec.New(cfg.AMQP.URL)
+		defer amqpExec.Close()
+		sched.RegisterExecutor(scheduler.JobTypeAMQP, amqpExec)
+	}
+	if *enableCommandJobs {
+		sched.RegisterExecutor(scheduler.JobTypeCommand, &scheduler.CommandExecutor{})
+	}
+
+	if cfg.StatsD.Address != "" {
+		interval := 10 * time.Second
+		if cfg.StatsD.Interval != "" {
+			interval, err = time.ParseDuration(cfg.StatsD.Interval)
+			if err != nil || interval <= 0 {
+				log.Fatalf(i18n.T("statsd.interval 설정 오류: %q"), cfg.StatsD.Interval)
+			}
+		}
+		prefix := cfg.StatsD.Prefix
+		if prefix == "" {
+			prefix = "api_scheduler."
+		}
+		statsd, err := metrics.NewStatsD(cfg.StatsD.Address, prefix, cfg.StatsD.DogStatsD, cfg.StatsD.Tags)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer statsd.Close()
+		statsd.Attach(sched, interval)
+		statsd.AttachAccessLog()
+	}
+
+	if fc := cfg.LogForward; fc.Type != "" {
+		interval := time.Second
+		if fc.FlushInterval != "" {
+			interval, err = time.ParseDuration(fc.FlushInterval)
+			if err != nil || interval <= 0 {
+				log.Fatalf(i18n.T("logForward.flushInterval 설정 오류: %q"), fc.FlushInterval)
+			}
+		}
+		retries := fc.MaxRetries
+		if retries == 0 {
+			retries = 3
+		}
+		forwarder, err := logger.NewForwarder(logger.ForwardConfig{
+			Type:          fc.Type,
+			URL:           fc.URL,
+			Tag:           fc.Tag,
+			Labels:        fc.Labels,
+			Headers:       fc.Headers,
+			BatchSize:     fc.BatchSize,
+			FlushInterval: interval,
+			MaxRetries:    retries,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer forwarder.Close()
+		logger.SetForwarder(forwarder)
+	}
+
+	// Initialize the logger and forward scheduler events to it.
 	logger.Init()
+	logger.Attach(sched)
 	// Initialize the handler.
-	handler.Init()
+	handler.Init(sched)
+	handler.SetTokens(tokens)
+	handler.SetRoles(settings.Limits.Roles)
+	handler.SetBasePath(prefix)
+	if cfg.IdempotencyRetention != "" {
+		retention, err := time.ParseDuration(cfg.IdempotencyRetention)
+		if err != nil || retention <= 0 {
+			log.Fatalf(i18n.T("idempotencyRetention 설정 오류: %q"), cfg.IdempotencyRetention)
+		}
+		handler.SetIdempotencyRetention(retention)
+	}
+	// Apply the changes of the config file on POST /admin/reload or
+	// SIGHUP, keeping the schedulers running.
+	if *configPath != "" {
+		r := &reloader{path: *configPath, sched: sched, profile: *profile, cfg: cfg}
+		handler.SetReloader(r.reload)
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				if _, err := r.reload(); err != nil {
+					log.Printf(i18n.T("설정 다시 불러오기 오류: %v"), err)
+				}
+			}
+		}()
+	}
+
+	// Restore schedulers persisted before the last shutdown. The self-test
+	// only runs its own, so it never calls the real APIs.
+	if !*selftestMode {
+		if err := sched.Restore(); err != nil {
+			log.Fatalf(i18n.T("스케줄러 복원 오류: %v"), err)
+		}
+	}
+	if syncInterval > 0 && !*selftestMode {
+		go func() {
+			for range time.Tick(syncInterval) {
+				if err := sched.Sync(); err != nil {
+					log.Printf(i18n.T("스케줄러 동기화 오류: %v"), err)
+				}
+			}
+		}()
+	}
+	watchInterval, grace := 30*time.Second, scheduler.DefaultStallGrace
+	if cfg.Watchdog.Interval != "" {
+		watchInterval, err = time.ParseDuration(cfg.Watchdog.Interval)
+		if err != nil || watchInterval <= 0 {
+			log.Fatalf(i18n.T("watchdog.interval 설정 오류: %q"), cfg.Watchdog.Interval)
+		}
+	}
+	if cfg.Watchdog.Grace != "" {
+		grace, err = time.ParseDuration(cfg.Watchdog.Grace)
+		if err != nil || grace <= 0 {
+			log.Fatalf(i18n.T("watchdog.grace 설정 오류: %q"), cfg.Watchdog.Grace)
+		}
+	}
+	go func() {
+		for range time.Tick(watchInterval) {
+			sched.CheckStalls(grace)
+			sched.CheckSLAs()
+		}
+	}()
+	if cfg.Reports != nil && !*selftestMode {
+		if err := cfg.Reports.Validate(); err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			if err := sched.RunReports(context.Background(), *cfg.Reports); err != nil {
+				log.Printf(i18n.T("요약 보고서 설정 오류: %v"), err)
+			}
+		}()
+	}
 
-	// Serve static files from the 'web/static' directory.
-	fs := http.FileServer(http.Dir("web/static"))
-	http.Handle("/", fs)
+	mux := http.NewServeMux()
+	routes(mux, clock)
+
+	var root http.Handler = mux
+	if prefix != "" {
+		outer := http.NewServeMux()
+		outer.Handle(prefix+"/", http.StripPrefix(prefix, mux))
+		outer.Handle(prefix, http.RedirectHandler(prefix+"/", http.StatusMovedPermanently))
+		// The probes also answer at the root, for kubelets probing the pod
+		// directly.
+		outer.HandleFunc("GET /healthz", handler.HealthHandler)
+		outer.HandleFunc("GET /readyz", handler.ReadyHandler)
+		root = outer
+	}
+	root = handler.MaintenanceGuard(root)
+	root = handler.RateLimit(root, rateLimitConfig(cfg.RateLimit))
+	root = handler.CORS(root, handler.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	})
+	root = handler.AccessLog(root, handler.AccessLogConfig{
+		Disabled:  cfg.AccessLog.Disabled,
+		SkipPaths: cfg.AccessLog.SkipPaths,
+	})
+
+	addr := net.JoinHostPort(*bind, *port)
+	if *selftestMode {
+		addr = "127.0.0.1:0"
+	}
+	// Listen before serving so the self-test can send requests right away.
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv := &http.Server{Handler: root}
+	go func() {
+		if err := srv.Serve(ln); err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+	host, listenPort, _ := net.SplitHostPort(ln.Addr().String())
+	if *bind == "" && !*selftestMode {
+		host = "localhost"
+	}
+	log.Printf(i18n.T("웹 서버가 http://%s%s/ 에서 실행 중입니다."), net.JoinHostPort(host, listenPort), prefix)
+	handler.SetReady(true)
+
+	if *selftestMode {
+		ok := selftest("http://"+net.JoinHostPort(host, listenPort)+prefix, sched, tokens)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownGrace)
+		srv.Shutdown(shutdownCtx)
+		drain(shutdownCtx, sched)
+		cancel()
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Stop on SIGTERM, which Kubernetes sends when it terminates the pod,
+	// or on Ctrl+C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+	handler.SetReady(false)
+	log.Printf(i18n.T("종료 신호를 받았습니다. 진행 중인 요청과 실행을 최대 %s 기다립니다."), *shutdownGrace)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownGrace)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf(i18n.T("웹 서버 종료 오류: %v"), err)
+	}
+	if n := drain(shutdownCtx, sched); n > 0 {
+		log.Printf(i18n.T("진행 중인 실행 %d개를 기다리지 않고 종료합니다."), n)
+	}
+	log.Print(i18n.T("서버를 종료합니다."))
+}
+
+// routes registers the web UI, the API endpoints, the fake server and the
+// probes on mux. The clock endpoints are only registered with a virtual
+// clock.
+func routes(mux *http.ServeMux, clock *scheduler.VirtualClock) {
+	// Serve the web UI from the 'web/static' directory.
+	mux.Handle("/", handler.UIHandler("web/static"))
 
 	// Register API endpoints.
-	http.HandleFunc("/start", handler.StartHandler)
-	http.HandleFunc("/stop", handler.StopHandler)
-	http.HandleFunc("/logs", handler.LogsHandler)
+	mux.HandleFunc("/start", handler.StartHandler)
+	mux.HandleFunc("/stop", handler.StopHandler)
+	mux.HandleFunc("/logs", handler.LogsHandler)
+	mux.HandleFunc("GET /logs/download", handler.LogsDownloadHandler)
+	mux.HandleFunc("GET /logs/access", handler.AccessLogsHandler)
+	mux.HandleFunc("GET /ws", handler.WSHandler)
+	mux.HandleFunc("GET /schedulers", handler.ListHandler)
+	mux.HandleFunc("POST /schedulers", handler.Idempotent(handler.CreateHandler))
+	mux.HandleFunc("POST /schedulers/batch", handler.BatchHandler)
+	mux.HandleFunc("POST /schedulers/lint", handler.LintHandler)
+	mux.HandleFunc("GET /schedulers/{id}", handler.DetailHandler)
+	mux.HandleFunc("PUT /schedulers/{id}", handler.UpdateHandler)
+	mux.HandleFunc("POST /schedulers/{id}/clone", handler.CloneHandler)
+	mux.HandleFunc("GET /schedulers/{id}/versions", handler.VersionsHandler)
+	mux.HandleFunc("GET /schedulers/{id}/stats", handler.StatsHandler)
+	mux.HandleFunc("GET /schedulers/{id}/executions/export", handler.ExportExecutionsHandler)
+	mux.HandleFunc("GET /schedulers/{id}/uptime", handler.UptimeHandler)
+	mux.HandleFunc("GET /schedulers/{id}/ical", handler.ICalHandler)
+	mux.HandleFunc("GET /schedulers/{id}/graph", handler.WorkflowGraphHandler)
+	mux.HandleFunc("POST /schedulers/{id}/rollback/{version}", handler.RollbackHandler)
+	mux.HandleFunc("DELETE /schedulers/{id}", handler.DeleteHandler)
+	mux.HandleFunc("POST /schedulers/{id}/stop", handler.StopSchedulerHandler)
+	mux.HandleFunc("POST /schedulers/{id}/start", handler.RestartHandler)
+	mux.HandleFunc("POST /schedulers/{id}/pause", handler.PauseHandler)
+	mux.HandleFunc("POST /schedulers/{id}/resume", handler.ResumeHandler)
+	mux.HandleFunc("GET /approvals", handler.ApprovalsHandler)
+	mux.HandleFunc("POST /executions/{id}/approve", handler.ApproveHandler)
+	mux.HandleFunc("POST /executions/{id}/reject", handler.RejectHandler)
+	mux.HandleFunc("GET /timeline", handler.TimelineHandler)
+	mux.HandleFunc("GET /reports/latest", handler.LatestReportHandler)
+	mux.HandleFunc("GET /profiles", handler.ProfilesHandler)
+	mux.HandleFunc("GET /ical", handler.ICalFeedHandler)
+	mux.HandleFunc("/metrics", handler.MetricsHandler)
+	mux.HandleFunc("GET /templates", handler.ListTemplatesHandler)
+	mux.HandleFunc("POST /templates", handler.CreateTemplateHandler)
+	mux.HandleFunc("GET /templates/{name}", handler.GetTemplateHandler)
+	mux.HandleFunc("PUT /templates/{name}", handler.UpdateTemplateHandler)
+	mux.HandleFunc("DELETE /templates/{name}", handler.DeleteTemplateHandler)
+	mux.HandleFunc("GET /variables", handler.ListVariablesHandler)
+	mux.HandleFunc("POST /variables", handler.CreateVariableHandler)
+	mux.HandleFunc("GET /variables/{name}", handler.GetVariableHandler)
+	mux.HandleFunc("PUT /variables/{name}", handler.UpdateVariableHandler)
+	mux.HandleFunc("DELETE /variables/{name}", handler.DeleteVariableHandler)
+	mux.HandleFunc("POST /admin/reload", handler.AdminReloadHandler)
+	mux.HandleFunc("GET /admin/maintenance", handler.MaintenanceHandler)
+	mux.HandleFunc("PUT /admin/maintenance", handler.SetMaintenanceHandler)
+	if clock != nil {
+		handler.SetVirtualClock(clock)
+		mux.HandleFunc("GET /admin/clock", handler.ClockHandler)
+		mux.HandleFunc("POST /admin/clock/advance", handler.AdvanceClockHandler)
+	}
 
 	// Add a new endpoint for the fake server.
-	http.HandleFunc("/fake-server", handler.FakeServerHandler)
+	mux.HandleFunc("/fake-server", handler.FakeServerHandler)
+
+	// Liveness and readiness probes.
+	mux.HandleFunc("GET /healthz", handler.HealthHandler)
+	mux.HandleFunc("GET /readyz", handler.ReadyHandler)
+}
+
+// drain waits until no execution of s runs or ctx is done, and returns
+// the number of executions still running.
+func drain(ctx context.Context, s *scheduler.Scheduler) int64 {
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for {
+		active := s.PoolStats().Active
+		if active == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return active
+		case <-t.C:
+		}
+	}
+}
+
+// cleanBasePath returns p as a path prefix without a trailing slash, ""
+// for the root.
+func cleanBasePath(p string) (string, error) {
+	p = strings.TrimRight(p, "/")
+	if p == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(p, "/") || strings.ContainsAny(p, "?#{} ") {
+		return "", i18n.Errorf("base-path는 /로 시작하는 경로여야 합니다: %q", p)
+	}
+	return p, nil
+}
+
+// env returns the environment variable name, or def when it is unset.
+func env(name, def string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	return def
+}
+
+// envBool returns the boolean environment variable name, false when it is
+// unset.
+func envBool(name string) bool {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Fatalf(i18n.T("환경 변수 %s 설정 오류: %q"), name, v)
+	}
+	return b
+}
+
+// envDuration returns the environment variable name as a Go duration, or
+// def when it is unset.
+func envDuration(name string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Fatalf(i18n.T("환경 변수 %s 설정 오류: %q"), name, v)
+	}
+	return d
+}
+
+// serverSettings returns the scheduler settings of cfg and the users of
+// the configured API tokens, by token. The roles of those users are in the
+// limits of the settings.
+func serverSettings(cfg *config.Config) (scheduler.Settings, map[string]string, error) {
+	tokens := make(map[string]string, len(cfg.Auth.Tokens))
+	limits := scheduler.Limits{
+		MaxSchedulers: cfg.Limits.MaxSchedulers,
+		MaxPerOwner:   cfg.Limits.MaxPerUser,
+		MaxPerGroup:   cfg.Limits.MaxPerGroup,
+		MaxPerHost:    cfg.Limits.MaxPerHost,
+		MinInterval:   make(map[string]time.Duration, len(cfg.Limits.MinInterval)),
+		Roles:         make(map[string]string),
+	}
+	for _, t := range cfg.Auth.Tokens {
+		if t.Token == "" || t.User == "" {
+			return scheduler.Settings{}, nil, i18n.Errorf("auth.tokens에는 token과 user가 필요합니다.")
+		}
+		tokens[t.Token] = t.User
+		if t.Role != "" {
+			limits.Roles[t.User] = t.Role
+		}
+	}
+	for role, s := range cfg.Limits.MinInterval {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return scheduler.Settings{}, nil, i18n.Errorf("limits.minInterval 설정 오류: %s: %q", role, s)
+		}
+		limits.MinInterval[role] = d
+	}
+	return scheduler.Settings{
+		LogLevel:       cfg.LogLevel,
+		DefaultHeaders: cfg.HTTP.DefaultHeaders,
+		Notify:         cfg.Notify,
+		Blackouts:      cfg.Blackouts,
+		Limits:         limits,
+		Profiles:       cfg.Profiles,
+		ActiveProfile:  cfg.ActiveProfile,
+		AllowedEnv:     cfg.AllowedEnv,
+	}, tokens, nil
+}
+
+// rateLimitConfig returns the handler settings of c.
+func rateLimitConfig(c config.RateLimitConfig) handler.RateLimitConfig {
+	return handler.RateLimitConfig{
+		WritesPerMinute:   c.WritesPerMinute,
+		ReadsPerMinute:    c.ReadsPerMinute,
+		Burst:             c.Burst,
+		TrustForwardedFor: c.TrustForwardedFor,
+		ExemptUsers:       c.ExemptUsers,
+	}
+}
+
+// openStore opens the configured scheduler store. It returns nil when
+// the schedulers should not be persisted.
+func openStore(cfg config.StorageConfig) (storage.Store, error) {
+	opts := storage.Options{Path: cfg.Path, URL: cfg.URL, KeyPrefix: redisPrefix(cfg)}
+	if cfg.Driver != "" {
+		return storage.Open(cfg.Driver, opts)
+	}
+	store, err := storage.Open(config.StorageSQLite, opts)
+	if err == nil || cfg.Path != "" {
+		return store, err
+	}
+	// Builds without cgo have no SQLite driver; fall back to the JSON file store.
+	log.Printf(i18n.T("SQLite 저장소를 사용할 수 없어 파일 저장소를 사용합니다: %v"), err)
+	return storage.Open(config.StorageFile, storage.Options{})
+}
 
-	port := ":8080"
-	log.Printf("웹 서버가 http://localhost%s 에서 실행 중입니다.", port)
-	log.Fatal(http.ListenAndServe(port, nil))
+// redisPrefix returns the configured Redis key prefix or the default.
+func redisPrefix(cfg config.StorageConfig) string {
+	if cfg.KeyPrefix != "" {
+		return cfg.KeyPrefix
+	}
+	return redisstore.DefaultPrefix
 }
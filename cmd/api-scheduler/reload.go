@@ -0,0 +1,100 @@
+// cmd/api-scheduler/reload.go
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"go-api-scheduler/internal/config"
+	"go-api-scheduler/internal/handler"
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// reloadable are the settings applied by a reload, by their path in the
+// config file or the prefix of it.
+var reloadable = []string{"logLevel", "notify", "http.defaultHeaders", "blackouts", "profiles", "activeProfile", "allowedEnv", "auth.", "limits.", "rateLimit."}
+
+// reloader re-reads the config file and applies the settings that may
+// change while the server runs. The others are reported until a restart.
+type reloader struct {
+	path  string
+	sched *scheduler.Scheduler
+	// profile is the -profile flag, which overrides activeProfile.
+	profile string
+
+	// mu serializes reloads. cfg is the config the server started with,
+	// with the reloadable settings as last applied.
+	mu  sync.Mutex
+	cfg *config.Config
+}
+
+// reload re-reads and applies the config file. A config that can't be
+// read or applied leaves the running one as is.
+func (r *reloader) reload() (handler.ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next, err := config.Load(r.path)
+	if err != nil {
+		return handler.ReloadResult{}, err
+	}
+	if r.profile != "" {
+		next.ActiveProfile = r.profile
+	}
+	settings, tokens, err := serverSettings(next)
+	if err != nil {
+		return handler.ReloadResult{}, err
+	}
+	if err := r.sched.Reconfigure(settings); err != nil {
+		return handler.ReloadResult{}, err
+	}
+	handler.SetTokens(tokens)
+	handler.SetRoles(settings.Limits.Roles)
+	handler.SetRateLimit(rateLimitConfig(next.RateLimit))
+
+	result := handler.ReloadResult{Applied: []string{}, RestartRequired: []string{}}
+	for _, name := range config.Changed(r.cfg, next) {
+		if isReloadable(name) {
+			result.Applied = append(result.Applied, name)
+		} else {
+			result.RestartRequired = append(result.RestartRequired, name)
+		}
+	}
+	// Keep comparing the settings needing a restart with the running ones.
+	applied := *r.cfg
+	applied.LogLevel = next.LogLevel
+	applied.Notify = next.Notify
+	applied.HTTP.DefaultHeaders = next.HTTP.DefaultHeaders
+	applied.Blackouts = next.Blackouts
+	applied.Profiles = next.Profiles
+	applied.ActiveProfile = next.ActiveProfile
+	applied.AllowedEnv = next.AllowedEnv
+	applied.Auth = next.Auth
+	applied.Limits = next.Limits
+	applied.RateLimit = next.RateLimit
+	r.cfg = &applied
+
+	log.Printf(i18n.T("설정을 다시 불러왔습니다. 적용: %s, 재시작 필요: %s"),
+		listOrNone(result.Applied), listOrNone(result.RestartRequired))
+	return result, nil
+}
+
+// isReloadable reports whether the setting named name is applied by a
+// reload.
+func isReloadable(name string) bool {
+	for _, r := range reloadable {
+		if name == r || strings.HasSuffix(r, ".") && strings.HasPrefix(name, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// listOrNone joins names for the log, "-" when there are none.
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,227 @@
+// cmd/api-scheduler/selftest.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-api-scheduler/internal/handler"
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// selftestTimeout bounds how long a scenario waits for its execution.
+const selftestTimeout = 15 * time.Second
+
+// scenario is a scripted run of the self-test: a scheduler targeting the
+// fake server, and the check of the outcome of its first fire time.
+type scenario struct {
+	name   string
+	method string
+	// payload is sent as the query of GET requests, which scripts the fake
+	// server; see handler.FakeServerHandler. A "{id}" is replaced by the ID
+	// of the scheduler.
+	payload string
+	retry   *scheduler.RetryPolicy
+	// check returns why the execution isn't the expected one, or "".
+	check func(ev scheduler.Event) string
+}
+
+// scenarios are the runs of the self-test, covering success, failure,
+// retries, slow responses and the request body.
+var scenarios = []scenario{
+	{
+		name:   "success",
+		method: http.MethodGet,
+		check:  expect(scheduler.EventSucceeded, http.StatusOK),
+	},
+	{
+		name:    "echo",
+		method:  http.MethodPost,
+		payload: `{"selftest":"echo"}`,
+		check: func(ev scheduler.Event) string {
+			if msg := expect(scheduler.EventSucceeded, http.StatusOK)(ev); msg != "" {
+				return msg
+			}
+			if !strings.Contains(ev.Execution.Output, "selftest=echo") {
+				return fmt.Sprintf(i18n.T("응답 본문에 페이로드가 없습니다: %q"), ev.Execution.Output)
+			}
+			return ""
+		},
+	},
+	{
+		name:    "failure",
+		method:  http.MethodGet,
+		payload: `{"status":"503"}`,
+		check:   expect(scheduler.EventFailed, http.StatusServiceUnavailable),
+	},
+	{
+		name:    "retry",
+		method:  http.MethodGet,
+		payload: `{"fail":"1","key":"{id}"}`,
+		retry:   &scheduler.RetryPolicy{MaxAttempts: 2, Backoff: "100ms"},
+		check:   expect(scheduler.EventSucceeded, http.StatusOK),
+	},
+	{
+		name:    "slow",
+		method:  http.MethodGet,
+		payload: `{"delay":"200ms"}`,
+		check: func(ev scheduler.Event) string {
+			if msg := expect(scheduler.EventSucceeded, http.StatusOK)(ev); msg != "" {
+				return msg
+			}
+			if ev.Execution.Duration < 200*time.Millisecond {
+				return fmt.Sprintf(i18n.T("실행 시간이 지연보다 짧습니다: %s"), ev.Execution.Duration)
+			}
+			return ""
+		},
+	},
+}
+
+// expect returns a check that the execution ended with the event typ and
+// the status code.
+func expect(typ string, status int) func(scheduler.Event) string {
+	return func(ev scheduler.Event) string {
+		if ev.Type != typ || ev.Execution.StatusCode != status {
+			return fmt.Sprintf(i18n.T("예상: %s %d, 실제: %s %d %s"), typ, status, ev.Type, ev.Execution.StatusCode, ev.Execution.Error)
+		}
+		return ""
+	}
+}
+
+// selftest runs the scenarios against the server listening at base, which
+// runs sched, and reports whether they all passed. The schedulers are
+// registered through the API like a client would, and their outcomes are
+// observed on the event bus. tokens are the API tokens of the server; the
+// first one authenticates the self-test when there are any.
+func selftest(base string, sched *scheduler.Scheduler, tokens map[string]string) bool {
+	var token string
+	if len(tokens) > 0 {
+		keys := make([]string, 0, len(tokens))
+		for t := range tokens {
+			keys = append(keys, t)
+		}
+		sort.Strings(keys)
+		token = keys[0]
+	}
+
+	// Route the outcomes to the scenario waiting for them, by scheduler ID.
+	var mu sync.Mutex
+	waiting := map[string]chan scheduler.Event{}
+	remove := sched.AddSubscriber(scheduler.SubscriberFunc(func(ev scheduler.Event) {
+		if ev.Type != scheduler.EventSucceeded && ev.Type != scheduler.EventFailed {
+			return
+		}
+		mu.Lock()
+		ch := waiting[ev.SchedulerID]
+		mu.Unlock()
+		if ch != nil {
+			select {
+			case ch <- ev:
+			default:
+			}
+		}
+	}))
+	defer remove()
+
+	// Suffix the IDs so that runs against persistent storage don't clash.
+	run := strconv.FormatInt(time.Now().UnixNano()%1e9, 36)
+	log.Printf(i18n.T("자체 테스트를 시작합니다: 시나리오 %d개"), len(scenarios))
+	var wg sync.WaitGroup
+	failures := make([]string, len(scenarios))
+	for i, sc := range scenarios {
+		id := "selftest-" + sc.name + "-" + run
+		ch := make(chan scheduler.Event, 1)
+		mu.Lock()
+		waiting[id] = ch
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(i int, sc scenario) {
+			defer wg.Done()
+			failures[i] = runScenario(base, token, id, sched.Now(), sc, ch)
+			if failures[i] == "" {
+				log.Printf("[PASS] %s", sc.name)
+			} else {
+				log.Printf("[FAIL] %s: %s", sc.name, failures[i])
+			}
+		}(i, sc)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, f := range failures {
+		if f != "" {
+			failed++
+		}
+	}
+	log.Printf(i18n.T("자체 테스트 결과: 통과 %d개, 실패 %d개"), len(scenarios)-failed, failed)
+	return failed == 0
+}
+
+// runScenario registers the scheduler id for sc, starting after now on
+// the scheduler's clock, waits for the outcome of its first fire time on
+// ch and deletes it. It returns why the scenario failed, or "".
+func runScenario(base, token, id string, now time.Time, sc scenario, ch <-chan scheduler.Event) string {
+	// The first fire time is one interval after the start time.
+	cfg := handler.Config{ID: id, Config: scheduler.Config{
+		StartTime:  now.Add(time.Second).Format("15:04:05"),
+		Interval:   "1s",
+		APIURL:     base + "/fake-server",
+		HTTPMethod: sc.method,
+		Payload:    strings.ReplaceAll(sc.payload, "{id}", id),
+		Retry:      sc.retry,
+	}}
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return err.Error()
+	}
+	if err := selftestRequest(http.MethodPost, base+"/schedulers", token, body); err != nil {
+		return fmt.Sprintf(i18n.T("스케줄러 등록 실패: %v"), err)
+	}
+	defer func() {
+		if err := selftestRequest(http.MethodDelete, base+"/schedulers/"+url.PathEscape(id), token, nil); err != nil {
+			log.Printf(i18n.T("자체 테스트 스케줄러 삭제 실패: %s: %v"), id, err)
+		}
+	}()
+
+	select {
+	case ev := <-ch:
+		return sc.check(ev)
+	case <-time.After(selftestTimeout):
+		return fmt.Sprintf(i18n.T("%s 안에 실행되지 않았습니다."), selftestTimeout)
+	}
+}
+
+// selftestRequest sends a request to the API and fails unless it answers
+// with a 2xx status.
+func selftestRequest(method, target, token string, body []byte) error {
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// cmd/api-scheduler/selftest_test.go
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api-scheduler/internal/handler"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// TestSelftest runs the self-test scenarios against the API and the fake
+// server of an in-process server, and checks the executions they made.
+func TestSelftest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the scenarios take a few seconds")
+	}
+	sched := scheduler.New()
+	handler.Init(sched)
+	mux := http.NewServeMux()
+	routes(mux, nil)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// Record the executions of each scenario, by its name.
+	var mu sync.Mutex
+	attempts := map[string][]scheduler.Execution{}
+	final := map[string]scheduler.Event{}
+	remove := sched.AddSubscriber(scheduler.SubscriberFunc(func(ev scheduler.Event) {
+		name, ok := strings.CutPrefix(ev.SchedulerID, "selftest-")
+		if !ok || ev.Execution == nil {
+			return
+		}
+		name = name[:strings.LastIndex(name, "-")]
+		mu.Lock()
+		defer mu.Unlock()
+		switch ev.Type {
+		case scheduler.EventExecution:
+			attempts[name] = append(attempts[name], *ev.Execution)
+		case scheduler.EventSucceeded, scheduler.EventFailed:
+			if _, ok := final[name]; !ok {
+				final[name] = ev
+			}
+		}
+	}))
+	defer remove()
+
+	if !selftest(srv.URL, sched, nil) {
+		t.Error("selftest failed")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	tests := []struct {
+		name     string
+		outcome  string
+		status   int
+		attempts []int
+	}{
+		{"success", scheduler.EventSucceeded, http.StatusOK, []int{200}},
+		{"echo", scheduler.EventSucceeded, http.StatusOK, []int{200}},
+		{"failure", scheduler.EventFailed, http.StatusServiceUnavailable, []int{503}},
+		{"retry", scheduler.EventSucceeded, http.StatusOK, []int{500, 200}},
+		{"slow", scheduler.EventSucceeded, http.StatusOK, []int{200}},
+	}
+	for _, tt := range tests {
+		ev, ok := final[tt.name]
+		if !ok {
+			t.Errorf("%s: no execution", tt.name)
+			continue
+		}
+		if ev.Type != tt.outcome || ev.Execution.StatusCode != tt.status {
+			t.Errorf("%s: %s %d, want %s %d", tt.name, ev.Type, ev.Execution.StatusCode, tt.outcome, tt.status)
+		}
+		// Later fire times may have executed before the scheduler was
+		// deleted; the first ones are those of the first fire time.
+		got := attempts[tt.name]
+		if len(got) < len(tt.attempts) {
+			t.Errorf("%s: %d attempts, want %d", tt.name, len(got), len(tt.attempts))
+			continue
+		}
+		for i, status := range tt.attempts {
+			if got[i].StatusCode != status {
+				t.Errorf("%s: attempt %d: status %d, want %d", tt.name, i+1, got[i].StatusCode, status)
+			}
+		}
+	}
+	if ev, ok := final["echo"]; ok && !strings.Contains(ev.Execution.Output, "selftest=echo") {
+		t.Errorf("echo: output %q, want the payload", ev.Execution.Output)
+	}
+	if ev, ok := final["slow"]; ok && ev.Execution.Duration < 200*time.Millisecond {
+		t.Errorf("slow: took %s, want at least 200ms", ev.Execution.Duration)
+	}
+
+	// The self-test deletes its schedulers.
+	if list := sched.List(); len(list) != 0 {
+		t.Errorf("%d schedulers left after the self-test", len(list))
+	}
+}
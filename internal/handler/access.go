@@ -0,0 +1,196 @@
+// internal/handler/access.go
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-api-scheduler/internal/logger"
+)
+
+// defaultAccessSkip are the paths not logged by default: the probes are
+// called every few seconds.
+var defaultAccessSkip = []string{"/healthz", "/readyz"}
+
+// AccessLogConfig selects the requests written to the access log.
+type AccessLogConfig struct {
+	// Disabled turns the access log and its metrics off.
+	Disabled bool
+	// SkipPaths are request paths, before the base path is stripped, that
+	// are counted in the metrics but not logged. Nil means /healthz and
+	// /readyz.
+	SkipPaths []string
+}
+
+// accessKey groups the requests counted in the metrics.
+type accessKey struct {
+	method string
+	status int
+}
+
+// accessStats are the request counters exported by MetricsHandler.
+var accessStats = struct {
+	sync.Mutex
+	count    map[accessKey]int64
+	duration map[accessKey]float64
+}{count: map[accessKey]int64{}, duration: map[accessKey]float64{}}
+
+// AccessLog returns next with every request written to the access log of
+// the logger, apart from the log tail of the schedulers, and counted in
+// the API request metrics.
+func AccessLog(next http.Handler, c AccessLogConfig) http.Handler {
+	if c.Disabled {
+		return next
+	}
+	skip := c.SkipPaths
+	if skip == nil {
+		skip = defaultAccessSkip
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		elapsed := time.Since(start)
+
+		method := r.Method
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		default:
+			// Unknown methods would grow the metrics without bound.
+			method = "OTHER"
+		}
+		key := accessKey{method, rec.status}
+		accessStats.Lock()
+		accessStats.count[key]++
+		accessStats.duration[key] += elapsed.Seconds()
+		accessStats.Unlock()
+
+		if slices.Contains(skip, r.URL.Path) {
+			return
+		}
+		logger.AddAccess(logger.AccessEntry{
+			Timestamp:  start,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     rec.status,
+			Bytes:      rec.bytes,
+			Duration:   float64(elapsed.Microseconds()) / 1000,
+			Caller:     tokenUser(r),
+			RemoteAddr: clientIP(r, false),
+		})
+	})
+}
+
+// statusRecorder captures the status and body size of a response. It
+// passes hijacking on for the WebSocket upgrade.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *statusRecorder) ReadFrom(src io.Reader) (int64, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := io.Copy(r.ResponseWriter, src)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(r.ResponseWriter).Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// writeAccessMetrics writes the API request counters in the Prometheus
+// text format.
+func writeAccessMetrics(w io.Writer) {
+	accessStats.Lock()
+	keys := make([]accessKey, 0, len(accessStats.count))
+	for k := range accessStats.count {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+	var count strings.Builder
+	var duration strings.Builder
+	for _, k := range keys {
+		labels := fmt.Sprintf("{method=%q,status=%q}", k.method, strconv.Itoa(k.status))
+		fmt.Fprintf(&count, "api_scheduler_api_requests_total%s %d\n", labels, accessStats.count[k])
+		fmt.Fprintf(&duration, "api_scheduler_api_request_duration_seconds_total%s %g\n", labels, accessStats.duration[k])
+	}
+	accessStats.Unlock()
+
+	fmt.Fprintf(w, "# HELP api_scheduler_api_requests_total Management API requests handled.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_api_requests_total counter\n")
+	io.WriteString(w, count.String())
+	fmt.Fprintf(w, "# HELP api_scheduler_api_request_duration_seconds_total Time spent handling management API requests.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_api_request_duration_seconds_total counter\n")
+	io.WriteString(w, duration.String())
+	fmt.Fprintf(w, "# HELP api_scheduler_api_rate_limited_total Management API requests rejected by the rate limit.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_api_rate_limited_total counter\n")
+	fmt.Fprintf(w, "api_scheduler_api_rate_limited_total %d\n", rateLimited.Load())
+}
+
+// AccessLogsHandler returns the kept access log entries, oldest first. The
+// limit parameter keeps only as many of the newest.
+func AccessLogsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := intParam(r.URL.Query(), "limit")
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "limit 파라미터가 올바르지 않습니다.", nil)
+		return
+	}
+	entries := logger.AccessLogs()
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	if entries == nil {
+		entries = []logger.AccessEntry{}
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
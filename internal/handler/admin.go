@@ -0,0 +1,96 @@
+// internal/handler/admin.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ReloadResult is the response of POST /admin/reload.
+type ReloadResult struct {
+	// Applied are the changed settings that took effect, by their path in
+	// the config file.
+	Applied []string `json:"applied"`
+	// RestartRequired are the changed settings that only take effect when
+	// the server restarts.
+	RestartRequired []string `json:"restartRequired"`
+}
+
+// reloader re-reads the server config and applies it. It is set once by
+// SetReloader before the server starts.
+var reloader func() (ReloadResult, error)
+
+// SetReloader sets the function called by AdminReloadHandler.
+func SetReloader(f func() (ReloadResult, error)) {
+	reloader = f
+}
+
+// AdminReloadHandler handles POST /admin/reload, which re-reads the config
+// file and applies it without restarting, so the schedulers keep running.
+// Only users with RoleAdmin may call it. A config that can't be read or
+// applied is rejected as a whole and the running one is kept.
+func AdminReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := admin(w, r); !ok {
+		return
+	}
+	if reloader == nil {
+		writeError(w, r, http.StatusNotImplemented, CodeReloadFailed, "설정 파일 없이 실행 중이라 다시 읽을 수 없습니다.", nil)
+		return
+	}
+	result, err := reloader()
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, CodeReloadFailed, "설정을 다시 불러오지 못했습니다.", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// MaintenanceRequest is the body of PUT /admin/maintenance.
+type MaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
+
+// MaintenanceHandler handles GET /admin/maintenance, which returns the
+// maintenance mode so clients can tell why nothing executes.
+func MaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, sched.Maintenance())
+}
+
+// SetMaintenanceHandler handles PUT /admin/maintenance, which enables or
+// lifts maintenance mode. Only users with RoleAdmin may call it.
+func SetMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := admin(w, r)
+	if !ok {
+		return
+	}
+	var req MaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sched.SetMaintenance(req.Enabled, req.Reason, user))
+}
+
+// MaintenanceGuard returns next with the management writes rejected while
+// the scheduler is in maintenance mode, so nothing is started or changed
+// until it is lifted. Reads, the admin endpoints, approval decisions on
+// executions already running and the fake server keep working.
+func MaintenanceGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, basePath)
+		m := sched.Maintenance()
+		if !m.Enabled || strings.HasPrefix(path, "/admin/") || strings.HasPrefix(path, "/executions/") || path == "/fake-server" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		writeError(w, r, http.StatusServiceUnavailable, CodeMaintenance, "점검 모드라 변경 요청을 처리할 수 없습니다.", m)
+	})
+}
@@ -0,0 +1,60 @@
+// internal/handler/approval.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ApprovalRequest is the optional body of the approve and reject
+// endpoints.
+type ApprovalRequest struct {
+	// Step names the step to decide when several steps of the execution
+	// wait for approval.
+	Step    string `json:"step,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ApprovalsHandler returns the workflow steps waiting for approval.
+func ApprovalsHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, sched.Approvals())
+}
+
+// ApproveHandler approves the workflow step of the execution in the path
+// that waits for approval.
+func ApproveHandler(w http.ResponseWriter, r *http.Request) {
+	decide(w, r, true)
+}
+
+// RejectHandler rejects the workflow step of the execution in the path
+// that waits for approval.
+func RejectHandler(w http.ResponseWriter, r *http.Request) {
+	decide(w, r, false)
+}
+
+// decide approves or rejects, as the caller, a step of the execution in
+// the path and returns the step's Approval. Anonymous callers can't, so
+// that every decision records who made it.
+func decide(w http.ResponseWriter, r *http.Request, approve bool) {
+	user, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	if user == "" {
+		writeError(w, r, http.StatusUnauthorized, CodeUnauthorized, "API 토큰이 필요합니다.", nil)
+		return
+	}
+	var req ApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeBodyError(w, r, err)
+		return
+	}
+	approval, err := sched.Decide(r.PathValue("id"), req.Step, user, approve, req.Comment)
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, approval)
+}
@@ -0,0 +1,101 @@
+// internal/handler/auth.go
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// RoleAdmin is the role of the users allowed to call the admin endpoints.
+const RoleAdmin = "admin"
+
+// auth holds the API tokens of the server config, mapped to their users,
+// and the roles of those users. They are replaced when the config is
+// reloaded.
+var auth struct {
+	sync.RWMutex
+	tokens map[string]string
+	roles  map[string]string
+}
+
+// SetTokens sets the API tokens that identify callers, mapped to their
+// users. Schedulers created by a caller are owned by its user. Requests
+// without a token are anonymous.
+func SetTokens(t map[string]string) {
+	auth.Lock()
+	auth.tokens = t
+	auth.Unlock()
+}
+
+// SetRoles sets the roles of the token users. Users with RoleAdmin may
+// call the admin endpoints.
+func SetRoles(roles map[string]string) {
+	auth.Lock()
+	auth.roles = roles
+	auth.Unlock()
+}
+
+// lookupToken returns the user of an API token.
+func lookupToken(token string) (string, bool) {
+	auth.RLock()
+	defer auth.RUnlock()
+	user, ok := auth.tokens[strings.TrimSpace(token)]
+	return user, ok
+}
+
+// tokenUser returns the user of the bearer token of r, or "" when it has
+// no known token.
+func tokenUser(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	user, _ := lookupToken(token)
+	return user
+}
+
+// caller returns the user identified by the bearer token of r, or "" for
+// an anonymous request. It writes the error response and reports false
+// when the token is unknown.
+func caller(w http.ResponseWriter, r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return "", true
+	}
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	user, known := lookupToken(token)
+	if !ok || !known {
+		writeError(w, r, http.StatusUnauthorized, CodeUnauthorized, "알 수 없는 API 토큰입니다.", nil)
+		return "", false
+	}
+	return user, true
+}
+
+// isAdmin reports whether user has RoleAdmin.
+func isAdmin(user string) bool {
+	if user == "" {
+		return false
+	}
+	auth.RLock()
+	defer auth.RUnlock()
+	return auth.roles[user] == RoleAdmin
+}
+
+// admin returns the user of r if it has RoleAdmin. It writes the error
+// response and reports false otherwise.
+func admin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	user, ok := caller(w, r)
+	if !ok {
+		return "", false
+	}
+	if user == "" {
+		writeError(w, r, http.StatusUnauthorized, CodeUnauthorized, "API 토큰이 필요합니다.", nil)
+		return "", false
+	}
+	if !isAdmin(user) {
+		writeError(w, r, http.StatusForbidden, CodeForbidden, "관리자 권한이 필요합니다.", nil)
+		return "", false
+	}
+	return user, true
+}
@@ -0,0 +1,85 @@
+// internal/handler/batch.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// maxBatchOps bounds the operations of one batch request.
+const maxBatchOps = 100
+
+// batchRequest is the body of a batch request.
+type batchRequest struct {
+	Operations []scheduler.BatchOp `json:"operations"`
+}
+
+// batchItem is the result of one operation of a batch, with the status
+// the operation would have gotten on its own.
+type batchItem struct {
+	Op      string         `json:"op"`
+	ID      string         `json:"id,omitempty"`
+	Status  int            `json:"status"`
+	Message string         `json:"message,omitempty"`
+	Error   *ErrorResponse `json:"error,omitempty"`
+}
+
+// batchResponse is the body of a batch response.
+type batchResponse struct {
+	// Aborted reports that no operation was executed because one of them
+	// was invalid.
+	Aborted bool        `json:"aborted"`
+	Results []batchItem `json:"results"`
+}
+
+// BatchHandler starts and stops several schedulers in one request and
+// returns the result of every operation, in order. It answers 200 OK when
+// all of them succeeded and 207 Multi-Status otherwise.
+func BatchHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	if len(req.Operations) == 0 {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "operations가 비어 있습니다.", nil)
+		return
+	}
+	if len(req.Operations) > maxBatchOps {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "operations가 너무 많습니다.", maxBatchOps)
+		return
+	}
+
+	for _, op := range req.Operations {
+		if op.Config != nil {
+			op.Config.Owner = owner
+		}
+	}
+	results, err := sched.Batch(req.Operations)
+	resp := batchResponse{Aborted: err != nil, Results: make([]batchItem, 0, len(results))}
+	status := http.StatusOK
+	for _, res := range results {
+		item := batchItem{Op: res.Op, ID: res.ID}
+		switch {
+		case res.Err != nil:
+			var e ErrorResponse
+			item.Status, e = schedulerError(r, res.Err)
+			item.Error = &e
+			status = http.StatusMultiStatus
+		case res.Op == scheduler.BatchStart:
+			item.Status, item.Message = http.StatusCreated, translate(r, "스케줄러가 시작되었습니다.")
+		case res.Stopped:
+			item.Status, item.Message = http.StatusOK, translate(r, "스케줄러가 중지되었습니다.")
+		default:
+			item.Status, item.Message = http.StatusOK, translate(r, "스케줄러가 이미 중지되었습니다.")
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	writeJSON(w, status, resp)
+}
@@ -0,0 +1,84 @@
+// internal/handler/clock.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// virtualClock is the clock of the scheduler when the server was started
+// with clock control for tests, nil otherwise. It is set once by
+// SetVirtualClock before the server starts, and the clock endpoints are
+// only registered then.
+var virtualClock *scheduler.VirtualClock
+
+// SetVirtualClock enables the clock endpoints, which move c.
+func SetVirtualClock(c *scheduler.VirtualClock) {
+	virtualClock = c
+}
+
+// ClockResponse is the response of the clock endpoints.
+type ClockResponse struct {
+	Now time.Time `json:"now"`
+	// Offset is how far the clock is ahead of the system clock, as a Go
+	// duration string.
+	Offset string `json:"offset"`
+}
+
+// AdvanceClockRequest is the body of POST /admin/clock/advance. Exactly
+// one of its fields is set.
+type AdvanceClockRequest struct {
+	// Duration moves the clock forward by a Go duration, e.g. "24h".
+	Duration string `json:"duration,omitempty"`
+	// Until moves the clock forward to an RFC 3339 time.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// ClockHandler handles GET /admin/clock, which returns the time of the
+// scheduler's clock.
+func ClockHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, clockResponse(virtualClock.Now()))
+}
+
+// AdvanceClockHandler handles POST /admin/clock/advance, which moves the
+// scheduler's clock forward so tests can check schedules without waiting.
+// Fire times passed by the jump are missed fire times, handled by the
+// misfire policy of each scheduler. Only users with RoleAdmin may call it.
+func AdvanceClockHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := admin(w, r); !ok {
+		return
+	}
+	var req AdvanceClockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	var d time.Duration
+	switch {
+	case req.Duration != "" && req.Until == nil:
+		var err error
+		d, err = time.ParseDuration(req.Duration)
+		if err != nil || d < 0 {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "duration은 0 이상의 Go duration이어야 합니다.", req.Duration)
+			return
+		}
+	case req.Until != nil && req.Duration == "":
+		d = req.Until.Sub(virtualClock.Now())
+		if d < 0 {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "시계를 과거로 되돌릴 수 없습니다.", req.Until)
+			return
+		}
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "duration과 until 중 하나만 지정해야 합니다.", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, clockResponse(virtualClock.Advance(d)))
+}
+
+// clockResponse returns the response for the virtual clock showing now.
+func clockResponse(now time.Time) ClockResponse {
+	return ClockResponse{Now: now.Round(0), Offset: virtualClock.Offset().String()}
+}
@@ -0,0 +1,104 @@
+// internal/handler/cors.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Defaults of CORSConfig.
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", "Accept-Language", "Idempotency-Key"}
+	// corsExposed are the response headers of the API that browsers hide
+	// from cross-origin callers unless they are exposed.
+	corsExposed = []string{"Location", "X-Scheduler-Id", "X-Total-Count", "Content-Disposition", "Idempotent-Replayed"}
+)
+
+// CORSConfig selects the browser origins allowed to call the API.
+type CORSConfig struct {
+	// AllowedOrigins are origins such as "https://admin.example.com", or
+	// "*" for any origin.
+	AllowedOrigins []string
+	// AllowedMethods and AllowedHeaders are answered to preflight
+	// requests. Empty means GET, POST, PUT and DELETE, and Content-Type,
+	// Authorization, Accept-Language and Idempotency-Key.
+	AllowedMethods []string
+	AllowedHeaders []string
+	// AllowCredentials lets browsers send cookies and Authorization
+	// headers. The allowed origin is then always echoed instead of "*".
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response, in
+	// seconds. Zero leaves it to the browser.
+	MaxAge int
+}
+
+// allows reports whether origin may call the API.
+func (c CORSConfig) allows(origin string) bool {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS returns next with Cross-Origin Resource Sharing headers added for
+// the origins allowed by c. It answers preflight requests itself. With no
+// allowed origins it returns next as is.
+func CORS(next http.Handler, c CORSConfig) http.Handler {
+	if len(c.AllowedOrigins) == 0 {
+		return next
+	}
+	methods, headers := c.AllowedMethods, c.AllowedHeaders
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	wildcard := !c.AllowCredentials && len(c.AllowedOrigins) == 1 && c.AllowedOrigins[0] == "*"
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !c.allows(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if wildcard {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			h.Set("Access-Control-Expose-Headers", strings.Join(corsExposed, ", "))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		h.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		if c.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
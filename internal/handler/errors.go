@@ -0,0 +1,103 @@
+// internal/handler/errors.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// Error codes returned in the code field of error responses. They are
+// stable, so clients can branch on them instead of on messages.
+const (
+	CodeInvalidBody          = "INVALID_BODY"
+	CodeInvalidParameter     = "INVALID_PARAMETER"
+	CodeInvalidConfig        = "INVALID_CONFIG"
+	CodeUnknownJobType       = "UNKNOWN_JOB_TYPE"
+	CodeSchedulerNotFound    = "SCHEDULER_NOT_FOUND"
+	CodeDuplicateID          = "DUPLICATE_ID"
+	CodeVersionNotFound      = "VERSION_NOT_FOUND"
+	CodeTemplateNotFound     = "TEMPLATE_NOT_FOUND"
+	CodeDuplicateTemplate    = "DUPLICATE_TEMPLATE"
+	CodeVariableNotFound     = "VARIABLE_NOT_FOUND"
+	CodeDuplicateVariable    = "DUPLICATE_VARIABLE"
+	CodeHistoryUnavailable   = "HISTORY_UNAVAILABLE"
+	CodeInvalidState         = "INVALID_STATE"
+	CodeBatchAborted         = "BATCH_ABORTED"
+	CodeLimitExceeded        = "LIMIT_EXCEEDED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	CodeReloadFailed         = "RELOAD_FAILED"
+	CodeMaintenance          = "MAINTENANCE"
+	CodeApprovalNotFound     = "APPROVAL_NOT_FOUND"
+	CodeInternal             = "INTERNAL_ERROR"
+)
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// writeError writes an error response in the language negotiated for r.
+// Error details are written as their localized message.
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string, details any) {
+	writeJSON(w, status, errorResponse(r, code, message, details))
+}
+
+// errorResponse returns an error response in the language negotiated for r.
+func errorResponse(r *http.Request, code, message string, details any) ErrorResponse {
+	lang := language(r)
+	if err, ok := details.(error); ok {
+		details = i18n.Localize(err, lang)
+	}
+	return ErrorResponse{Code: code, Message: i18n.Translate(lang, message), Details: details}
+}
+
+// writeSchedulerError maps an error returned by the scheduler manager to a
+// response.
+func writeSchedulerError(w http.ResponseWriter, r *http.Request, err error) {
+	status, resp := schedulerError(r, err)
+	writeJSON(w, status, resp)
+}
+
+// schedulerError maps an error returned by the scheduler manager to a
+// status and an error response.
+func schedulerError(r *http.Request, err error) (int, ErrorResponse) {
+	switch {
+	case errors.Is(err, scheduler.ErrNotFound):
+		return http.StatusNotFound, errorResponse(r, CodeSchedulerNotFound, "존재하지 않는 스케줄러 ID입니다.", nil)
+	case errors.Is(err, scheduler.ErrVersionNotFound):
+		return http.StatusNotFound, errorResponse(r, CodeVersionNotFound, "존재하지 않는 버전입니다.", nil)
+	case errors.Is(err, scheduler.ErrAlreadyExists):
+		return http.StatusConflict, errorResponse(r, CodeDuplicateID, "이미 존재하는 스케줄러 ID입니다.", nil)
+	case errors.Is(err, scheduler.ErrUnknownJobType):
+		return http.StatusBadRequest, errorResponse(r, CodeUnknownJobType, "지원하지 않는 작업 유형입니다.", nil)
+	case errors.Is(err, scheduler.ErrInvalidConfig):
+		return http.StatusBadRequest, errorResponse(r, CodeInvalidConfig, "잘못된 스케줄러 설정입니다.", err)
+	case errors.Is(err, scheduler.ErrInvalidState):
+		return http.StatusConflict, errorResponse(r, CodeInvalidState, "현재 상태에서 허용되지 않는 요청입니다.", err)
+	case errors.Is(err, scheduler.ErrNoHistory):
+		return http.StatusNotImplemented, errorResponse(r, CodeHistoryUnavailable, "실행 이력을 저장하는 저장소가 설정되지 않았습니다.", nil)
+	case errors.Is(err, scheduler.ErrLimitExceeded):
+		return http.StatusForbidden, errorResponse(r, CodeLimitExceeded, "스케줄러 한도를 초과했습니다.", err)
+	case errors.Is(err, scheduler.ErrApprovalNotFound):
+		return http.StatusNotFound, errorResponse(r, CodeApprovalNotFound, "승인을 기다리는 단계가 없습니다.", nil)
+	case errors.Is(err, scheduler.ErrNotApprover):
+		return http.StatusForbidden, errorResponse(r, CodeForbidden, "승인 권한이 없습니다.", err)
+	case errors.Is(err, scheduler.ErrBatchAborted):
+		return http.StatusFailedDependency, errorResponse(r, CodeBatchAborted, "배치의 다른 작업이 유효하지 않아 실행하지 않았습니다.", nil)
+	default:
+		return http.StatusInternalServerError, errorResponse(r, CodeInternal, "내부 오류가 발생했습니다.", err)
+	}
+}
+
+// writeBodyError writes the response for a request body that can't be decoded.
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, r, http.StatusBadRequest, CodeInvalidBody, "잘못된 요청 본문입니다.", err)
+}
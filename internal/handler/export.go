@@ -0,0 +1,341 @@
+// internal/handler/export.go
+package handler
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// exportColumn is a column of an execution export.
+type exportColumn struct {
+	name  string
+	value func(e scheduler.Execution) any
+}
+
+// exportColumns are the columns an execution export can select, in their
+// default order. Output is only exported when selected.
+var exportColumns = []exportColumn{
+	{"startedAt", func(e scheduler.Execution) any { return e.StartedAt }},
+	{"id", func(e scheduler.Execution) any { return e.ID }},
+	{"durationMs", func(e scheduler.Execution) any { return e.Duration.Milliseconds() }},
+	{"success", func(e scheduler.Execution) any { return e.Success }},
+	{"statusCode", func(e scheduler.Execution) any {
+		if e.StatusCode == 0 {
+			return nil
+		}
+		return e.StatusCode
+	}},
+	{"error", func(e scheduler.Execution) any { return e.Error }},
+	{"errorCode", func(e scheduler.Execution) any { return e.ErrorCode }},
+	{"triggeredBy", func(e scheduler.Execution) any { return e.TriggeredBy }},
+	{"output", func(e scheduler.Execution) any { return e.Output }},
+}
+
+// ExportExecutionsHandler downloads the execution history of the scheduler
+// in the path as CSV or as an Excel workbook, given by the "format"
+// parameter. The "columns" parameter selects and orders the columns, and
+// "from" and "to", in RFC 3339, bound the start times.
+func ExportExecutionsHandler(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	format := params.Get("format")
+	switch format {
+	case "":
+		format = "csv"
+	case "csv", "xlsx":
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "format 파라미터는 csv 또는 xlsx여야 합니다.", nil)
+		return
+	}
+
+	columns := exportColumns[:len(exportColumns)-1]
+	if v := params.Get("columns"); v != "" {
+		columns = nil
+		for _, name := range strings.Split(v, ",") {
+			c, ok := exportColumnNamed(strings.TrimSpace(name))
+			if !ok {
+				names := make([]string, len(exportColumns))
+				for i, c := range exportColumns {
+					names[i] = c.name
+				}
+				writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "columns 파라미터에 알 수 없는 열이 있습니다.", names)
+				return
+			}
+			columns = append(columns, c)
+		}
+	}
+
+	var from, to time.Time
+	for name, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		v := params.Get(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "from과 to 파라미터는 RFC 3339 시각이어야 합니다.", name)
+			return
+		}
+		*t = parsed
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "to는 from 이후여야 합니다.", nil)
+		return
+	}
+
+	executions, err := sched.Executions(r.PathValue("id"), from, to)
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+
+	header := make([]any, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	row := func(e scheduler.Execution) []any {
+		cells := make([]any, len(columns))
+		for i, c := range columns {
+			cells[i] = c.value(e)
+		}
+		return cells
+	}
+
+	filename := "api-scheduler-executions-" + time.Now().Format("20060102-150405") + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		cw := csv.NewWriter(w)
+		cw.Write(csvRecord(header))
+		for _, e := range executions {
+			if err := cw.Write(csvRecord(row(e))); err != nil {
+				return
+			}
+		}
+		cw.Flush()
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	xw, err := newXLSXWriter(w, "executions")
+	if err != nil {
+		return
+	}
+	xw.writeRow(header, xlsxStyleHeader)
+	for _, e := range executions {
+		xw.writeRow(row(e), xlsxStyleNone)
+	}
+	xw.close()
+}
+
+// exportColumnNamed returns the export column called name.
+func exportColumnNamed(name string) (exportColumn, bool) {
+	for _, c := range exportColumns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+// csvRecord formats the cells of a row as CSV fields.
+func csvRecord(cells []any) []string {
+	record := make([]string, len(cells))
+	for i, v := range cells {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case time.Time:
+			record[i] = v.Format(time.RFC3339Nano)
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		case int:
+			record[i] = strconv.Itoa(v)
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		}
+	}
+	return record
+}
+
+// Cell styles of xlsxStyles, by index.
+const (
+	xlsxStyleNone = iota
+	xlsxStyleHeader
+	xlsxStyleTime
+)
+
+// xlsxMaxCell is the most characters an Excel cell holds.
+const xlsxMaxCell = 32767
+
+// The parts of a workbook with a single sheet, apart from the sheet
+// itself.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`
+)
+
+// xlsxWriter streams a workbook with a single sheet, row by row. Strings
+// are inline rather than shared, so nothing is buffered but the row being
+// written.
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// newXLSXWriter writes the parts of a workbook before the rows of its
+// sheet, which is called name, to w.
+func newXLSXWriter(w io.Writer, name string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(name))
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escaped.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return nil, err
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+// writeRow appends a row of cells in style. Times are written as dates in
+// the local time zone, numbers and booleans as such, and anything else
+// as text.
+func (x *xlsxWriter) writeRow(cells []any, style int) error {
+	x.rows++
+	n := strconv.Itoa(x.rows)
+	x.sheet.WriteString(`<row r="` + n + `">`)
+	for i, v := range cells {
+		if v == nil {
+			continue
+		}
+		ref := xlsxColumn(i) + n
+		s := style
+		var typ, value string
+		switch v := v.(type) {
+		case time.Time:
+			if s == xlsxStyleNone {
+				s = xlsxStyleTime
+			}
+			value = strconv.FormatFloat(excelDate(v), 'f', -1, 64)
+		case int:
+			value = strconv.Itoa(v)
+		case int64:
+			value = strconv.FormatInt(v, 10)
+		case bool:
+			typ, value = "b", "0"
+			if v {
+				value = "1"
+			}
+		case string:
+			if v == "" {
+				continue
+			}
+			typ = "inlineStr"
+		}
+		x.sheet.WriteString(`<c r="` + ref + `"`)
+		if s != xlsxStyleNone {
+			x.sheet.WriteString(` s="` + strconv.Itoa(s) + `"`)
+		}
+		if typ != "" {
+			x.sheet.WriteString(` t="` + typ + `"`)
+		}
+		x.sheet.WriteString(">")
+		if text, ok := v.(string); ok {
+			if len([]rune(text)) > xlsxMaxCell {
+				text = string([]rune(text)[:xlsxMaxCell])
+			}
+			x.sheet.WriteString(`<is><t xml:space="preserve">`)
+			xml.EscapeText(x.sheet, []byte(text))
+			x.sheet.WriteString(`</t></is>`)
+		} else {
+			x.sheet.WriteString("<v>" + value + "</v>")
+		}
+		x.sheet.WriteString("</c>")
+	}
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+// close ends the sheet and the workbook.
+func (x *xlsxWriter) close() error {
+	x.sheet.WriteString("</sheetData></worksheet>")
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
+
+// xlsxColumn returns the letters of the column with index i, from 0.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// excelDate returns t in the local time zone as an Excel date serial:
+// days since 1899-12-30.
+func excelDate(t time.Time) float64 {
+	t = t.Local()
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+}
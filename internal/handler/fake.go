@@ -0,0 +1,66 @@
+// internal/handler/fake.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxFakeDelay bounds the delay parameter of the fake server.
+const maxFakeDelay = 30 * time.Second
+
+// fakeCalls counts the requests to the fake server by their key parameter,
+// for the fail parameter.
+var fakeCalls = struct {
+	sync.Mutex
+	count map[string]int
+}{count: map[string]int{}}
+
+// fakeScript is how the fake server answers a request, scripted by its
+// query parameters so that tests can provoke failures:
+//
+//   - status: the status code of the response, 200 by default.
+//   - delay: a Go duration to wait before answering, at most 30s.
+//   - fail and key: answer the first fail requests with the same key
+//     with 500, e.g. to exercise retries.
+type fakeScript struct {
+	status int
+	delay  time.Duration
+}
+
+// parseFakeScript reads the script of r and counts it for its key. It
+// returns the name of an invalid parameter, if any.
+func parseFakeScript(r *http.Request) (fakeScript, string) {
+	q := r.URL.Query()
+	script := fakeScript{status: http.StatusOK}
+	if v := q.Get("status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil || status < 100 || status > 599 {
+			return script, "status"
+		}
+		script.status = status
+	}
+	if v := q.Get("delay"); v != "" {
+		delay, err := time.ParseDuration(v)
+		if err != nil || delay < 0 || delay > maxFakeDelay {
+			return script, "delay"
+		}
+		script.delay = delay
+	}
+	if v := q.Get("fail"); v != "" {
+		fail, err := strconv.Atoi(v)
+		if err != nil || fail < 0 {
+			return script, "fail"
+		}
+		fakeCalls.Lock()
+		fakeCalls.count[q.Get("key")]++
+		n := fakeCalls.count[q.Get("key")]
+		fakeCalls.Unlock()
+		if n <= fail {
+			script.status = http.StatusInternalServerError
+		}
+	}
+	return script, ""
+}
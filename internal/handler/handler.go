@@ -2,84 +2,608 @@
 package handler
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"go-api-scheduler/internal/logger"
-	"go-api-scheduler/internal/scheduler"
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
 )
 
 // Config holds the user's scheduler configuration.
 type Config struct {
-	ID          string `json:"id"`
-	StartTime   string `json:"startTime"`
-	RepeatValue int    `json:"repeatValue"`
-	RepeatUnit  string `json:"repeatUnit"`
-	APIURL      string `json:"apiURL"`
-	HTTPMethod  string `json:"httpMethod"`
-	Payload     string `json:"payload"`
+	ID string `json:"id"`
+	scheduler.Config
 }
 
-// Init initializes the handler package.
-func Init() {
-	// Empty for now, but good practice for future initialization.
+// UnmarshalJSON decodes the ID and the scheduler config, which would
+// otherwise take over decoding with its own UnmarshalJSON.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var id struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	c.ID = id.ID
+	return json.Unmarshal(data, &c.Config)
+}
+
+// sched is the scheduler manager used by the handlers.
+var sched *scheduler.Scheduler
+
+// Init initializes the handler package with the scheduler manager to control.
+func Init(s *scheduler.Scheduler) {
+	sched = s
 }
 
-// StartHandler handles the request to start a scheduler.
+// language returns the language of the response to r, negotiated from its
+// Accept-Language header.
+func language(r *http.Request) string {
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// translate returns msg in the language of the response to r.
+func translate(r *http.Request, msg string) string {
+	return i18n.Translate(language(r), msg)
+}
+
+// StartHandler handles the request to start a scheduler. A new ID is
+// generated when the request has none.
 func StartHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := startScheduler(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("X-Scheduler-Id", id)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(translate(r, "스케줄러가 시작되었습니다.")))
+}
+
+// CreateHandler starts a scheduler and returns its ID, which is generated
+// when the request has none.
+func CreateHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := startScheduler(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Location", basePath+"/schedulers/"+id)
+	writeJSON(w, http.StatusCreated, map[string]string{"id": id})
+}
+
+// startScheduler starts the scheduler in the request body, owned by the
+// caller, and returns its ID. It writes the error response and reports false on failure.
+func startScheduler(w http.ResponseWriter, r *http.Request) (string, bool) {
+	owner, ok := caller(w, r)
+	if !ok {
+		return "", false
+	}
+	var config Config
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeBodyError(w, r, err)
+		return "", false
+	}
+	if config.ID == "" {
+		config.ID = scheduler.NewID()
+	}
+	config.Owner = owner
+	if err := sched.Start(config.ID, config.Config); err != nil {
+		writeSchedulerError(w, r, err)
+		return "", false
+	}
+	return config.ID, true
+}
+
+// CloneHandler starts a copy of the scheduler in the path under the ID
+// given in the body. Other fields in the body override the copied config.
+func CloneHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := caller(w, r)
+	if !ok {
+		return
+	}
 	var config Config
-	err := json.NewDecoder(r.Body).Decode(&config)
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	if config.ID == "" {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "새 스케줄러 ID가 필요합니다.", nil)
+		return
+	}
+	config.Owner = owner
+
+	if err := sched.Clone(r.PathValue("id"), config.ID, config.Config); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"id": config.ID})
+}
+
+// DetailHandler returns the config and runtime state of the scheduler in
+// the path, with secrets masked.
+func DetailHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := sched.Status(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "잘못된 요청 본문입니다.", http.StatusBadRequest)
+		writeSchedulerError(w, r, err)
 		return
 	}
+	writeJSON(w, http.StatusOK, status)
+}
 
-	scheduler.StartScheduler(config.ID, scheduler.SchedulerConfig{
-		StartTime:   config.StartTime,
-		RepeatValue: config.RepeatValue,
-		RepeatUnit:  config.RepeatUnit,
-		APIURL:      config.APIURL,
-		HTTPMethod:  config.HTTPMethod,
-		Payload:     config.Payload,
-	})
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("스케줄러가 시작되었습니다."))
+// UpdateHandler replaces the config of the scheduler in the path and
+// restarts it.
+func UpdateHandler(w http.ResponseWriter, r *http.Request) {
+	var config scheduler.Config
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	if err := sched.Update(r.PathValue("id"), config); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러 설정이 변경되었습니다.")))
+}
+
+// VersionsHandler returns the config history of the scheduler in the path.
+func VersionsHandler(w http.ResponseWriter, r *http.Request) {
+	versions, err := sched.Versions(r.PathValue("id"))
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	if len(versions) == 0 {
+		writeSchedulerError(w, r, scheduler.ErrNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, versions)
+}
+
+// RollbackHandler restarts the scheduler in the path with the config of
+// the version in the path.
+func RollbackHandler(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "잘못된 버전 번호입니다.", nil)
+		return
+	}
+	if err := sched.Rollback(r.PathValue("id"), version); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러 설정이 복원되었습니다.")))
+}
+
+// PauseHandler pauses the scheduler in the path.
+func PauseHandler(w http.ResponseWriter, r *http.Request) {
+	if err := sched.Pause(r.PathValue("id")); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러가 일시 중지되었습니다.")))
 }
 
-// StopHandler handles the request to stop a scheduler.
+// ResumeHandler resumes the paused scheduler in the path.
+func ResumeHandler(w http.ResponseWriter, r *http.Request) {
+	if err := sched.Resume(r.PathValue("id")); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러가 다시 실행됩니다.")))
+}
+
+// StatsHandler returns execution statistics of the scheduler in the path
+// over the window given as a Go duration in the "window" parameter
+// (default 168h).
+func StatsHandler(w http.ResponseWriter, r *http.Request) {
+	window := 7 * 24 * time.Hour
+	if v := r.URL.Query().Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "window 파라미터가 올바르지 않습니다.", nil)
+			return
+		}
+		window = d
+	}
+
+	stats, err := sched.ExecutionStats(r.PathValue("id"), window)
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// UptimeHandler returns the up/down state of the monitor in the path and
+// its uptime over the last day, week and 30 days.
+func UptimeHandler(w http.ResponseWriter, r *http.Request) {
+	uptime, err := sched.Uptime(r.PathValue("id"))
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, uptime)
+}
+
+// TimelineHandler returns the executed and upcoming runs of all schedulers
+// between the "from" and "to" parameters, given in RFC 3339. The window
+// defaults to the day before and the day after now.
+func TimelineHandler(w http.ResponseWriter, r *http.Request) {
+	now := sched.Now()
+	from, to := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+	params := r.URL.Query()
+	for name, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		v := params.Get(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "from과 to 파라미터는 RFC 3339 시각이어야 합니다.", name)
+			return
+		}
+		*t = parsed
+	}
+	if !to.After(from) {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "to는 from 이후여야 합니다.", nil)
+		return
+	}
+
+	timeline, err := sched.Timeline(from, to)
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, timeline)
+}
+
+// StopHandler handles the request to stop a scheduler. Stopping a
+// scheduler that is already stopped succeeds without doing anything.
 func StopHandler(w http.ResponseWriter, r *http.Request) {
 	var reqBody map[string]string
 	err := json.NewDecoder(r.Body).Decode(&reqBody)
 	if err != nil {
-		http.Error(w, "잘못된 요청 본문입니다.", http.StatusBadRequest)
+		writeBodyError(w, r, err)
 		return
 	}
+	stop(w, r, reqBody["id"])
+}
 
-	id := reqBody["id"]
-	scheduler.StopScheduler(id)
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("스케줄러가 중지되었습니다."))
+// StopSchedulerHandler stops the scheduler in the path, like StopHandler.
+func StopSchedulerHandler(w http.ResponseWriter, r *http.Request) {
+	stop(w, r, r.PathValue("id"))
+}
+
+// stop stops the scheduler registered under id. It stays registered.
+func stop(w http.ResponseWriter, r *http.Request, id string) {
+	stopped, err := sched.Stop(id)
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	if !stopped {
+		w.Write([]byte(translate(r, "스케줄러가 이미 중지되었습니다.")))
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러가 중지되었습니다.")))
+}
+
+// RestartHandler starts the stopped scheduler in the path again.
+func RestartHandler(w http.ResponseWriter, r *http.Request) {
+	if err := sched.Restart(r.PathValue("id")); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러가 다시 시작되었습니다.")))
+}
+
+// DeleteHandler stops the scheduler in the path and deletes it with its
+// history.
+func DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if err := sched.Delete(r.PathValue("id")); err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	w.Write([]byte(translate(r, "스케줄러가 삭제되었습니다.")))
+}
+
+// ListHandler returns the status of the registered schedulers. Query
+// parameters filter, sort and page the list; the number of matches across
+// all pages is returned in the X-Total-Count header.
+func ListHandler(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := scheduler.Query{
+		Text:  params.Get("q"),
+		State: params.Get("state"),
+		Group: params.Get("group"),
+		Sort:  params.Get("sort"),
+	}
+	for _, label := range params["label"] {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "label 파라미터는 key=value 형식이어야 합니다.", nil)
+			return
+		}
+		if q.Labels == nil {
+			q.Labels = make(map[string]string)
+		}
+		q.Labels[k] = v
+	}
+	switch q.State {
+	case "", "active", scheduler.StatePending, scheduler.StateWaiting, scheduler.StateRunning, scheduler.StatePaused,
+		scheduler.StateStopping, scheduler.StateStopped, scheduler.StateFailed, scheduler.StateCompleted:
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "지원하지 않는 state 파라미터입니다.", q.State)
+		return
+	}
+	switch strings.TrimPrefix(q.Sort, "-") {
+	case "", scheduler.SortID, scheduler.SortName, scheduler.SortNextRun, scheduler.SortFailures:
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "지원하지 않는 정렬 기준입니다.", q.Sort)
+		return
+	}
+	var err error
+	if q.Limit, err = intParam(params, "limit"); err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "limit 파라미터가 올바르지 않습니다.", nil)
+		return
+	}
+	if q.Offset, err = intParam(params, "offset"); err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "offset 파라미터가 올바르지 않습니다.", nil)
+		return
+	}
+
+	page, total := sched.Search(q)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, page)
 }
 
-// LogsHandler returns the current log entries.
+// intParam returns the non-negative integer query parameter name, or 0 when
+// it is absent.
+func intParam(params url.Values, name string) (int, error) {
+	v := params.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return n, nil
+}
+
+// MetricsHandler exposes scheduler metrics in the Prometheus text format.
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	stats := sched.PoolStats()
+	list := sched.List()
+	stalled := 0
+	for _, st := range list {
+		if st.Stall != nil {
+			stalled++
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP api_scheduler_schedulers Number of registered schedulers.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_schedulers gauge\n")
+	fmt.Fprintf(w, "api_scheduler_schedulers %d\n", len(list))
+	fmt.Fprintf(w, "# HELP api_scheduler_stalled_schedulers Schedulers whose fire time passed without executing.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_stalled_schedulers gauge\n")
+	fmt.Fprintf(w, "api_scheduler_stalled_schedulers %d\n", stalled)
+	fmt.Fprintf(w, "# HELP api_scheduler_sla_violations_total SLA violations of each scheduler since it started.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_sla_violations_total counter\n")
+	for _, st := range list {
+		if st.SLA != nil {
+			fmt.Fprintf(w, "api_scheduler_sla_violations_total{id=%q,kind=%q} %d\n", st.ID, scheduler.SLADeadline, st.SLA.DeadlineMisses)
+			fmt.Fprintf(w, "api_scheduler_sla_violations_total{id=%q,kind=%q} %d\n", st.ID, scheduler.SLASuccess, st.SLA.SuccessMisses)
+		}
+	}
+	maintenance := 0
+	if sched.Maintenance().Enabled {
+		maintenance = 1
+	}
+	fmt.Fprintf(w, "# HELP api_scheduler_maintenance Whether maintenance mode skips every execution.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_maintenance gauge\n")
+	fmt.Fprintf(w, "api_scheduler_maintenance %d\n", maintenance)
+	fmt.Fprintf(w, "# HELP api_scheduler_pool_max_concurrent Maximum concurrent executions (0 = unlimited).\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_pool_max_concurrent gauge\n")
+	fmt.Fprintf(w, "api_scheduler_pool_max_concurrent %d\n", stats.MaxConcurrent)
+	fmt.Fprintf(w, "# HELP api_scheduler_pool_active Executions currently running.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_pool_active gauge\n")
+	fmt.Fprintf(w, "api_scheduler_pool_active %d\n", stats.Active)
+	fmt.Fprintf(w, "# HELP api_scheduler_pool_queued Executions waiting for a free slot.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_pool_queued gauge\n")
+	fmt.Fprintf(w, "api_scheduler_pool_queued %d\n", stats.Queued)
+	fmt.Fprintf(w, "# HELP api_scheduler_executions_total Executions finished since start.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_executions_total counter\n")
+	fmt.Fprintf(w, "api_scheduler_executions_total %d\n", stats.Completed)
+
+	clients := sched.ClientStats()
+	fmt.Fprintf(w, "# HELP api_scheduler_http_transports Distinct outbound HTTP transports.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_http_transports gauge\n")
+	fmt.Fprintf(w, "api_scheduler_http_transports %d\n", clients.Transports)
+	fmt.Fprintf(w, "# HELP api_scheduler_http_open_connections Open outbound HTTP connections.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_http_open_connections gauge\n")
+	fmt.Fprintf(w, "api_scheduler_http_open_connections %d\n", clients.OpenConns)
+	fmt.Fprintf(w, "# HELP api_scheduler_http_dialed_connections_total Outbound HTTP connections dialed.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_http_dialed_connections_total counter\n")
+	fmt.Fprintf(w, "api_scheduler_http_dialed_connections_total %d\n", clients.DialedTotal)
+	fmt.Fprintf(w, "# HELP api_scheduler_http_reused_connections_total Outbound HTTP requests served by a kept-alive connection.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_http_reused_connections_total counter\n")
+	fmt.Fprintf(w, "api_scheduler_http_reused_connections_total %d\n", clients.ReusedTotal)
+	fmt.Fprintf(w, "# HELP api_scheduler_http_requests_total Outbound HTTP requests sent.\n")
+	fmt.Fprintf(w, "# TYPE api_scheduler_http_requests_total counter\n")
+	fmt.Fprintf(w, "api_scheduler_http_requests_total %d\n", clients.RequestsTotal)
+
+	writeAccessMetrics(w)
+	writeExtractedMetrics(w, list)
+}
+
+// writeExtractedMetrics writes the metrics extracted from the responses of
+// the schedulers in list as gauges labelled with the scheduler ID, grouped
+// by name. The help text is the first one set among the schedulers.
+func writeExtractedMetrics(w io.Writer, list []scheduler.Status) {
+	var names []string
+	help := map[string]string{}
+	samples := map[string][]string{}
+	for _, st := range list {
+		for _, m := range st.Metrics {
+			if _, ok := samples[m.Name]; !ok {
+				names = append(names, m.Name)
+			}
+			if help[m.Name] == "" {
+				help[m.Name] = m.Help
+			}
+			samples[m.Name] = append(samples[m.Name], fmt.Sprintf("%s{id=%q} %s\n", m.Name, st.ID, strconv.FormatFloat(m.Value, 'g', -1, 64)))
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h := help[name]
+		if h == "" {
+			h = "Extracted from scheduler responses."
+		}
+		h = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(h)
+		fmt.Fprintf(w, "# HELP %s %s\n", name, h)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		for _, line := range samples[name] {
+			io.WriteString(w, line)
+		}
+	}
+}
+
+// LogsHandler returns the kept log entries, oldest first. Query parameters
+// select the entries after or before an ID or since a time, reverse the
+// order and limit the count; the number of matches is returned in the
+// X-Total-Count header.
 func LogsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(logger.GetLogs())
+	q, ok := logQuery(w, r)
+	if !ok {
+		return
+	}
+	page, total := logger.Search(q)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, page)
 }
 
-// FakeServerHandler handles the request for the fake server.
+// LogsDownloadHandler streams the kept log entries as an attachment, in
+// NDJSON or, with format=csv, CSV. It takes the query parameters of
+// LogsHandler.
+func LogsDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = "ndjson"
+	case "ndjson", "csv":
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "format 파라미터는 ndjson 또는 csv여야 합니다.", nil)
+		return
+	}
+	q, ok := logQuery(w, r)
+	if !ok {
+		return
+	}
+	entries, _ := logger.Search(q)
+
+	filename := "api-scheduler-logs-" + time.Now().Format("20060102-150405") + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"id", "timestamp", "message", "executionId"})
+		for _, e := range entries {
+			cw.Write([]string{strconv.FormatInt(e.ID, 10), e.Timestamp.Format(time.RFC3339Nano), e.Message, e.ExecutionID})
+		}
+		cw.Flush()
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return
+		}
+	}
+}
+
+// logQuery reads the log query parameters of r. It writes the error
+// response and reports false when they are invalid.
+func logQuery(w http.ResponseWriter, r *http.Request) (logger.Query, bool) {
+	params := r.URL.Query()
+	var q logger.Query
+	afterID, err := intParam(params, "afterId")
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "afterId 파라미터가 올바르지 않습니다.", nil)
+		return q, false
+	}
+	beforeID, err := intParam(params, "beforeId")
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "beforeId 파라미터가 올바르지 않습니다.", nil)
+		return q, false
+	}
+	q.AfterID, q.BeforeID = int64(afterID), int64(beforeID)
+	if v := params.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "since 파라미터는 RFC 3339 시각이어야 합니다.", nil)
+			return q, false
+		}
+		q.Since = since
+	}
+	q.ExecutionID = params.Get("executionId")
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		q.Reverse = true
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "order 파라미터는 asc 또는 desc여야 합니다.", nil)
+		return q, false
+	}
+	if q.Limit, err = intParam(params, "limit"); err != nil {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "limit 파라미터가 올바르지 않습니다.", nil)
+		return q, false
+	}
+	return q, true
+}
+
+// FakeServerHandler handles the request for the fake server. It echoes
+// the request body, answering as scripted by the query parameters; see
+// fakeScript.
 func FakeServerHandler(w http.ResponseWriter, r *http.Request) {
+	script, invalid := parseFakeScript(r)
+	if invalid != "" {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "가짜 서버 파라미터가 올바르지 않습니다.", invalid)
+		return
+	}
 	// Read the request body.
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, "요청 본문을 읽을 수 없습니다.", http.StatusInternalServerError)
+		writeError(w, r, http.StatusInternalServerError, CodeInternal, "요청 본문을 읽을 수 없습니다.", err)
 		return
 	}
 
 	// Set the content type to JSON.
 	w.Header().Set("Content-Type", "application/json")
+	// Tell tests moving the clock when the call arrived by the scheduler's
+	// time.
+	if virtualClock != nil {
+		w.Header().Set("X-Virtual-Time", virtualClock.Now().Round(0).Format(time.RFC3339))
+	}
+
+	select {
+	case <-time.After(script.delay):
+	case <-r.Context().Done():
+		return
+	}
 
 	// Write the received body back to the response.
+	w.WriteHeader(script.status)
 	w.Write(body)
 }
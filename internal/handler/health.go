@@ -0,0 +1,32 @@
+// internal/handler/health.go
+package handler
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ready reports whether the server takes traffic: the stored schedulers are
+// restored and it isn't shutting down.
+var ready atomic.Bool
+
+// SetReady sets whether ReadyHandler reports the server ready.
+func SetReady(r bool) {
+	ready.Store(r)
+}
+
+// HealthHandler is the liveness probe. It answers 200 OK as long as the
+// server serves requests.
+func HealthHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ReadyHandler is the readiness probe. It answers 503 Service Unavailable
+// until the server is ready and once it is shutting down.
+func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	if !ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
@@ -0,0 +1,133 @@
+// internal/handler/ical.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+const (
+	// icalDefaultDays and icalMaxDays bound how far ahead the iCalendar
+	// feeds list runs.
+	icalDefaultDays = 30
+	icalMaxDays     = 366
+	// icalEventLength is the length of the event shown for each run.
+	icalEventLength = time.Minute
+	// icalTimeLayout is the layout of iCalendar UTC date-times.
+	icalTimeLayout = "20060102T150405Z"
+)
+
+// ICalHandler returns the upcoming runs of the scheduler in the path as an
+// iCalendar feed that calendar apps can subscribe to.
+func ICalHandler(w http.ResponseWriter, r *http.Request) {
+	writeICal(w, r, r.PathValue("id"))
+}
+
+// ICalFeedHandler returns the upcoming runs of every scheduler as a single
+// iCalendar feed.
+func ICalFeedHandler(w http.ResponseWriter, r *http.Request) {
+	writeICal(w, r, "")
+}
+
+// writeICal writes the runs of the next "days" days (default 30) of the
+// scheduler registered under id, or of every scheduler when id is empty.
+func writeICal(w http.ResponseWriter, r *http.Request, id string) {
+	days := icalDefaultDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > icalMaxDays {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "days 파라미터는 1에서 366 사이여야 합니다.", nil)
+			return
+		}
+		days = n
+	}
+
+	now := sched.Now()
+	runs, err := sched.UpcomingRuns(id, now, now.AddDate(0, 0, days))
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+
+	name := translate(r, "API 스케줄러")
+	filename := "schedulers.ics"
+	if id != "" {
+		name += " - " + id
+		if len(runs) > 0 && runs[0].Name != "" {
+			name += " - " + runs[0].Name
+		}
+		filename = "scheduler.ics"
+	}
+
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		b.WriteString(foldICal(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+	stamp := now.UTC().Format(icalTimeLayout)
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//go-api-scheduler//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:%s", escapeICal(name))
+	for _, run := range runs {
+		title := run.SchedulerID
+		if run.Name != "" {
+			title = run.Name
+		}
+		line("BEGIN:VEVENT")
+		line("UID:%s-%d@go-api-scheduler", escapeICal(run.SchedulerID), run.Start.UnixNano())
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART:%s", run.Start.UTC().Format(icalTimeLayout))
+		line("DTEND:%s", run.Start.Add(icalEventLength).UTC().Format(icalTimeLayout))
+		line("SUMMARY:%s", escapeICal(title))
+		line("DESCRIPTION:%s", escapeICal(icalDescription(r, run)))
+		if run.Group != "" {
+			line("CATEGORIES:%s", escapeICal(run.Group))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
+	w.Write([]byte(b.String()))
+}
+
+// icalDescription describes a run in the language negotiated for r.
+func icalDescription(r *http.Request, run scheduler.TimelineRun) string {
+	desc := translate(r, "스케줄러 ID") + ": " + run.SchedulerID
+	if run.Group != "" {
+		desc += "\n" + translate(r, "그룹") + ": " + run.Group
+	}
+	return desc
+}
+
+// escapeICal escapes a TEXT value as RFC 5545 requires.
+func escapeICal(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICal splits a content line into lines of at most 75 octets, starting
+// the continuations with a space. Lines are only split between runes.
+func foldICal(s string) string {
+	const max = 75
+	var b strings.Builder
+	n := 0
+	for _, c := range s {
+		size := len(string(c))
+		if n+size > max {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(c)
+		n += size
+	}
+	return b.String()
+}
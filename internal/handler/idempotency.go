@@ -0,0 +1,170 @@
+// internal/handler/idempotency.go
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyRetention is how long responses are kept for replay
+// unless SetIdempotencyRetention changes it.
+const DefaultIdempotencyRetention = 24 * time.Hour
+
+// maxIdempotencyKey bounds the length of Idempotency-Key headers.
+const maxIdempotencyKey = 255
+
+// replayedHeaders are the response headers kept for replay; the others,
+// such as the CORS headers, depend on the request.
+var replayedHeaders = []string{"Content-Type", "Location", "X-Scheduler-Id"}
+
+// idempotentResponse is the response to the first request with an
+// Idempotency-Key, replayed for the repeated ones.
+type idempotentResponse struct {
+	// fingerprint is the hash of the request body, so a key reused for a
+	// different request is detected.
+	fingerprint [sha256.Size]byte
+	// done is closed when the first request has finished. The fields
+	// below are set before.
+	done    chan struct{}
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// idempotency keeps the responses by caller and key. The entry of a key
+// whose first request is still running has an open done channel.
+var idempotency = struct {
+	sync.Mutex
+	retention time.Duration
+	responses map[string]*idempotentResponse
+}{retention: DefaultIdempotencyRetention, responses: map[string]*idempotentResponse{}}
+
+// SetIdempotencyRetention sets how long the responses to requests with an
+// Idempotency-Key are kept for replay.
+func SetIdempotencyRetention(d time.Duration) {
+	idempotency.Lock()
+	defer idempotency.Unlock()
+	idempotency.retention = d
+}
+
+// Idempotent returns next with support for the Idempotency-Key header, so
+// clients can retry requests that create resources without creating them
+// twice. The response to the first request with a key is kept for the
+// retention window and replayed, with an Idempotent-Replayed header, for
+// repeated requests of the same caller with the same key and body. A
+// repeat while the first request is running waits for it. Reusing a key
+// for a different body fails with 422. Server errors aren't kept, so the
+// request can be retried.
+func Idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKey {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "Idempotency-Key 헤더가 너무 깁니다.", nil)
+			return
+		}
+		owner, ok := caller(w, r)
+		if !ok {
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeBodyError(w, r, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := sha256.Sum256(body)
+		// The key is scoped to the caller so users can't see each other's
+		// responses.
+		scoped := owner + "\x00" + r.URL.Path + "\x00" + key
+
+		for {
+			idempotency.Lock()
+			now := time.Now()
+			for k, resp := range idempotency.responses {
+				if !resp.expires.IsZero() && now.After(resp.expires) {
+					delete(idempotency.responses, k)
+				}
+			}
+			resp, found := idempotency.responses[scoped]
+			if !found {
+				resp = &idempotentResponse{fingerprint: fingerprint, done: make(chan struct{})}
+				idempotency.responses[scoped] = resp
+			}
+			idempotency.Unlock()
+
+			if found {
+				if resp.fingerprint != fingerprint {
+					writeError(w, r, http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, "Idempotency-Key가 다른 요청에 이미 사용되었습니다.", nil)
+					return
+				}
+				select {
+				case <-resp.done:
+				case <-r.Context().Done():
+					return
+				}
+				if resp.status == 0 {
+					// The first request failed and was dropped; try again.
+					continue
+				}
+				for name, values := range resp.header {
+					w.Header()[name] = values
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(resp.status)
+				w.Write(resp.body)
+				return
+			}
+
+			rec := &responseCapture{ResponseWriter: w}
+			next(rec, r)
+			idempotency.Lock()
+			if rec.status == 0 || rec.status >= 500 {
+				delete(idempotency.responses, scoped)
+			} else {
+				resp.status = rec.status
+				resp.header = http.Header{}
+				for _, name := range replayedHeaders {
+					if values := w.Header().Values(name); len(values) > 0 {
+						resp.header[name] = values
+					}
+				}
+				resp.body = rec.body.Bytes()
+				resp.expires = time.Now().Add(idempotency.retention)
+			}
+			idempotency.Unlock()
+			close(resp.done)
+			return
+		}
+	}
+}
+
+// responseCapture copies the status and body of a response.
+type responseCapture struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (c *responseCapture) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseCapture) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
@@ -0,0 +1,56 @@
+// internal/handler/lint.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// lintResponse is the body of a lint response.
+type lintResponse struct {
+	// Valid reports whether the config would be accepted by a create.
+	Valid    bool                    `json:"valid"`
+	Error    *ErrorResponse          `json:"error,omitempty"`
+	Warnings []scheduler.LintWarning `json:"warnings"`
+}
+
+// LintHandler checks the scheduler config in the body without starting it
+// and returns whether it is valid along with warnings about likely
+// mistakes. The "dns" parameter set to true also looks up the host names
+// the scheduler calls.
+func LintHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	var opts scheduler.LintOptions
+	if v := r.URL.Query().Get("dns"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "dns 파라미터가 올바르지 않습니다.", nil)
+			return
+		}
+		opts.ResolveHosts = b
+	}
+	var config Config
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	config.Owner = owner
+
+	warnings, err := sched.Lint(r.Context(), config.Config, opts)
+	resp := lintResponse{Valid: err == nil, Warnings: make([]scheduler.LintWarning, len(warnings))}
+	if err != nil {
+		_, e := schedulerError(r, err)
+		resp.Error = &e
+	}
+	lang := language(r)
+	for i, warning := range warnings {
+		resp.Warnings[i] = warning.Localize(lang)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,10 @@
+// internal/handler/profile.go
+package handler
+
+import "net/http"
+
+// ProfilesHandler lists the environment profiles with their base URLs and
+// which one is active. Their headers and credentials are left out.
+func ProfilesHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, sched.Profiles())
+}
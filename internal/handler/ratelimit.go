@@ -0,0 +1,186 @@
+// internal/handler/ratelimit.go
+package handler
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitConfig bounds the requests of each client, identified by the
+// user of its API token or, for anonymous requests, by its IP address.
+type RateLimitConfig struct {
+	// WritesPerMinute bounds the POST, PUT, PATCH and DELETE requests of
+	// a client, such as creating schedulers. Zero means unlimited.
+	WritesPerMinute int
+	// ReadsPerMinute bounds the other requests of a client, the UI's
+	// included. Zero means unlimited.
+	ReadsPerMinute int
+	// Burst is how many requests of each kind a client may send at once
+	// before the rate applies. Zero means a minute's worth.
+	Burst int
+	// TrustForwardedFor identifies anonymous clients by the last address
+	// of X-Forwarded-For, as set by a reverse proxy in front of the
+	// server. Without a proxy it would let clients pick their address.
+	TrustForwardedFor bool
+	// ExemptUsers are token users that aren't limited.
+	ExemptUsers []string
+}
+
+// rateLimited counts the requests rejected by RateLimit, exported by
+// MetricsHandler.
+var rateLimited atomic.Int64
+
+// bucket is a token bucket holding up to burst requests, refilled at rate
+// requests per second.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter keeps a bucket for each client.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter(perMinute, burst int) *limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = perMinute
+	}
+	return &limiter{rate: float64(perMinute) / 60, burst: float64(burst), buckets: map[string]*bucket{}}
+}
+
+// allow takes a request of client from its bucket. When the bucket is
+// empty it reports false and how long until a request is allowed.
+func (l *limiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Buckets that have filled up again are the same as new ones.
+	if now.Sub(l.lastSweep) > time.Minute {
+		for c, b := range l.buckets {
+			if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+				delete(l.buckets, c)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// rateLimits are the limits applied by RateLimit, replaced by
+// SetRateLimit.
+var rateLimits atomic.Pointer[rateLimitState]
+
+// rateLimitState is a RateLimitConfig with the buckets of its clients.
+// Nil limiters don't limit.
+type rateLimitState struct {
+	config        RateLimitConfig
+	writes, reads *limiter
+	exempt        map[string]bool
+}
+
+// SetRateLimit replaces the limits applied by RateLimit. The clients
+// start with full buckets, unless c is unchanged.
+func SetRateLimit(c RateLimitConfig) {
+	if old := rateLimits.Load(); old != nil && reflect.DeepEqual(old.config, c) {
+		return
+	}
+	st := &rateLimitState{
+		config: c,
+		writes: newLimiter(c.WritesPerMinute, c.Burst),
+		reads:  newLimiter(c.ReadsPerMinute, c.Burst),
+		exempt: map[string]bool{},
+	}
+	for _, user := range c.ExemptUsers {
+		st.exempt[user] = true
+	}
+	rateLimits.Store(st)
+}
+
+// RateLimit returns next with the requests of each client limited by c,
+// or by the limits set later with SetRateLimit. Rejected requests fail
+// with 429 and a Retry-After header. Preflight requests and the probes
+// aren't limited.
+func RateLimit(next http.Handler, c RateLimitConfig) http.Handler {
+	SetRateLimit(c)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st := rateLimits.Load()
+		l := st.reads
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			l = st.writes
+		case http.MethodOptions:
+			l = nil
+		}
+		switch strings.TrimPrefix(r.URL.Path, basePath) {
+		case "/healthz", "/readyz":
+			l = nil
+		}
+		if l == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user := tokenUser(r)
+		if st.exempt[user] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := "user:" + user
+		if user == "" {
+			client = "ip:" + clientIP(r, st.config.TrustForwardedFor)
+		}
+		ok, wait := l.allow(client, time.Now())
+		if !ok {
+			rateLimited.Add(1)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, r, http.StatusTooManyRequests, CodeRateLimited, "요청이 너무 많습니다. 잠시 후 다시 시도하세요.", nil)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the IP address of the client of r, taken from the last
+// X-Forwarded-For address when forwarded is set.
+func clientIP(r *http.Request, forwarded bool) string {
+	if forwarded {
+		if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+			addrs := strings.Split(xff[len(xff)-1], ",")
+			if ip := strings.TrimSpace(addrs[len(addrs)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
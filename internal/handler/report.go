@@ -0,0 +1,15 @@
+// internal/handler/report.go
+package handler
+
+import "net/http"
+
+// LatestReportHandler returns the last summary report, or a report on the
+// period up to now when none was generated yet.
+func LatestReportHandler(w http.ResponseWriter, r *http.Request) {
+	report, err := sched.LatestReport()
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
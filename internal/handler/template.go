@@ -0,0 +1,85 @@
+// internal/handler/template.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// ListTemplatesHandler returns every scheduler template.
+func ListTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, sched.Templates())
+}
+
+// GetTemplateHandler returns the template named in the path.
+func GetTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	t, err := sched.Template(r.PathValue("name"))
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, CodeTemplateNotFound, "존재하지 않는 템플릿입니다.", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// CreateTemplateHandler creates a template. It fails if the name is taken.
+func CreateTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	var t scheduler.Template
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	if _, err := sched.Template(t.Name); err == nil {
+		writeError(w, r, http.StatusConflict, CodeDuplicateTemplate, "이미 존재하는 템플릿입니다.", nil)
+		return
+	}
+	saveTemplate(w, r, t, http.StatusCreated)
+}
+
+// UpdateTemplateHandler creates or replaces the template named in the path.
+func UpdateTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	var t scheduler.Template
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	t.Name = r.PathValue("name")
+	saveTemplate(w, r, t, http.StatusOK)
+}
+
+// DeleteTemplateHandler removes the template named in the path.
+func DeleteTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	err := sched.DeleteTemplate(r.PathValue("name"))
+	if errors.Is(err, scheduler.ErrTemplateNotFound) {
+		writeError(w, r, http.StatusNotFound, CodeTemplateNotFound, "존재하지 않는 템플릿입니다.", nil)
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, CodeInternal, "템플릿 삭제 오류가 발생했습니다.", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// saveTemplate saves t and writes it back with the given status.
+func saveTemplate(w http.ResponseWriter, r *http.Request, t scheduler.Template, status int) {
+	err := sched.SaveTemplate(t)
+	if errors.Is(err, scheduler.ErrInvalidConfig) {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidConfig, "잘못된 템플릿 설정입니다.", err)
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, CodeInternal, "템플릿 저장 오류가 발생했습니다.", err)
+		return
+	}
+	writeJSON(w, status, t)
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
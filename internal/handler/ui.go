@@ -0,0 +1,44 @@
+// internal/handler/ui.go
+package handler
+
+import (
+	"bytes"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// basePath is the URL prefix the server is hosted under, without a
+// trailing slash. It is set once by SetBasePath before the server starts.
+var basePath string
+
+// SetBasePath sets the URL prefix the server is hosted under, such as
+// "/api-scheduler", which is added to the links in responses and passed to
+// the web UI.
+func SetBasePath(p string) {
+	basePath = p
+}
+
+// basePathMeta is the tag of the web UI the base path is filled into.
+var basePathMeta = []byte(`<meta name="base-path" content="">`)
+
+// UIHandler serves the web UI from dir. The base path is filled into the
+// index page, so the UI calls the API under the same prefix.
+func UIHandler(dir string) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			files.ServeHTTP(w, r)
+			return
+		}
+		page, err := os.ReadFile(filepath.Join(dir, "index.html"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		page = bytes.Replace(page, basePathMeta, []byte(`<meta name="base-path" content="`+html.EscapeString(basePath)+`">`), 1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+}
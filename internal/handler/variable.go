@@ -0,0 +1,109 @@
+// internal/handler/variable.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// ListVariablesHandler returns every global variable, with their values
+// masked unless the caller is an admin.
+func ListVariablesHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	list := sched.Variables()
+	if !isAdmin(user) {
+		for i, v := range list {
+			list[i] = v.Masked()
+		}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// GetVariableHandler returns the variable named in the path, with its
+// value masked unless the caller is an admin.
+func GetVariableHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	v, err := sched.Variable(r.PathValue("name"))
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, CodeVariableNotFound, "존재하지 않는 변수입니다.", nil)
+		return
+	}
+	if !isAdmin(user) {
+		v = v.Masked()
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// CreateVariableHandler creates a variable. It fails if the name is taken.
+// Only admins may.
+func CreateVariableHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := admin(w, r); !ok {
+		return
+	}
+	var v scheduler.Variable
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	if _, err := sched.Variable(v.Name); err == nil {
+		writeError(w, r, http.StatusConflict, CodeDuplicateVariable, "이미 존재하는 변수입니다.", nil)
+		return
+	}
+	saveVariable(w, r, v, http.StatusCreated)
+}
+
+// UpdateVariableHandler creates or replaces the variable named in the
+// path. Only admins may.
+func UpdateVariableHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := admin(w, r); !ok {
+		return
+	}
+	var v scheduler.Variable
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	v.Name = r.PathValue("name")
+	saveVariable(w, r, v, http.StatusOK)
+}
+
+// DeleteVariableHandler removes the variable named in the path. Only
+// admins may.
+func DeleteVariableHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := admin(w, r); !ok {
+		return
+	}
+	err := sched.DeleteVariable(r.PathValue("name"))
+	if errors.Is(err, scheduler.ErrVariableNotFound) {
+		writeError(w, r, http.StatusNotFound, CodeVariableNotFound, "존재하지 않는 변수입니다.", nil)
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, CodeInternal, "변수 삭제 오류가 발생했습니다.", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// saveVariable saves v and writes it back with the given status.
+func saveVariable(w http.ResponseWriter, r *http.Request, v scheduler.Variable, status int) {
+	err := sched.SaveVariable(v)
+	if errors.Is(err, scheduler.ErrInvalidConfig) {
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "잘못된 변수 이름입니다.", err)
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, CodeInternal, "변수 저장 오류가 발생했습니다.", err)
+		return
+	}
+	writeJSON(w, status, v)
+}
@@ -0,0 +1,112 @@
+// internal/handler/workflow.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// workflowColors are the fill colors of the step states in a DOT graph.
+var workflowColors = map[string]string{
+	scheduler.StepPending:   "white",
+	scheduler.StepSucceeded: "palegreen",
+	scheduler.StepFailed:    "lightpink",
+	scheduler.StepSkipped:   "lightgray",
+}
+
+// WorkflowGraphHandler returns the step graph of the workflow scheduler in
+// the path with the outcome of its last execution. The "format" parameter
+// selects json (default), dot for Graphviz or mermaid.
+func WorkflowGraphHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "json", "dot", "mermaid":
+	default:
+		writeError(w, r, http.StatusBadRequest, CodeInvalidParameter, "format 파라미터가 올바르지 않습니다.", "json, dot, mermaid")
+		return
+	}
+	g, err := sched.WorkflowGraph(r.PathValue("id"))
+	if err != nil {
+		writeSchedulerError(w, r, err)
+		return
+	}
+
+	switch format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		fmt.Fprint(w, workflowDOT(g))
+	case "mermaid":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, workflowMermaid(g))
+	default:
+		writeJSON(w, http.StatusOK, g)
+	}
+}
+
+// workflowLabel returns the label of a node: its name, request and outcome.
+func workflowLabel(n scheduler.WorkflowNode) []string {
+	lines := []string{n.Name, n.HTTPMethod + " " + n.APIURL}
+	if n.Type != "" {
+		lines[1] = n.Type
+	}
+	outcome := n.State
+	if n.StatusCode != 0 {
+		outcome += " (" + strconv.Itoa(n.StatusCode) + ")"
+	}
+	if n.Attempts > 1 {
+		outcome += fmt.Sprintf(" ×%d", n.Attempts)
+	}
+	lines = append(lines, outcome)
+	if n.When != "" {
+		lines = append(lines, "when: "+n.When)
+	}
+	return lines
+}
+
+// workflowDOT renders g in the Graphviz DOT language.
+func workflowDOT(g scheduler.WorkflowGraph) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(g.SchedulerID))
+	b.WriteString("  rankdir=LR;\n  node [shape=box, style=\"rounded,filled\"];\n")
+	for _, n := range g.Nodes {
+		label := strings.Join(workflowLabel(n), "\n")
+		fmt.Fprintf(&b, "  %s [label=%s, fillcolor=%s];\n", strconv.Quote(n.Name), strconv.Quote(label), workflowColors[n.State])
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.Conditional {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", strconv.Quote(e.From), strconv.Quote(e.To), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// workflowMermaid renders g as a Mermaid flowchart. Nodes are numbered,
+// since step names may hold characters Mermaid doesn't accept in IDs.
+func workflowMermaid(g scheduler.WorkflowGraph) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.Name] = "n" + strconv.Itoa(i)
+		label := strings.ReplaceAll(strings.Join(workflowLabel(n), "<br/>"), `"`, "#quot;")
+		fmt.Fprintf(&b, "  %s[\"%s\"]:::%s\n", ids[n.Name], label, n.State)
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Conditional {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+	for _, state := range []string{scheduler.StepPending, scheduler.StepSucceeded, scheduler.StepFailed, scheduler.StepSkipped} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", state, workflowColors[state])
+	}
+	return b.String()
+}
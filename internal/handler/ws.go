@@ -0,0 +1,87 @@
+// internal/handler/ws.go
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+const (
+	// wsBuffer is the number of events queued for a slow connection
+	// before further events are dropped.
+	wsBuffer = 256
+	// wsWriteTimeout bounds writing one message.
+	wsWriteTimeout = 10 * time.Second
+	// wsPingInterval is how often idle connections are pinged; a
+	// connection that doesn't answer within two intervals is closed.
+	wsPingInterval = 30 * time.Second
+)
+
+// upgrader accepts WebSocket connections from the UI's own origin only.
+var upgrader = websocket.Upgrader{}
+
+// WSHandler pushes the events of every scheduler to a WebSocket
+// connection as JSON messages: log messages, lifecycle changes, and
+// finished executions without their response bodies. Clients only
+// receive; messages they send are ignored.
+func WSHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has written the error response.
+		return
+	}
+	defer conn.Close()
+
+	events := make(chan scheduler.Event, wsBuffer)
+	unsubscribe := sched.AddSubscriber(scheduler.SubscriberFunc(func(ev scheduler.Event) {
+		if ev.Execution != nil {
+			e := *ev.Execution
+			e.Output = ""
+			ev.Execution = &e
+		}
+		select {
+		case events <- ev:
+		default:
+			// Subscribers must not block; a client this far behind
+			// misses events.
+		}
+	}))
+	defer unsubscribe()
+
+	// The read loop handles pongs and notices the client going away.
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * wsPingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * wsPingInterval))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case ev := <-events:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteJSON(ev); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// internal/logger/access.go
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// AccessEntry records one request to the management API.
+type AccessEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	// Bytes is the size of the response body.
+	Bytes int64 `json:"bytes"`
+	// Duration is how long the request took, in milliseconds.
+	Duration float64 `json:"durationMs"`
+	// Caller is the user of the request's API token, empty when it is
+	// anonymous.
+	Caller     string `json:"caller,omitempty"`
+	RemoteAddr string `json:"remoteAddr"`
+}
+
+// String formats e as one access log line.
+func (e AccessEntry) String() string {
+	caller := e.Caller
+	if caller == "" {
+		caller = "-"
+	}
+	return fmt.Sprintf("%s %s \"%s %s\" %d %d %.1fms", e.RemoteAddr, caller, e.Method, e.Path, e.Status, e.Bytes, e.Duration)
+}
+
+var (
+	// access keeps the newest access log entries, apart from the log
+	// tail so API traffic doesn't push out the scheduler output. It is
+	// protected by mu.
+	access []AccessEntry
+	// accessSubscribers are called with every access log entry.
+	accessSubscribers []func(AccessEntry)
+)
+
+// maxAccessLogs is the number of access log entries kept.
+const maxAccessLogs = 100
+
+// AddAccess records an API request. The entry is kept for AccessLogs,
+// passed to the access subscribers and shipped by the forwarder, if any,
+// marked as an access log entry.
+func AddAccess(e AccessEntry) {
+	mu.Lock()
+	access = append(access, e)
+	if len(access) > maxAccessLogs {
+		access = access[1:]
+	}
+	subscribers := accessSubscribers
+	if forwarder != nil {
+		forwarder.enqueue(LogEntry{
+			Time:      e.Timestamp.Format("15:04:05"),
+			Timestamp: e.Timestamp,
+			Message:   e.String(),
+			Access:    &e,
+		})
+	}
+	mu.Unlock()
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
+
+// SubscribeAccess calls fn with every access log entry added after it.
+// fn must not block.
+func SubscribeAccess(fn func(AccessEntry)) {
+	mu.Lock()
+	defer mu.Unlock()
+	accessSubscribers = append(accessSubscribers[:len(accessSubscribers):len(accessSubscribers)], fn)
+}
+
+// AccessLogs returns the kept access log entries, oldest first.
+func AccessLogs() []AccessEntry {
+	mu.Lock()
+	defer mu.Unlock()
+	return access
+}
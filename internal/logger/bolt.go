@@ -0,0 +1,84 @@
+// internal/logger/bolt.go
+package logger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+)
+
+// BoltBackend keeps the log tail in a bbolt bucket, so it survives a
+// restart of a single instance.
+type BoltBackend struct {
+	DB *bbolt.DB
+	// Bucket holds the entries keyed by their ID as a big-endian integer.
+	// It must exist.
+	Bucket []byte
+}
+
+// boltKey returns the key of the entry with the given ID.
+func boltKey(id int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+// Append numbers the entry, adds it and drops the entries beyond max.
+func (b *BoltBackend) Append(entry *LogEntry, max int) error {
+	return b.DB.Update(func(tx *bbolt.Tx) error {
+		return b.append(tx.Bucket(b.Bucket), entry, max)
+	})
+}
+
+// append adds entry to bucket like Append.
+func (b *BoltBackend) append(bucket *bbolt.Bucket, entry *LogEntry, max int) error {
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+	entry.ID = int64(seq)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put(boltKey(entry.ID), data); err != nil {
+		return err
+	}
+	// Replaced entries leave gaps in the IDs, so the entries are counted.
+	var keys [][]byte
+	bucket.ForEach(func(k, _ []byte) error {
+		keys = append(keys, append([]byte(nil), k...))
+		return nil
+	})
+	for i := 0; i < len(keys)-max; i++ {
+		if err := bucket.Delete(keys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace removes old and adds entry like Append, in one transaction.
+func (b *BoltBackend) Replace(old LogEntry, entry *LogEntry, max int) error {
+	return b.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.Bucket)
+		if err := bucket.Delete(boltKey(old.ID)); err != nil {
+			return err
+		}
+		return b.append(bucket, entry, max)
+	})
+}
+
+// Entries returns the kept entries, oldest first.
+func (b *BoltBackend) Entries() ([]LogEntry, error) {
+	var entries []LogEntry
+	err := b.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(b.Bucket).ForEach(func(_, data []byte) error {
+			var e LogEntry
+			if err := json.Unmarshal(data, &e); err == nil {
+				entries = append(entries, e)
+			}
+			return nil
+		})
+	})
+	return entries, err
+}
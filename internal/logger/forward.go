@@ -0,0 +1,317 @@
+// internal/logger/forward.go
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Forward destinations.
+const (
+	ForwardSyslog = "syslog"
+	ForwardLoki   = "loki"
+	ForwardHTTP   = "http"
+)
+
+// forwardQueue is the number of entries waiting to be shipped before new
+// entries are dropped.
+const forwardQueue = 10000
+
+// ForwardConfig selects where a Forwarder ships log entries.
+type ForwardConfig struct {
+	// Type is ForwardSyslog, ForwardLoki or ForwardHTTP.
+	Type string
+	// URL is "udp://host:port" or "tcp://host:port" for syslog, the base
+	// URL of Loki, or the endpoint of an HTTP collector.
+	URL string
+	// Tag is the syslog APP-NAME. Empty means "api-scheduler".
+	Tag string
+	// Labels are the Loki stream labels. Empty means job="api-scheduler".
+	Labels map[string]string
+	// Headers are added to Loki and HTTP requests, e.g. for authentication.
+	Headers map[string]string
+	// BatchSize bounds the entries sent at once; FlushInterval is the
+	// longest an entry waits for a batch to fill.
+	BatchSize     int
+	FlushInterval time.Duration
+	// MaxRetries is how often a failed batch is sent again, with doubling
+	// delays starting at one second, before it is dropped.
+	MaxRetries int
+}
+
+// Forwarder ships log entries to a central logging system in the
+// background. Entries are batched, and batches that fail are retried;
+// shipping never blocks AddLog.
+type Forwarder struct {
+	config  ForwardConfig
+	send    func(ctx context.Context, batch []LogEntry) error
+	entries chan LogEntry
+	done    chan struct{}
+	// dropped counts entries lost to a full queue since the last report.
+	dropped atomic.Int64
+}
+
+// NewForwarder validates c and starts a Forwarder. Pass it to
+// SetForwarder to ship the log entries.
+func NewForwarder(c ForwardConfig) (*Forwarder, error) {
+	if c.URL == "" {
+		return nil, i18n.Errorf("logForward.url 설정이 필요합니다")
+	}
+	if c.Tag == "" {
+		c.Tag = "api-scheduler"
+	}
+	if len(c.Labels) == 0 {
+		c.Labels = map[string]string{"job": "api-scheduler"}
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = time.Second
+	}
+	f := &Forwarder{
+		config:  c,
+		entries: make(chan LogEntry, forwardQueue),
+		done:    make(chan struct{}),
+	}
+
+	switch c.Type {
+	case ForwardSyslog:
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+			return nil, i18n.Errorf("syslog 주소는 udp://host:port 또는 tcp://host:port 형식이어야 합니다: %q", c.URL)
+		}
+		f.send = (&syslogSender{network: u.Scheme, addr: u.Host, tag: c.Tag}).send
+	case ForwardLoki:
+		f.send = f.sendLoki
+	case ForwardHTTP:
+		f.send = f.sendHTTP
+	default:
+		return nil, i18n.Errorf("logForward.type은 syslog, loki, http 중 하나여야 합니다: %q", c.Type)
+	}
+	go f.run()
+	return f, nil
+}
+
+// SetForwarder makes AddLog pass every entry to f as well.
+func SetForwarder(f *Forwarder) {
+	mu.Lock()
+	defer mu.Unlock()
+	forwarder = f
+}
+
+// enqueue queues e for shipping, dropping it when the queue is full.
+func (f *Forwarder) enqueue(e LogEntry) {
+	select {
+	case f.entries <- e:
+	default:
+		f.dropped.Add(1)
+	}
+}
+
+// Close detaches the Forwarder from the logger, ships the queued entries
+// and stops it.
+func (f *Forwarder) Close() {
+	mu.Lock()
+	if forwarder == f {
+		forwarder = nil
+	}
+	mu.Unlock()
+	close(f.entries)
+	<-f.done
+}
+
+// run batches the queued entries and ships them.
+func (f *Forwarder) run() {
+	defer close(f.done)
+	ticker := time.NewTicker(f.config.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []LogEntry
+	flush := func() {
+		if len(batch) > 0 {
+			f.ship(batch)
+			batch = nil
+		}
+		if dropped := f.dropped.Swap(0); dropped > 0 {
+			log.Printf(i18n.T("로그 전송 대기열이 가득 차 로그 %d건을 버렸습니다."), dropped)
+		}
+	}
+	for {
+		select {
+		case e, ok := <-f.entries:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= f.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// ship sends batch, retrying failures with doubling delays. Failures are
+// reported through the standard logger so they don't loop back into the
+// forwarded log.
+func (f *Forwarder) ship(batch []LogEntry) {
+	delay := time.Second
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := f.send(ctx, batch)
+		cancel()
+		if err == nil {
+			return
+		}
+		if _, permanent := err.(permanentError); permanent || attempt >= f.config.MaxRetries {
+			log.Printf(i18n.T("로그 전송 오류 (%d회 시도): %v"), attempt+1, err)
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// permanentError is a rejection that retrying won't fix.
+type permanentError struct{ error }
+
+// post sends body to u with the configured headers.
+func (f *Forwarder) post(ctx context.Context, u string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range f.config.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return i18n.Errorf("로그 수집기가 요청을 거부했습니다 - 상태 코드: %d", resp.StatusCode)
+	default:
+		return permanentError{i18n.Errorf("로그 수집기가 요청을 거부했습니다 - 상태 코드: %d", resp.StatusCode)}
+	}
+}
+
+// sendHTTP posts the batch as a JSON array of entries.
+func (f *Forwarder) sendHTTP(ctx context.Context, batch []LogEntry) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return permanentError{err}
+	}
+	return f.post(ctx, f.config.URL, body)
+}
+
+// sendLoki pushes the batch through Loki's push API, the access log
+// entries in a separate stream labeled stream="access".
+func (f *Forwarder) sendLoki(ctx context.Context, batch []LogEntry) error {
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	logs := stream{Stream: f.config.Labels}
+	access := stream{Stream: map[string]string{"stream": "access"}}
+	for name, value := range f.config.Labels {
+		access.Stream[name] = value
+	}
+	for _, e := range batch {
+		value := [2]string{strconv.FormatInt(e.Timestamp.UnixNano(), 10), e.Message}
+		if e.Access != nil {
+			access.Values = append(access.Values, value)
+		} else {
+			logs.Values = append(logs.Values, value)
+		}
+	}
+	var streams []stream
+	for _, s := range []stream{logs, access} {
+		if len(s.Values) > 0 {
+			streams = append(streams, s)
+		}
+	}
+	body, err := json.Marshal(map[string][]stream{"streams": streams})
+	if err != nil {
+		return permanentError{err}
+	}
+	return f.post(ctx, strings.TrimSuffix(f.config.URL, "/")+"/loki/api/v1/push", body)
+}
+
+// syslogSender writes RFC 5424 messages with the facility local0 and the
+// severity informational, access log entries with the MSGID "access".
+// Over TCP they are framed by octet counting (RFC 6587) and the connection
+// is kept for the next batches.
+type syslogSender struct {
+	network, addr, tag string
+	conn               net.Conn
+}
+
+// send writes one message per entry.
+func (s *syslogSender) send(ctx context.Context, batch []LogEntry) error {
+	if s.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, s.network, s.addr)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	var buf bytes.Buffer
+	for _, e := range batch {
+		msgID := "-"
+		if e.Access != nil {
+			msgID = "access"
+		}
+		msg := fmt.Sprintf("<134>1 %s %s %s %d %s - %s", e.Timestamp.Format(time.RFC3339Nano), hostname, s.tag, os.Getpid(), msgID, e.Message)
+		if s.network == "tcp" {
+			fmt.Fprintf(&buf, "%d %s", len(msg), msg)
+			continue
+		}
+		if _, err := s.conn.Write([]byte(msg)); err != nil {
+			s.reset()
+			return err
+		}
+	}
+	if buf.Len() > 0 {
+		if deadline, ok := ctx.Deadline(); ok {
+			s.conn.SetWriteDeadline(deadline)
+		}
+		if _, err := s.conn.Write(buf.Bytes()); err != nil {
+			// A partially written batch is sent again in full.
+			s.reset()
+			return err
+		}
+	}
+	return nil
+}
+
+// reset drops the connection so the next batch dials again.
+func (s *syslogSender) reset() {
+	s.conn.Close()
+	s.conn = nil
+}
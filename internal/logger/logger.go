@@ -2,14 +2,33 @@
 package logger
 
 import (
+	"fmt"
+	"log"
+	"slices"
 	"sync"
 	"time"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
 )
 
 // LogEntry represents a single log message.
 type LogEntry struct {
-	Time    string `json:"time"`
-	Message string `json:"message"`
+	// ID numbers the entries in the order they were added, starting at 1.
+	ID        int64     `json:"id,omitempty"`
+	Time      string    `json:"time"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+	// ExecutionID is the ID of the run the message was logged for, if any.
+	ExecutionID string `json:"executionId,omitempty"`
+	// Repeat counts the identical failures collapsed into the entry, and
+	// FirstTimestamp is when the first of them was logged; Timestamp is
+	// the last.
+	Repeat         int        `json:"repeat,omitempty"`
+	FirstTimestamp *time.Time `json:"firstTimestamp,omitempty"`
+	// Access is set on the access log entries shipped by the forwarder.
+	// They aren't part of the log tail.
+	Access *AccessEntry `json:"access,omitempty"`
 }
 
 var (
@@ -17,8 +36,37 @@ var (
 	logs []LogEntry
 	// mu protects concurrent access to the logs.
 	mu sync.Mutex
+	// lastID is the ID of the newest in-memory entry.
+	lastID int64
+	// backend, when set, replaces the in-memory list so several instances
+	// share one log tail.
+	backend Backend
+	// forwarder, when set, ships every entry to a central logging system.
+	forwarder *Forwarder
 )
 
+// maxLogs is the number of log entries kept.
+const maxLogs = 100
+
+// Backend stores the log tail outside the process.
+type Backend interface {
+	// Append adds an entry, dropping the oldest beyond max entries. It
+	// sets the entry's ID, which must be higher than that of every entry
+	// added before.
+	Append(entry *LogEntry, max int) error
+	// Replace removes old and adds entry like Append.
+	Replace(old LogEntry, entry *LogEntry, max int) error
+	// Entries returns the kept entries, oldest first.
+	Entries() ([]LogEntry, error)
+}
+
+// SetBackend makes the logger keep its entries in b instead of in memory.
+func SetBackend(b Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	backend = b
+}
+
 // Init initializes the logger.
 func Init() {
 	// You can add initialization logic here if needed.
@@ -26,22 +74,137 @@ func Init() {
 
 // AddLog adds a new log message to the log list.
 func AddLog(message string) {
+	addLog(message, "", 0)
+}
+
+// addLog adds a log message, logged for the run executionID, that was
+// logged repeat times in a row. When
+// repeat is more than one, the newest entry with the same message is
+// replaced by one counting the repeats, which moves to the end of the list
+// so clients reading after an ID see it again.
+func addLog(message, executionID string, repeat int) {
 	mu.Lock()
 	defer mu.Unlock()
+	now := time.Now()
 	entry := LogEntry{
-		Time:    time.Now().Format("15:04:05"),
-		Message: message,
+		Time:        now.Format("15:04:05"),
+		Timestamp:   now,
+		Message:     message,
+		ExecutionID: executionID,
 	}
-	logs = append(logs, entry)
-	// Keep the log list from growing too large.
-	if len(logs) > 100 {
-		logs = logs[1:]
+	var entries []LogEntry
+	if repeat > 1 {
+		entry.Repeat = repeat
+		entries = logs
+		if backend != nil {
+			entries, _ = backend.Entries()
+		}
 	}
+	prev := -1
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Message == message {
+			prev = i
+			break
+		}
+	}
+	if prev >= 0 {
+		first := entries[prev].Timestamp
+		if entries[prev].FirstTimestamp != nil {
+			first = *entries[prev].FirstTimestamp
+		}
+		entry.FirstTimestamp = &first
+	}
+
+	if backend != nil {
+		var err error
+		if prev >= 0 {
+			err = backend.Replace(entries[prev], &entry, maxLogs)
+		} else {
+			err = backend.Append(&entry, maxLogs)
+		}
+		if err != nil {
+			log.Printf(i18n.T("로그 저장 오류: %v"), err)
+		}
+	} else {
+		if prev >= 0 {
+			// GetLogs hands out logs, so it is copied rather than changed.
+			logs = slices.Delete(slices.Clone(logs), prev, prev+1)
+		}
+		lastID++
+		entry.ID = lastID
+		logs = append(logs, entry)
+		// Keep the log list from growing too large.
+		if len(logs) > maxLogs {
+			logs = logs[1:]
+		}
+	}
+	if forwarder != nil {
+		forwarder.enqueue(entry)
+	}
+}
+
+// Attach subscribes the logger to the events of the given scheduler manager.
+func Attach(s *scheduler.Scheduler) {
+	s.Subscribe(func(ev scheduler.Event) {
+		message := ev.Message
+		// Events about the whole service, such as maintenance mode,
+		// have no scheduler.
+		if ev.SchedulerID != "" {
+			message = fmt.Sprintf("[%s] %s", ev.SchedulerID, ev.Message)
+		}
+		addLog(message, ev.ExecutionID, ev.Repeat)
+	})
 }
 
 // GetLogs returns the current log entries.
 func GetLogs() []LogEntry {
 	mu.Lock()
 	defer mu.Unlock()
+	if backend != nil {
+		entries, err := backend.Entries()
+		if err != nil {
+			log.Printf(i18n.T("로그 조회 오류: %v"), err)
+		}
+		return entries
+	}
 	return logs
 }
+
+// Query selects log entries for Search. Zero fields don't filter.
+type Query struct {
+	// AfterID and BeforeID select the entries with a higher or lower ID.
+	AfterID  int64
+	BeforeID int64
+	// Since selects the entries added at or after it.
+	Since time.Time
+	// ExecutionID selects the entries logged for a run.
+	ExecutionID string
+	// Reverse returns the newest entries first.
+	Reverse bool
+	// Limit bounds the number of entries returned; 0 returns all.
+	Limit int
+}
+
+// Search returns the entries matching q, and the number of matches before
+// the limit.
+func Search(q Query) (page []LogEntry, total int) {
+	entries := GetLogs()
+	page = make([]LogEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.ID <= q.AfterID || q.BeforeID > 0 && e.ID >= q.BeforeID || e.Timestamp.Before(q.Since) {
+			continue
+		}
+		if q.ExecutionID != "" && e.ExecutionID != q.ExecutionID {
+			continue
+		}
+		page = append(page, e)
+	}
+	if q.Reverse {
+		slices.Reverse(page)
+	}
+	total = len(page)
+	if q.Limit > 0 && len(page) > q.Limit {
+		page = page[:q.Limit]
+	}
+	return page, total
+}
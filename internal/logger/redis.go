@@ -0,0 +1,73 @@
+// internal/logger/redis.go
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend keeps the log tail in a Redis list shared by all instances.
+type RedisBackend struct {
+	Client *redis.Client
+	// Key is the list holding the entries, newest first. The last entry ID
+	// is kept under Key+":id".
+	Key string
+}
+
+// Append numbers the entry, prepends it and trims the list to max entries.
+func (b *RedisBackend) Append(entry *LogEntry, max int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	id, err := b.Client.Incr(ctx, b.Key+":id").Result()
+	if err != nil {
+		return err
+	}
+	entry.ID = id
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	pipe := b.Client.TxPipeline()
+	pipe.LPush(ctx, b.Key, data)
+	pipe.LTrim(ctx, b.Key, 0, int64(max)-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Replace removes old from the list and adds entry like Append.
+func (b *RedisBackend) Replace(old LogEntry, entry *LogEntry, max int) error {
+	data, err := json.Marshal(old)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.Client.LRem(ctx, b.Key, 1, data).Err(); err != nil {
+		return err
+	}
+	return b.Append(entry, max)
+}
+
+// Entries returns the kept entries, oldest first.
+func (b *RedisBackend) Entries() ([]LogEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	items, err := b.Client.LRange(ctx, b.Key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]LogEntry, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		var e LogEntry
+		if err := json.Unmarshal([]byte(items[i]), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
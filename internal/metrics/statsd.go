@@ -0,0 +1,159 @@
+// internal/metrics/statsd.go
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go-api-scheduler/internal/logger"
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// maxPacketSize keeps batched datagrams below a typical network MTU.
+const maxPacketSize = 1432
+
+// StatsD pushes scheduler metrics to a StatsD or DogStatsD agent over UDP.
+type StatsD struct {
+	conn   net.Conn
+	prefix string
+	// dogstatsd tags metrics instead of encoding the scheduler ID in the name.
+	dogstatsd bool
+	tags      []string
+
+	mu sync.Mutex
+	// lastCompleted is the pool's completed count at the previous flush.
+	lastCompleted int64
+}
+
+// NewStatsD creates an emitter sending to addr ("host:port"). Every metric
+// name is prefixed with prefix. With dogstatsd, tags ("key:value") are
+// added to every metric.
+func NewStatsD(addr, prefix string, dogstatsd bool, tags []string) (*StatsD, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, i18n.Errorf("StatsD 연결 오류: %w", err)
+	}
+	return &StatsD{conn: conn, prefix: prefix, dogstatsd: dogstatsd, tags: tags}, nil
+}
+
+// Close closes the UDP socket.
+func (d *StatsD) Close() error {
+	return d.conn.Close()
+}
+
+// Attach sends a timing and a success or failure count for every execution
+// of s, a count for every SLA violation, and its gauges every interval.
+func (d *StatsD) Attach(s *scheduler.Scheduler, interval time.Duration) {
+	s.SubscribeExecutions(func(e scheduler.Execution) {
+		result := "failure"
+		if e.Success {
+			result = "success"
+		}
+		d.send(
+			d.line("execution.duration", e.SchedulerID, fmt.Sprintf("%d|ms", e.Duration.Milliseconds())),
+			d.line("execution."+result, e.SchedulerID, "1|c"),
+		)
+	})
+
+	s.AddSubscriber(scheduler.SubscriberFunc(func(ev scheduler.Event) {
+		if ev.Type == scheduler.EventSLAViolated {
+			d.send(d.line("sla.violation."+ev.SLA.Kind, ev.SchedulerID, "1|c"))
+		}
+	}))
+	go func() {
+		for range time.Tick(interval) {
+			d.flushGauges(s)
+		}
+	}()
+}
+
+// AttachAccessLog sends a timing and a count by status class, such as
+// "api.requests.2xx", for every management API request in the access log.
+func (d *StatsD) AttachAccessLog() {
+	logger.SubscribeAccess(func(e logger.AccessEntry) {
+		d.send(
+			d.line("api.request.duration", "", fmt.Sprintf("%d|ms", int64(e.Duration))),
+			d.line(fmt.Sprintf("api.requests.%dxx", e.Status/100), "", "1|c"),
+		)
+	})
+}
+
+// flushGauges sends the manager-wide gauges and counters.
+func (d *StatsD) flushGauges(s *scheduler.Scheduler) {
+	pool := s.PoolStats()
+	clients := s.ClientStats()
+	list := s.List()
+	stalled := 0
+	for _, st := range list {
+		if st.Stall != nil {
+			stalled++
+		}
+	}
+
+	d.mu.Lock()
+	completed := pool.Completed - d.lastCompleted
+	d.lastCompleted = pool.Completed
+	d.mu.Unlock()
+
+	d.send(
+		d.line("schedulers", "", fmt.Sprintf("%d|g", len(list))),
+		d.line("schedulers.stalled", "", fmt.Sprintf("%d|g", stalled)),
+		d.line("pool.active", "", fmt.Sprintf("%d|g", pool.Active)),
+		d.line("pool.queued", "", fmt.Sprintf("%d|g", pool.Queued)),
+		d.line("executions", "", fmt.Sprintf("%d|c", completed)),
+		d.line("http.open_connections", "", fmt.Sprintf("%d|g", clients.OpenConns)),
+	)
+}
+
+// line formats one metric. A non-empty schedulerID becomes a tag with
+// DogStatsD and part of the name otherwise.
+func (d *StatsD) line(name, schedulerID, value string) string {
+	tags := d.tags
+	if schedulerID != "" {
+		if d.dogstatsd {
+			tags = append(tags[:len(tags):len(tags)], "scheduler:"+schedulerID)
+		} else {
+			name = "scheduler." + sanitize(schedulerID) + "." + name
+		}
+	}
+
+	l := d.prefix + name + ":" + value
+	if d.dogstatsd && len(tags) > 0 {
+		l += "|#" + strings.Join(tags, ",")
+	}
+	return l
+}
+
+// send writes lines in as few datagrams as possible. Errors are ignored,
+// as with any UDP metrics client.
+func (d *StatsD) send(lines ...string) {
+	var b strings.Builder
+	for _, l := range lines {
+		if b.Len() > 0 && b.Len()+1+len(l) > maxPacketSize {
+			d.conn.Write([]byte(b.String()))
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(l)
+	}
+	if b.Len() > 0 {
+		d.conn.Write([]byte(b.String()))
+	}
+}
+
+// sanitize replaces the characters StatsD uses as separators.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ':', '|', '@', '#', ',', ' ', '\n':
+			return '_'
+		}
+		return r
+	}, s)
+}
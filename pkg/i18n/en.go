@@ -0,0 +1,501 @@
+// pkg/i18n/en.go
+package i18n
+
+// english translates the messages into English.
+var english = map[string]string{
+	// Configuration and startup
+	"설정 파일 읽기 오류: %w":                                             "failed to read config file: %w",
+	"설정 파일 파싱 오류: %w":                                             "failed to parse config file: %w",
+	"지원하지 않는 언어입니다: %q":                                           "unsupported language: %q",
+	"http.timeout 설정 파싱 오류: %v":                                   "failed to parse http.timeout setting: %v",
+	"http.proxy 설정 오류: %v":                                        "invalid http.proxy setting: %v",
+	"storage.syncInterval 설정 오류: %q":                              "invalid storage.syncInterval setting: %q",
+	"statsd.interval 설정 오류: %q":                                   "invalid statsd.interval setting: %q",
+	"watchdog.interval 설정 오류: %q":                                 "invalid watchdog.interval setting: %q",
+	"watchdog.grace 설정 오류: %q":                                    "invalid watchdog.grace setting: %q",
+	"요약 보고서 설정 오류: %v":                                            "invalid reports setting: %v",
+	"idempotencyRetention 설정 오류: %q":                              "invalid idempotencyRetention setting: %q",
+	"auth.tokens에는 token과 user가 필요합니다.":                           "auth.tokens entries need a token and a user.",
+	"limits.minInterval 설정 오류: %s: %q":                            "invalid limits.minInterval setting: %s: %q",
+	"스케줄러 복원 오류: %v":                                              "failed to restore schedulers: %v",
+	"설정을 다시 불러왔습니다. 적용: %s, 재시작 필요: %s":                           "reloaded the config; applied: %s, restart required: %s",
+	"설정 다시 불러오기 오류: %v":                                           "failed to reload the config: %v",
+	"가상 시계로 실행합니다. 테스트 외에는 사용하지 마세요.":                             "running on a virtual clock; don't use it outside tests",
+	"스케줄러 동기화 오류: %v":                                             "failed to sync schedulers: %v",
+	"웹 서버가 http://%s%s/ 에서 실행 중입니다.":                              "Web server is running at http://%s%s/.",
+	"자체 테스트를 시작합니다: 시나리오 %d개":                                     "Starting the self-test: %d scenarios",
+	"자체 테스트 결과: 통과 %d개, 실패 %d개":                                   "Self-test result: %d passed, %d failed",
+	"자체 테스트 스케줄러 삭제 실패: %s: %v":                                   "Failed to delete self-test scheduler: %s: %v",
+	"스케줄러 등록 실패: %v":                                              "Failed to register the scheduler: %v",
+	"%s 안에 실행되지 않았습니다.":                                           "Not executed within %s.",
+	"예상: %s %d, 실제: %s %d %s":                                     "Expected: %s %d, got: %s %d %s",
+	"응답 본문에 페이로드가 없습니다: %q":                                       "The response body doesn't contain the payload: %q",
+	"실행 시간이 지연보다 짧습니다: %s":                                        "The execution took less than the delay: %s",
+	"base-path는 /로 시작하는 경로여야 합니다: %q":                             "base-path must be a path starting with /: %q",
+	"환경 변수 %s 설정 오류: %q":                                          "invalid environment variable %s: %q",
+	"종료 신호를 받았습니다. 진행 중인 요청과 실행을 최대 %s 기다립니다.":                    "Received a termination signal. Waiting up to %s for requests and executions in progress.",
+	"웹 서버 종료 오류: %v":                                              "failed to shut down the web server: %v",
+	"진행 중인 실행 %d개를 기다리지 않고 종료합니다.":                                "Exiting without waiting for %d executions in progress.",
+	"서버를 종료합니다.":                                                  "Server stopped.",
+	"SQLite 저장소를 사용할 수 없어 파일 저장소를 사용합니다: %v":                      "SQLite storage is unavailable, using file storage: %v",
+	"redis 저장소에는 storage.url 설정이 필요합니다":                           "redis storage requires the storage.url setting",
+	"알 수 없는 저장소 드라이버입니다: %s":                                      "unknown storage driver: %s",
+	"로그 저장 오류: %v":                                                "failed to store log: %v",
+	"로그 조회 오류: %v":                                                "failed to read logs: %v",
+	"logForward.url 설정이 필요합니다":                                    "logForward.url setting is required",
+	"logForward.type은 syslog, loki, http 중 하나여야 합니다: %q":          "logForward.type must be syslog, loki or http: %q",
+	"logForward.flushInterval 설정 오류: %q":                          "invalid logForward.flushInterval setting: %q",
+	"syslog 주소는 udp://host:port 또는 tcp://host:port 형식이어야 합니다: %q": "syslog address must be udp://host:port or tcp://host:port: %q",
+	"로그 수집기가 요청을 거부했습니다 - 상태 코드: %d":                              "log collector rejected the request with status %d",
+	"로그 전송 오류 (%d회 시도): %v":                                       "failed to ship logs after %d attempts: %v",
+	"로그 전송 대기열이 가득 차 로그 %d건을 버렸습니다.":                              "Log shipping queue is full; dropped %d log entries.",
+	"StatsD 연결 오류: %w":                                            "failed to connect to StatsD: %w",
+	"OTLP 익스포터 생성 오류: %w":                                         "failed to create OTLP exporter: %w",
+	"OTel 리소스 생성 오류: %w":                                          "failed to create OTel resource: %w",
+
+	// API responses
+	"존재하지 않는 스케줄러 ID입니다.":                 "Scheduler ID does not exist.",
+	"존재하지 않는 버전입니다.":                      "Version does not exist.",
+	"이미 존재하는 스케줄러 ID입니다.":                 "Scheduler ID already exists.",
+	"지원하지 않는 작업 유형입니다.":                   "Job type is not supported.",
+	"잘못된 스케줄러 설정입니다.":                     "Invalid scheduler config.",
+	"실행 이력을 저장하는 저장소가 설정되지 않았습니다.":        "No store keeping the execution history is configured.",
+	"내부 오류가 발생했습니다.":                      "An internal error occurred.",
+	"operations가 비어 있습니다.":                "operations is empty.",
+	"operations가 너무 많습니다.":                "Too many operations.",
+	"배치의 다른 작업이 유효하지 않아 실행하지 않았습니다.":      "Not executed because another operation of the batch is invalid.",
+	"스케줄러 한도를 초과했습니다.":                    "Scheduler limit exceeded.",
+	"알 수 없는 API 토큰입니다.":                   "Unknown API token.",
+	"Idempotency-Key 헤더가 너무 깁니다.":         "The Idempotency-Key header is too long.",
+	"Idempotency-Key가 다른 요청에 이미 사용되었습니다.": "The Idempotency-Key was already used for a different request.",
+	"API 토큰이 필요합니다.":                      "An API token is required.",
+	"관리자 권한이 필요합니다.":                      "Admin role required.",
+	"설정 파일 없이 실행 중이라 다시 읽을 수 없습니다.":       "The server runs without a config file, so there is nothing to reload.",
+	"설정을 다시 불러오지 못했습니다.":                  "Failed to reload the config.",
+	"점검 모드라 변경 요청을 처리할 수 없습니다.":           "The service is in maintenance mode and accepts no changes.",
+	"duration은 0 이상의 Go duration이어야 합니다.": "duration must be a Go duration of 0 or more.",
+	"시계를 과거로 되돌릴 수 없습니다.":                 "The clock can't go back.",
+	"duration과 until 중 하나만 지정해야 합니다.":     "Set exactly one of duration and until.",
+	"가짜 서버 파라미터가 올바르지 않습니다.":              "Invalid fake server parameter.",
+	"요청이 너무 많습니다. 잠시 후 다시 시도하세요.":         "Too many requests. Try again later.",
+	"잘못된 요청 본문입니다.":                       "Invalid request body.",
+	"스케줄러가 시작되었습니다.":                      "Scheduler started.",
+	"새 스케줄러 ID가 필요합니다.":                   "A new scheduler ID is required.",
+	"스케줄러 설정이 변경되었습니다.":                   "Scheduler config updated.",
+	"잘못된 버전 번호입니다.":                       "Invalid version number.",
+	"스케줄러 설정이 복원되었습니다.":                   "Scheduler config rolled back.",
+	"스케줄러가 일시 중지되었습니다.":                   "Scheduler paused.",
+	"스케줄러가 다시 실행됩니다.":                     "Scheduler resumed.",
+	"현재 상태에서 허용되지 않는 요청입니다.":              "The request is not allowed in the current state.",
+	"window 파라미터가 올바르지 않습니다.":             "Invalid window parameter.",
+	"from과 to 파라미터는 RFC 3339 시각이어야 합니다.":  "The from and to parameters must be RFC 3339 times.",
+	"to는 from 이후여야 합니다.":                  "to must be after from.",
+	"dns 파라미터가 올바르지 않습니다.":                "Invalid dns parameter.",
+	"days 파라미터는 1에서 366 사이여야 합니다.":        "The days parameter must be between 1 and 366.",
+	"API 스케줄러":       "API Scheduler",
+	"스케줄러 ID":        "Scheduler ID",
+	"그룹":             "Group",
+	"스케줄러가 중지되었습니다.": "Scheduler stopped.",
+	"스케줄러가 이미 중지되었습니다.":                 "The scheduler is already stopped.",
+	"스케줄러가 다시 시작되었습니다.":                 "Scheduler started again.",
+	"스케줄러가 삭제되었습니다.":                    "Scheduler deleted.",
+	"label 파라미터는 key=value 형식이어야 합니다.":  "The label parameter must be in key=value form.",
+	"지원하지 않는 state 파라미터입니다.":            "Unsupported state parameter.",
+	"지원하지 않는 정렬 기준입니다.":                 "Sort key is not supported.",
+	"limit 파라미터가 올바르지 않습니다.":            "Invalid limit parameter.",
+	"offset 파라미터가 올바르지 않습니다.":           "Invalid offset parameter.",
+	"afterId 파라미터가 올바르지 않습니다.":          "Invalid afterId parameter.",
+	"beforeId 파라미터가 올바르지 않습니다.":         "Invalid beforeId parameter.",
+	"format 파라미터가 올바르지 않습니다.":           "Invalid format parameter.",
+	"since 파라미터는 RFC 3339 시각이어야 합니다.":   "The since parameter must be an RFC 3339 time.",
+	"order 파라미터는 asc 또는 desc여야 합니다.":    "The order parameter must be asc or desc.",
+	"format 파라미터는 ndjson 또는 csv여야 합니다.": "The format parameter must be ndjson or csv.",
+	"format 파라미터는 csv 또는 xlsx여야 합니다.":   "The format parameter must be csv or xlsx.",
+	"columns 파라미터에 알 수 없는 열이 있습니다.":     "The columns parameter has an unknown column.",
+	"요청 본문을 읽을 수 없습니다.":                 "Failed to read the request body.",
+	"존재하지 않는 템플릿입니다.":                   "Template does not exist.",
+	"이미 존재하는 템플릿입니다.":                   "Template already exists.",
+	"템플릿 삭제 오류가 발생했습니다.":                "Failed to delete the template.",
+	"잘못된 템플릿 설정입니다.":                    "Invalid template config.",
+	"템플릿 저장 오류가 발생했습니다.":                "Failed to save the template.",
+	"존재하지 않는 변수입니다.":                    "Variable does not exist.",
+	"이미 존재하는 변수입니다.":                    "Variable already exists.",
+	"변수 삭제 오류가 발생했습니다.":                 "Failed to delete the variable.",
+	"잘못된 변수 이름입니다.":                     "Invalid variable name.",
+	"변수 저장 오류가 발생했습니다.":                 "Failed to save the variable.",
+	"승인을 기다리는 단계가 없습니다.":                "No step is waiting for approval.",
+	"승인 권한이 없습니다.":                      "You may not approve this step.",
+
+	// Scheduler events
+	"스케줄러 시작 요청을 받았습니다: %s":                                                      "Received a request to start the scheduler: %s",
+	"스케줄러 시작 요청을 받았습니다.":                                                         "Received a request to start the scheduler.",
+	"설정: 시작 시각 %s, 반복 %s, URL %s":                                                "Config: start time %s, repeat every %s, URL %s",
+	"스케줄 시작까지 대기 중입니다... 남은 시간: %s":                                              "Waiting for the schedule to start... time left: %s",
+	"스케줄러가 시작 전에 중지되었습니다.":                                                       "Scheduler stopped before it started.",
+	"스케줄러가 실행 중입니다.":                                                             "Scheduler is running.",
+	"저장된 상태에서 복원되었습니다. 마지막 실행 시각: %s":                                            "Restored from the stored state. Last run: %s",
+	"재시작 전 놓친 실행을 따라잡지 않습니다 (catchUpWindow 미설정).":                                "Runs missed before the restart are not caught up (catchUpWindow not set).",
+	"따라잡기 범위(%s)보다 오래된 실행은 건너뜁니다.":                                               "Skipping runs older than the catch-up window (%s).",
+	"실행 선점 오류: %v":                                                               "failed to claim the run: %v",
+	"예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(skip)에 따라 건너뜁니다.":                       "Missed %d scheduled runs (first due at %s). Skipping them as per the skip policy.",
+	"예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(run-all)에 따라 %d회 실행합니다.":                "Missed %d scheduled runs (first due at %s). Running %[3]d times as per the run-all policy.",
+	"예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(run-once)에 따라 한 번 실행합니다.":               "Missed %d scheduled runs (first due at %s). Running once as per the run-once policy.",
+	"등록되지 않은 작업 유형입니다: %s":                                                       "No executor is registered for job type %s",
+	"API 호출 시작: URL %s, 메서드 %s":                                                  "Calling API: URL %s, method %s",
+	"GraphQL 요청 시작: URL %s":                                                      "Sending GraphQL request: URL %s",
+	"부하 테스트 시작: URL %s, 요청 %d회, 동시 실행 %d":                                        "Starting load test: URL %s, %d requests, concurrency %d",
+	"체인 실행 시작: %d단계":                                                             "Starting chain: %d steps",
+	"워크플로 실행 시작: %d단계, 동시 실행 %d":                                                 "Starting workflow: %d steps, concurrency %d",
+	"%s 단계의 승인을 기다립니다. 기한: %s":                                                   "Waiting for approval of step %s. Deadline: %s",
+	"%s 단계가 승인되었습니다. 승인자: %s":                                                    "Step %s was approved by %s",
+	"%s 단계가 거부되었습니다. 거부한 사용자: %s":                                                "Step %s was rejected by %s",
+	"승인 기한이 지나 %s 단계를 거부했습니다.":                                                   "Rejected step %s as its approval deadline passed.",
+	"다중 대상 호출 시작: 대상 %d개, 동시 실행 %d":                                              "Starting fan-out: %d targets, concurrency %d",
+	"상태 확인 시작: URL %s":                                                           "Starting health check: URL %s",
+	"모니터 상태 변경: 정상(up)":                                                          "Monitor state changed: up",
+	"모니터 상태 변경: 장애(down)":                                                        "Monitor state changed: down",
+	"연결 진단 - 호스트 %s, 실패 단계 %s, DNS %.1fms, 연결 %.1fms, TLS %.1fms, 전체 %.1fms":     "Connection diagnostics - host %s, failed in %s, DNS %.1fms, connect %.1fms, TLS %.1fms, total %.1fms",
+	"연결 진단 - 호스트 %s, DNS %.1fms, 연결 %.1fms, TLS %.1fms, 첫 바이트 %.1fms, 전체 %.1fms": "Connection diagnostics - host %s, DNS %.1fms, connect %.1fms, TLS %.1fms, first byte %.1fms, total %.1fms",
+	"명령 실행 시작: %s":                                                               "Running command: %s",
+	"gRPC 호출 시작: 대상 %s, 메서드 %s":                                                  "Calling gRPC: target %s, method %s",
+	"Kafka 메시지 발행 시작: 토픽 %s":                                                     "Publishing Kafka message: topic %s",
+	"AMQP 메시지 발행 시작: 익스체인지 %q, 라우팅 키 %q":                                         "Publishing AMQP message: exchange %q, routing key %q",
+	"작업 실행 시작: 유형 %s":                                                            "Running job: type %s",
+	"점검 모드라 실행을 건너뜁니다.":                                                          "Skipping the execution in maintenance mode.",
+	"%s 스케줄러의 트리거로 실행합니다.":                                                       "Executing on a trigger from scheduler %s.",
+	"%s 스케줄러를 트리거했습니다.":                                                          "Triggered scheduler %s.",
+	"%s 스케줄러에 이미 대기 중인 트리거가 있어 합칩니다.":                                            "Scheduler %s already has a pending trigger; merging.",
+	"트리거할 스케줄러가 없거나 실행 중이 아닙니다: %s":                                              "The scheduler to trigger doesn't exist or isn't running: %s",
+	"트리거가 순환하여 %s 스케줄러를 다시 실행하지 않습니다: %s":                                        "Not executing scheduler %s again because the triggers form a cycle: %s",
+	"점검 모드를 시작합니다. 예정된 실행을 건너뜁니다: %s":                                            "Maintenance mode started; scheduled executions are skipped: %s",
+	"점검 모드를 해제합니다. 다음 예정 시각부터 실행합니다.":                                            "Maintenance mode lifted; executions resume from the next fire time.",
+	"점검 시간대(%s)라 실행을 건너뜁니다.":                                                     "Skipping the execution during the blackout window (%s).",
+	"일시 중지 상태라 실행을 건너뜁니다.":                                                       "Skipping the execution while paused.",
+	"요청 헤더: %s": "Request headers: %s",
+	"스케줄러가 중지되어 진행 중이던 실행을 취소했습니다.":   "Scheduler stopped; the running execution was cancelled.",
+	"실행 완료 - 상태 코드: %d":               "Execution finished - status code: %d",
+	"응답을 보관했습니다: %s":                  "Archived the response: %s",
+	"응답 보관 오류: %v":                    "Failed to archive the response: %v",
+	"보관 기간이 지난 응답 삭제 오류: %v":          "Failed to delete expired archived responses: %v",
+	"보관 기간이 지난 응답 %d개를 삭제했습니다.":       "Deleted %d expired archived responses.",
+	"멱등성 키: %s":                       "Idempotency key: %s",
+	"로그인 요청: URL %s":                  "Logging in: URL %s",
+	"로그인 성공 - 상태 코드: %d":              "Logged in - status code: %d",
+	"세션이 만료되어 다시 로그인합니다 (상태 코드: %d).": "The session expired; logging in again (status code: %d).",
+	"실행 완료":     "Execution finished",
+	"응답 본문: %s": "Response body: %s",
+	"비교 기준 응답을 저장했습니다: %s":                             "Saved the baseline response for comparison: %s",
+	"응답이 JSON이 아니라 메트릭을 추출하지 못했습니다.":                   "The response isn't JSON, so no metrics were extracted.",
+	"메트릭 %s의 경로 %s에 값이 없습니다.":                          "Metric %s: no value at path %s.",
+	"메트릭 %s의 값이 숫자가 아닙니다: %s":                          "Metric %s: the value is not a number: %s",
+	"응답이 변경되었습니다 (%d건): %s":                            "Response changed (%d changes): %s",
+	"실행 조건(onlyIf)을 만족하지 않아 실행을 건너뜁니다: %s":             "Skipping the execution because the onlyIf condition does not hold: %s",
+	"건너뛰기 조건(skipIf)을 만족하여 실행을 건너뜁니다: %s":              "Skipping the execution because the skipIf condition holds: %s",
+	"실행 실패 - %s 후 재시도합니다 (%d/%d).":                     "Execution failed - retrying in %s (%d/%d).",
+	"페이로드를 만들 수 없어 재시도하지 않습니다.":                        "not retrying, as the payload can't be rendered.",
+	"실행 성공 - 스케줄러가 자동으로 중지됩니다.":                        "Execution succeeded - the scheduler stops automatically.",
+	"동시 실행 한도에 도달하여 실행 대기열에서 대기 중입니다.":                 "Concurrency limit reached; waiting in the execution queue.",
+	"스케줄러가 중지되어 대기 중이던 실행을 취소했습니다.":                    "Scheduler stopped; the queued execution was cancelled.",
+	"저장된 스케줄러를 복원할 수 없습니다: %v":                         "Failed to restore the stored scheduler: %v",
+	"다른 인스턴스에서 삭제되어 스케줄러를 중지합니다.":                      "Stopping the scheduler because another instance removed it.",
+	"다른 인스턴스에서 등록된 스케줄러를 시작합니다.":                       "Starting a scheduler registered by another instance.",
+	"스케줄러가 이미 실행 중입니다. 새로운 요청을 무시합니다.":                 "Scheduler is already running. Ignoring the new request.",
+	"같은 ID의 스케줄러가 이미 등록되어 있습니다. 새로운 요청을 무시합니다.":        "A scheduler with the same ID is already registered. Ignoring the new request.",
+	"스케줄러 상태 저장 오류: %v":                                "failed to save scheduler state: %v",
+	"스케줄러가 실행 중이지 않습니다.":                               "Scheduler is not running.",
+	"스케줄러 상태 삭제 오류: %v":                                "failed to delete scheduler state: %v",
+	"실행 기록 삭제 오류: %v":                                  "failed to delete the execution history: %v",
+	"다른 인스턴스에서 중지되어 스케줄러를 중지합니다.":                      "Stopping the scheduler because another instance stopped it.",
+	"스케줄러 설정이 변경되었습니다. 중지된 상태를 유지합니다.":                 "Scheduler config changed. The scheduler stays stopped.",
+	"%w: 중지된 스케줄러가 아닙니다 (현재 상태: %s)":                   "%w: the scheduler is not stopped (current state: %s)",
+	"스케줄러가 비정상 종료되었습니다: %v\n%s":                        "scheduler crashed: %v\n%s",
+	"%s 후 스케줄러를 다시 시작합니다.":                             "restarting the scheduler in %s.",
+	"스케줄러가 %s 동안 시작되지 않았습니다. 멈춘 것으로 보입니다.":             "The scheduler hasn't started for %s. It seems to be stuck.",
+	"실행 시각 %s 이후 %s 동안 실행되지 않았습니다. 스케줄러가 멈춘 것으로 보입니다.": "Nothing executed for %[2]s since the fire time %[1]s. The scheduler seems to be stuck.",
+	"멈췄던 스케줄러가 다시 진행됩니다.":                              "The stuck scheduler is making progress again.",
+	"SLA 위반: %s 실행이 %s 안에 끝나지 않았습니다 (소요 %s).":          "SLA violated: the %s run didn't finish within %s (took %s).",
+	"SLA 위반: %s 실행이 %s까지 끝나지 않았습니다.":                   "SLA violated: the %s run didn't finish by %s.",
+	"SLA 위반: %s 이후 성공한 실행이 없습니다.":                      "SLA violated: no successful run since %s.",
+	"실패한 스케줄러를 다시 시작합니다.":                              "restarting the failed scheduler.",
+	"%d회 연속 실행에 실패하여 스케줄러를 중지합니다.":                     "Stopping the scheduler after %d failed executions in a row.",
+	"%d회 연속 실행 실패: %s":                                 "%d failed executions in a row: %s",
+	"감사 기록 저장 오류: %v":                                  "failed to save audit entry: %v",
+	"실행 기록 저장 오류: %v":                                  "failed to save execution: %v",
+	"%w: %s 상태에서 %s 상태로 전환할 수 없습니다":                    "%w: cannot change from %s to %s",
+	"%w: 일시 중지된 스케줄러가 아닙니다 (현재 상태: %s)":                "%w: the scheduler is not paused (current state: %s)",
+	"설정이 변경되어 스케줄러를 다시 시작합니다.":                         "Config changed; restarting the scheduler.",
+	"설정 이력 조회 오류: %v":                                  "failed to read config history: %v",
+	"설정 이력 저장 오류: %v":                                  "failed to save config history: %v",
+	"공휴일 달력 로드 오류: %v":                                 "failed to load holiday calendar: %v",
+	"공휴일 달력 다운로드 오류: 상태 코드 %d":                         "failed to download holiday calendar: status code %d",
+	"공휴일 날짜 형식이 올바르지 않습니다: %q":                         "invalid holiday date: %q",
+	"알림 전송 오류: %v":                                     "failed to send notification: %v",
+	"알 수 없는 알림 채널 유형입니다: %q":                           "unknown notification channel type: %q",
+	"요약 보고서 생성 오류: %v":                                 "summary report error: %v",
+	"요약 보고서를 생성했습니다: 실행 %d회, 실패 %d회":                   "Generated the summary report: %d runs, %d failures",
+	"일간 요약 보고서: %s ~ %s":                               "Daily summary: %s ~ %s",
+	"주간 요약 보고서: %s ~ %s":                               "Weekly summary: %s ~ %s",
+	"요약 보고서: %s ~ %s":                                  "Summary: %s ~ %s",
+	"실행 기록을 보관하지 않아 실행 통계가 없습니다.":                      "No execution statistics, since the execution history is not kept.",
+	"스케줄러 %d개, 실행 %d회, 실패 %d회, 성공률 %.1f%%":             "%d schedulers, %d runs, %d failures, %.1f%% successful",
+	"예정된 실행 %d회, 다음 실행: %s %s":                         "%d upcoming runs, next: %s at %s",
+	"상태 코드 %d": "status code %d",
+	"텔레그램 봇 토큰이 설정되지 않았습니다": "Telegram bot token is not configured",
+	"%s (%d회)":               "%s (%d)",
+	"실패가 많은 스케줄러: %s":        "Most failures: %s",
+	"%s (평균 %.0fms)":         "%s (avg %.0fms)",
+	"가장 느린 스케줄러: %s":         "Slowest: %s",
+	"응답 전달 오류: %v":           "failed to forward the response: %v",
+	"응답 전달 오류: 상태 코드 %d":     "failed to forward the response: status code %d",
+	"응답을 전달했습니다: %s (%d바이트)": "Forwarded the response: %s (%d bytes)",
+	"응답 변환 오류: %w":           "failed to transform the response: %w",
+	"응답이 JSON이 아닙니다":         "the response is not JSON",
+
+	// Config validation
+	"%w: %s 식 오류: %v":                                                   "%w: invalid %s expression: %v",
+	"예상하지 못한 %q":                                                        "unexpected %q",
+	"닫히지 않은 문자열입니다":                                                     "unterminated string",
+	"식이 끝나지 않았습니다":                                                      "unexpected end of expression",
+	"닫는 괄호가 필요합니다":                                                      "missing closing parenthesis",
+	"알 수 없는 이름입니다: %q":                                                  "unknown name: %q",
+	"'.' 뒤에 이름이 필요합니다":                                                  "expected a name after '.'",
+	"'[' 뒤에 0 이상의 인덱스가 필요합니다":                                           "expected a non-negative index after '['",
+	"닫는 대괄호가 필요합니다":                                                     "missing closing bracket",
+	"%w: diff.ignore 경로는 $로 시작해야 합니다: %q":                               "%w: diff.ignore paths must start with $: %q",
+	"%w: metrics는 %d개 이하여야 합니다":                                         "%w: metrics must have at most %d entries",
+	"%w: metrics의 이름이 올바르지 않습니다: %q":                                    "%w: invalid metrics name: %q",
+	"%w: metrics의 이름이 중복됩니다: %s":                                        "%w: duplicate metrics name: %s",
+	"%w: metrics.%s 경로 오류: %v":                                          "%w: metrics.%s path error: %v",
+	"%w: load.requests는 1에서 %d 사이여야 합니다":                                "%w: load.requests must be between 1 and %d",
+	"%w: chain.steps는 1개에서 %d개 사이여야 합니다":                                "%w: chain.steps must have between 1 and %d steps",
+	"%w: %s 단계에 apiURL이 없습니다":                                           "%w: step %s has no apiURL",
+	"%w: %s 단계의 successIf 식 오류: %v":                                     "%w: step %s has an invalid successIf expression: %v",
+	"%w: %s 단계의 extract.%s 경로 오류: %v":                                   "%w: step %s has an invalid extract.%s path: %v",
+	"%w: workflow.steps는 1개에서 %d개 사이여야 합니다":                             "%w: workflow.steps must have between 1 and %d steps",
+	"%w: workflow.concurrency는 0에서 %d 사이여야 합니다":                         "%w: workflow.concurrency must be between 0 and %d",
+	"%w: workflow 단계의 이름은 문자, 숫자, '_', '-'로 이루어져야 합니다: %q":              "%w: workflow step names must consist of letters, digits, '_' and '-': %q",
+	"%w: workflow 단계의 이름이 중복됩니다: %s":                                    "%w: duplicate workflow step name: %s",
+	"%w: %s 단계의 dependsOn에 알 수 없는 단계가 있습니다: %q":                         "%w: step %s depends on an unknown step: %q",
+	"%w: %s 단계의 when 식 오류: %v":                                          "%w: step %s has an invalid when expression: %v",
+	"%w: workflow 단계의 의존 관계가 순환합니다: %s":                                 "%w: the workflow steps depend on each other in a cycle: %s",
+	"%w: %s 단계의 type이 올바르지 않습니다: %q":                                    "%w: step %s has an invalid type: %q",
+	"%w: %s 단계의 approval.timeout은 0보다 크고 %s 이하여야 합니다":                   "%w: approval.timeout of step %s must be positive and at most %s",
+	"%w: 승인을 기다리는 단계가 여러 개라 step을 지정해야 합니다: %s":                         "%w: several steps are waiting for approval, so step must be set: %s",
+	"%w: %s 단계의 승인자가 아닙니다":                                              "%w: not an approver of step %s",
+	"%w: 익명 사용자는 %s 단계를 승인하거나 거부할 수 없습니다":                               "%w: anonymous users can't approve or reject step %s",
+	"경로는 $로 시작해야 합니다: %q":                                               "paths must start with $: %q",
+	"%w: fanout.targets는 1개에서 %d개 사이여야 합니다":                             "%w: fanout.targets must have between 1 and %d URLs",
+	"%w: fanout.targets에 빈 URL이 있습니다":                                   "%w: fanout.targets contains an empty URL",
+	"%w: fanout.concurrency는 0에서 %d 사이여야 합니다":                           "%w: fanout.concurrency must be between 0 and %d",
+	"%w: fanout.minSuccessRate는 0에서 1 사이여야 합니다":                         "%w: fanout.minSuccessRate must be between 0 and 1",
+	"%w: monitor 임계값은 0 이상이어야 합니다":                                      "%w: monitor thresholds must not be negative",
+	"%w: 알 수 없는 transport.protocol입니다: %q":                              "%w: unknown transport.protocol %q",
+	"%w: transport.proxy 오류: %v":                                        "%w: invalid transport.proxy: %v",
+	"%w: transport TLS 설정 오류: %v":                                       "%w: invalid transport TLS settings: %v",
+	"%w: transport.proxy는 %s 프로토콜과 함께 쓸 수 없습니다":                         "%w: transport.proxy cannot be used with the %s protocol",
+	"%w: auth.type은 basic 또는 digest여야 합니다":                              "%w: auth.type must be basic or digest",
+	"%w: auth.username이 필요합니다":                                          "%w: auth.username is required",
+	"%w: auth와 signing.aws는 함께 쓸 수 없습니다":                                "%w: auth cannot be used with signing.aws",
+	"%w: archive에는 dir과 s3 중 하나만 지정해야 합니다":                              "%w: archive needs exactly one of dir and s3",
+	"%w: archive.s3에는 bucket과 region이 필요합니다":                            "%w: archive.s3 requires bucket and region",
+	"%w: archive.s3에는 accessKeyId와 secretAccessKey가 필요합니다":              "%w: archive.s3 requires accessKeyId and secretAccessKey",
+	"%w: archive.dir: %v":                                               "%w: archive.dir: %v",
+	"%w: calendar.holidays: %v":                                         "%w: calendar.holidays: %v",
+	"%w: grpc.descriptorSet: %v":                                        "%w: grpc.descriptorSet: %v",
+	"서버에 보관 디렉터리(-archive-root)가 설정되지 않아 응답을 보관할 수 없습니다: %s":            "cannot archive responses because the server has no -archive-root: %s",
+	"보관 디렉터리가 -archive-root 밖에 있습니다: %s":                                "archive directory is outside the -archive-root directory: %s",
+	"%w: archive.s3.endpoint 오류: %v":                                    "%w: invalid archive.s3.endpoint: %v",
+	"%w: archive.retention은 양의 기간이어야 합니다: %q":                           "%w: archive.retention must be a positive duration: %q",
+	"%w: contentType은 payloadType json, text, binary에만 쓸 수 있습니다":        "%w: contentType only works with payloadType json, text or binary",
+	"%w: payloadType %s는 POST 요청에만 쓸 수 있습니다":                            "%w: payloadType %s only works with POST requests",
+	"%w: files는 payloadType form에만 쓸 수 있습니다":                            "%w: files only work with payloadType form",
+	"%w: payloadType %s에는 contentType이 필요합니다":                           "%w: payloadType %s needs a contentType",
+	"%w: contentType이 올바르지 않습니다: %q":                                    "%w: invalid contentType: %q",
+	"%w: 알 수 없는 payloadType입니다: %q":                                     "%w: unknown payloadType: %q",
+	"%w: rawQuery는 GET 요청에만 쓸 수 있습니다":                                   "%w: rawQuery only works with GET requests",
+	"%w: payloadFrom에는 file과 url 중 하나만 지정해야 합니다":                        "%w: payloadFrom needs exactly one of file and url",
+	"%w: payloadFrom.url은 http 또는 https URL이어야 합니다: %q":                 "%w: payloadFrom.url must be an http or https URL: %q",
+	"%w: files[%d]에 field가 없습니다":                                        "%w: files[%d] has no field",
+	"%w: files[%d]에는 path와 content 중 하나만 지정해야 합니다":                      "%w: files[%d] needs exactly one of path and content",
+	"%w: files[%d].content는 base64여야 합니다: %v":                           "%w: files[%d].content must be base64: %v",
+	"%w: session.login.apiURL이 필요합니다":                                   "%w: session.login.apiURL is required",
+	"%w: 프로필 %q의 baseURL은 절대 URL이어야 합니다":                                "%w: baseURL of profile %q must be an absolute URL",
+	"%w: 활성 프로필이 정의되어 있지 않습니다: %q":                                      "%w: the active profile is not defined: %q",
+	"%w: 상대 apiURL에는 프로필이 필요합니다":                                        "%w: a relative apiURL needs a profile",
+	"%w: 프로필을 찾을 수 없습니다: %q":                                            "%w: profile not found: %q",
+	"%w: signing.hmac.secret이 필요합니다":                                    "%w: signing.hmac.secret is required",
+	"%w: 지원하지 않는 signing.hmac.algorithm입니다: %q":                         "%w: unsupported signing.hmac.algorithm %q",
+	"%w: signing.hmac.encoding은 hex 또는 base64여야 합니다":                    "%w: signing.hmac.encoding must be hex or base64",
+	"%w: signing.aws에는 region과 service가 필요합니다":                          "%w: signing.aws requires region and service",
+	"지원하지 않는 프록시 스킴입니다: %q":                                             "unsupported proxy scheme %q",
+	"프록시 주소에 호스트가 없습니다: %q":                                             "the proxy URL has no host: %q",
+	"%w: 모니터(monitor) 유형의 스케줄러가 아닙니다":                                   "%w: the scheduler is not a monitor",
+	"%w: 워크플로(workflow) 유형의 스케줄러가 아닙니다":                                 "%w: the scheduler is not a workflow",
+	"%w: load.concurrency는 0에서 %d 사이여야 합니다":                             "%w: load.concurrency must be between 0 and %d",
+	"%w: load.minSuccessRate는 0에서 1 사이여야 합니다":                           "%w: load.minSuccessRate must be between 0 and 1",
+	"%w: 유효하지 않은 반복 단위입니다: %q":                                          "%w: invalid repeat unit: %q",
+	"%w: 알 수 없는 misfire 정책입니다: %q":                                      "%w: unknown misfire policy %q",
+	"%w: interval 파싱 오류: %v":                                            "%w: failed to parse interval: %v",
+	"%w: 반복 간격은 %s 이상이어야 합니다: %s":                                       "%w: interval must be at least %s: %s",
+	"%w: 1초보다 짧은 반복 간격에는 allowFastInterval이 필요합니다: %s":                  "%w: intervals shorter than a second require allowFastInterval: %s",
+	"%w: 반복 값은 1 이상이어야 합니다":                                             "%w: repeat value must be at least 1",
+	"%w: 시작 시간 파싱 오류: %v":                                               "%w: failed to parse start time: %v",
+	"%w: catchUpWindow 파싱 오류: %v":                                       "%w: failed to parse catchUpWindow: %v",
+	"%w: 이름은 %d자를 넘을 수 없습니다":                                            "%w: name must not exceed %d characters",
+	"%w: 스케줄러 ID가 필요합니다":                                                "%w: scheduler ID is required",
+	"%w: notify.webhookURL 또는 notify.channels가 필요합니다":                   "%w: notify.webhookURL or notify.channels is required",
+	"%w: notify.channels[%d].url은 http 또는 https URL이어야 합니다: %q":         "%w: notify.channels[%d].url must be an http or https URL: %q",
+	"%w: notify.channels[%d].template 오류: %v":                           "%w: notify.channels[%d].template error: %v",
+	"%w: notify.channels[%d]에 routingKey 또는 groupRoutingKeys가 필요합니다":    "%w: notify.channels[%d] requires routingKey or groupRoutingKeys",
+	"%w: notify.channels[%d].failureThreshold는 0 이상이어야 합니다":             "%w: notify.channels[%d].failureThreshold must be at least 0",
+	"%w: notify.channels[%d]에 chatId가 필요합니다":                            "%w: notify.channels[%d] requires chatId",
+	"%w: 알 수 없는 알림 채널 유형입니다: %q":                                        "%w: unknown notification channel type: %q",
+	"%w: forward.url은 http 또는 https URL이어야 합니다: %q":                     "%w: forward.url must be an http or https URL: %q",
+	"%w: forward.method는 POST, PUT, PATCH 중 하나여야 합니다: %q":               "%w: forward.method must be POST, PUT or PATCH: %q",
+	"%w: transform에는 jq와 template 중 하나만 지정해야 합니다":                       "%w: transform needs exactly one of jq and template",
+	"%w: transform.jq 오류: %v":                                           "%w: transform.jq error: %v",
+	"%w: transform.template 오류: %v":                                     "%w: transform.template error: %v",
+	"%w: %s는 %d개 이하여야 합니다":                                              "%w: %s must have at most %d entries",
+	"%w: %s에 빈 스케줄러 ID가 있습니다":                                           "%w: %s contains an empty scheduler ID",
+	"%w: retry.maxAttempts는 1 이상이어야 합니다":                                "%w: retry.maxAttempts must be at least 1",
+	"%w: retry.backoff 파싱 오류: %q":                                       "%w: failed to parse retry.backoff: %q",
+	"%w: 알 수 없는 요일입니다: %q":                                              "%w: unknown day of the week: %q",
+	"%w: reports.period는 daily 또는 weekly여야 합니다: %q":                     "%w: reports.period must be daily or weekly: %q",
+	"%w: reports.at은 HH:mm 형식이어야 합니다: %q":                               "%w: reports.at must be HH:mm: %q",
+	"%w: reports.top은 0 이상이어야 합니다":                                      "%w: reports.top must be at least 0",
+	"%w: 날짜 형식은 YYYY-MM-DD여야 합니다: %q":                                   "%w: dates must be in YYYY-MM-DD form: %q",
+	"%w: 점검 시간대의 시작과 끝은 HH:mm 또는 YYYY-MM-DD HH:mm 형식이어야 합니다: %q ~ %q":   "%w: blackout window bounds must be in HH:mm or YYYY-MM-DD HH:mm form: %q ~ %q",
+	"%w: 점검 시간대의 끝은 시작 이후여야 합니다: %q ~ %q":                               "%w: blackout window must end after it starts: %q ~ %q",
+	"%w: 템플릿 이름이 필요합니다":                                                 "%w: template name is required",
+	"%w: 템플릿은 다른 템플릿을 참조할 수 없습니다":                                       "%w: a template cannot refer to another template",
+	"%w: 템플릿을 찾을 수 없습니다: %q":                                            "%w: template not found: %q",
+	"%w: 변수 이름은 문자 또는 '_'로 시작하고 문자, 숫자, '_', '.', '-'로 이루어져야 합니다: %q":   "%w: variable names must start with a letter or '_' and consist of letters, digits, '_', '.' and '-': %q",
+	"잘못된 마스킹 정규식 %q: %w":                                                "invalid redaction pattern %q: %w",
+	"%w: logLevel은 debug, info, warn, error 중 하나여야 합니다: %q":             "%w: logLevel must be debug, info, warn or error: %q",
+	"%w: schemaVersion %d은 지원하지 않습니다 (최대 %d)":                           "%w: unsupported schemaVersion %d (at most %d)",
+	"schemaVersion은 정수여야 합니다: %s":                                       "schemaVersion must be an integer: %s",
+	"설정을 schemaVersion %d에서 %d로 변환할 수 없습니다: %w":                         "cannot migrate config from schemaVersion %d to %d: %w",
+	"%w: restartPolicy는 never, on-crash 또는 on-failure-streak여야 합니다: %q": "%w: restartPolicy must be never, on-crash or on-failure-streak: %q",
+	"%w: op는 start 또는 stop이어야 합니다: %q":                                  "%w: op must be start or stop: %q",
+	"%w: sla에는 completeWithin 또는 succeedEvery가 필요합니다":                   "%w: sla needs completeWithin or succeedEvery",
+	"%w: sla.%s은 양수 Go duration이어야 합니다: %q":                             "%w: sla.%s must be a positive Go duration: %q",
+	"%w: %s 역할의 최소 반복 간격은 %s입니다: %s":                                    "%w: the minimum repeat interval of role %s is %s: %s",
+	"%w: 인스턴스의 스케줄러가 최대 %d개입니다":                                         "%w: the instance allows at most %d schedulers",
+	"%w: 사용자 %q의 스케줄러가 최대 %d개입니다":                                       "%w: user %q may have at most %d schedulers",
+	"%w: 그룹 %q의 스케줄러가 최대 %d개입니다":                                        "%w: group %q may have at most %d schedulers",
+	"%w: 호스트 %s를 호출하는 실행 중인 스케줄러가 최대 %d개입니다":                            "%[1]w: at most %[3]d running schedulers may call host %[2]s",
+	"%w: start 작업에는 config가 필요합니다":                                      "%w: a start operation needs a config",
+	"%w: restartThreshold는 0 이상이어야 합니다":                                 "%w: restartThreshold must not be negative",
+	"%w: restartCooldown은 양수 Go duration이어야 합니다: %q":                    "%w: restartCooldown must be a positive Go duration: %q",
+	"변수를 확인할 수 없습니다: %s":                                                "variable cannot be resolved: %s",
+	"반복 간격 %s은 %s보다 짧습니다. 대상 API에 부담이 될 수 있습니다":                         "the interval %s is shorter than %s, which may overload the target API",
+	"성공 조건 없이 첫 200 응답에서 스케줄러가 중지됩니다. 계속 반복하려면 stopOnSuccess를 false로 설정하세요": "the scheduler stops on the first 200 response, with no success criteria; set stopOnSuccess to false to keep it repeating",
+	"호스트 이름을 확인할 수 없습니다: %s: %v": "host name cannot be resolved: %s: %v",
+
+	// Executors
+	"요청 생성 오류: %w":                                                 "failed to create request: %w",
+	"URL 파싱 오류: %w":                                                "failed to parse URL: %w",
+	"API 호출 오류: %w":                                                "API call failed: %w",
+	"응답 본문 읽기 오류: %w":                                              "failed to read response body: %w",
+	"GraphQL 쿼리가 설정되지 않았습니다":                                       "GraphQL query is not set",
+	"부하 테스트 설정(load)이 없습니다":                                        "Load test settings (load) are not set",
+	"체인 설정(chain)이 없습니다":                                           "Chain settings (chain) are not set",
+	"%s 단계 오류: %w":                                                 "step %s failed: %w",
+	"성공 조건(successIf)을 만족하지 않습니다: %s":                              "The successIf condition does not hold: %s",
+	"응답에서 %s 값을 찾을 수 없습니다: %s":                                     "%s was not found in the response: %s",
+	"정의되지 않은 변수입니다: %s":                                            "undefined variable: %s",
+	"환경 변수가 설정되지 않았습니다: %s":                                        "environment variable is not set: %s",
+	"허용되지 않은 환경 변수입니다: %s":                                         "environment variable is not allowed: %s",
+	"%w: 허용되지 않은 환경 변수입니다: %s":                                     "%w: environment variables not allowed by allowedEnv: %s",
+	"정의되지 않은 전역 변수입니다: %s":                                         "undefined global variable: %s",
+	"워크플로 설정(workflow)이 없습니다":                                      "Workflow settings (workflow) are not set",
+	"의존 단계가 끝나지 않아 실행할 수 없는 워크플로 단계가 있습니다: %s":                     "workflow steps can't run as their dependencies never finish: %s",
+	"실패한 단계: %s":                                                   "failed steps: %s",
+	"의존 단계 %s를 건너뛰었습니다":                                            "dependency %s was skipped",
+	"의존 단계 %s가 실패했습니다":                                             "dependency %s failed",
+	"실행 조건(when)을 만족하지 않습니다: %s":                                   "the when condition does not hold: %s",
+	"승인 단계는 스케줄러가 실행할 때만 쓸 수 있습니다":                                 "approval steps only work when run by a scheduler",
+	"승인 기한이 지났습니다":                                                 "the approval deadline passed",
+	"거부되었습니다: %s":                                                  "rejected: %s",
+	"거부되었습니다":                                                      "rejected",
+	"다중 대상 설정(fanout)이 없습니다":                                       "Fan-out settings (fanout) are not set",
+	"서버가 HTTP/2를 지원하지 않습니다 (ALPN: %q)":                             "the server does not support HTTP/2 (ALPN: %q)",
+	"서버 인증서가 고정된 지문(pinnedSHA256)과 일치하지 않습니다":                      "the server certificate does not match the pinned fingerprints (pinnedSHA256)",
+	"CA 파일을 읽을 수 없습니다: %w":                                         "cannot read the CA file: %w",
+	"CA 파일에 PEM 인증서가 없습니다: %s":                                     "the CA file contains no PEM certificates: %s",
+	"잘못된 SHA-256 지문입니다: %q":                                        "invalid SHA-256 fingerprint %q",
+	"지원하지 않는 digest 알고리즘입니다: %q":                                   "unsupported digest algorithm %q",
+	"http 또는 https URL이어야 합니다: %q":                                 "must be an http or https URL: %q",
+	"S3 요청 실패 - 상태 코드: %d, 응답: %s":                                 "S3 request failed - status code: %d, response: %s",
+	"지원하지 않는 Content-Encoding입니다: %q":                              "unsupported Content-Encoding %q",
+	"응답 압축 해제 오류: %w":                                              "failed to decompress the response: %w",
+	"페이로드 파일을 읽을 수 없습니다: %w":                                       "cannot read the payload file: %w",
+	"서버에 파일 디렉터리(-file-root)가 설정되지 않아 파일을 읽을 수 없습니다: %s":           "cannot read files because the server has no -file-root: %s",
+	"파일이 허용된 디렉터리 밖에 있습니다: %s":                                     "file is outside the -file-root directory: %s",
+	"페이로드를 가져올 수 없습니다: %w":                                         "cannot fetch the payload: %w",
+	"페이로드를 가져올 수 없습니다 - 상태 코드: %d":                                 "cannot fetch the payload - status code: %d",
+	"페이로드 읽기 오류: %w":                                               "failed to read the payload: %w",
+	"페이로드가 올바른 JSON이 아닙니다: %v (위치 %d)":                             "the payload is not valid JSON: %v (offset %d)",
+	"form 페이로드는 JSON 객체여야 합니다. 다른 JSON 값은 payloadType json으로 보내세요": "a form payload must be a JSON object; send other JSON values with payloadType json",
+	"%s 필드의 값은 문자열, 숫자, 불리언 또는 그 배열이어야 합니다. 중첩된 값은 payloadType json으로 보내세요": "the value of field %s must be a string, number, boolean or an array of them; send nested values with payloadType json",
+	"binary 페이로드는 base64여야 합니다: %v":                       "a binary payload must be base64: %v",
+	"실행 중 패닉이 발생했습니다: %v\n%s":                             "panic during the execution: %v\n%s",
+	"rawQuery 페이로드에는 공백, 제어 문자, '#'을 쓸 수 없습니다 (위치 %d)":    "a rawQuery payload cannot contain spaces, control characters or '#' (offset %d)",
+	"페이로드가 %dMB를 넘습니다":                                    "the payload exceeds %dMB",
+	"응답 본문이 %d바이트를 넘습니다":                                  "response body exceeds %d bytes",
+	"업로드할 파일을 읽을 수 없습니다: %w":                              "cannot read the file to upload: %w",
+	"files.content 디코딩 오류: %w":                            "failed to decode files.content: %w",
+	"로그인 오류: %w":                                          "login failed: %w",
+	"로그인 실패 - 상태 코드: %d":                                  "login failed - status code: %d",
+	"요청 서명 오류: %w":                                        "failed to sign request: %w",
+	"AWS 자격 증명이 없습니다":                                     "AWS credentials are not set",
+	"GraphQL 변수 인코딩 오류: %w":                               "failed to encode GraphQL variables: %w",
+	"실행할 명령이 설정되지 않았습니다":                                  "command to run is not set",
+	"명령 타임아웃 파싱 오류: %w":                                   "failed to parse command timeout: %w",
+	"명령 실행 시간이 초과되었습니다 (%s)":                              "command timed out (%s)",
+	"명령 실행 오류: %w":                                        "command failed: %w",
+	"gRPC 대상 또는 메서드가 설정되지 않았습니다":                          "gRPC target or method is not set",
+	"gRPC 타임아웃 파싱 오류: %w":                                 "failed to parse gRPC timeout: %w",
+	"gRPC 연결 생성 오류: %w":                                   "failed to create gRPC connection: %w",
+	"gRPC 요청 메시지 파싱 오류: %w":                               "failed to parse gRPC request message: %w",
+	"gRPC 응답 메시지 변환 오류: %w":                               "failed to convert gRPC response message: %w",
+	"gRPC 메서드 형식이 올바르지 않습니다 (package.Service/Method): %s": "invalid gRPC method format (package.Service/Method): %s",
+	"gRPC 서비스를 찾을 수 없습니다: %s":                             "gRPC service not found: %s",
+	"gRPC 서비스가 아닙니다: %s":                                  "not a gRPC service: %s",
+	"gRPC 메서드를 찾을 수 없습니다: %s/%s":                          "gRPC method not found: %s/%s",
+	"스트리밍 gRPC 메서드는 지원하지 않습니다: %s/%s":                     "streaming gRPC methods are not supported: %s/%s",
+	"proto 디스크립터 파일 읽기 오류: %w":                            "failed to read proto descriptor file: %w",
+	"proto 디스크립터 파싱 오류: %w":                               "failed to parse proto descriptor: %w",
+	"gRPC 서버 리플렉션 오류: %w":                                 "gRPC server reflection failed: %w",
+	"gRPC 서버 리플렉션 오류: %s 파일을 받지 못했습니다":                    "gRPC server reflection failed: file %s was not returned",
+	"Kafka 토픽이 설정되지 않았습니다":                                "Kafka topic is not set",
+	"Kafka 메시지 발행 오류: %w":                                 "failed to publish Kafka message: %w",
+	"토픽 %s 에 메시지를 발행했습니다":                                 "Published a message to topic %s",
+	"AMQP 익스체인지 또는 라우팅 키가 설정되지 않았습니다":                     "AMQP exchange or routing key is not set",
+	"AMQP 연결 오류: %w":                                      "failed to connect to AMQP: %w",
+	"AMQP 채널 생성 오류: %w":                                   "failed to create AMQP channel: %w",
+	"AMQP 확인 모드 설정 오류: %w":                                "failed to enable AMQP confirm mode: %w",
+	"AMQP 메시지 발행 오류: %w":                                  "failed to publish AMQP message: %w",
+	"AMQP 발행 확인 대기 오류: %w":                                "failed to wait for AMQP publish confirmation: %w",
+	"브로커가 메시지를 거부했습니다 (nack)":                             "The broker rejected the message (nack)",
+	"익스체인지 %q, 라우팅 키 %q 로 메시지를 발행했습니다":                    "Published a message to exchange %q with routing key %q",
+
+	// Stores
+	"상태 파일 읽기 오류: %w":       "failed to read state file: %w",
+	"상태 파일 파싱 오류: %w":       "failed to parse state file: %w",
+	"Redis URL 파싱 오류: %w":   "failed to parse Redis URL: %w",
+	"Redis 연결 오류: %w":       "failed to connect to Redis: %w",
+	"스케줄러 %s 레코드 파싱 오류: %w": "failed to parse record of scheduler %s: %w",
+	"템플릿 %s 파싱 오류: %w":      "failed to parse template %s: %w",
+	"변수 %s 파싱 오류: %w":       "failed to parse variable %s: %w",
+	"SQLite 열기 오류: %w":      "failed to open SQLite: %w",
+	"SQLite 스키마 생성 오류: %w":  "failed to create SQLite schema: %w",
+	"bbolt 열기 오류: %w":       "failed to open bbolt: %w",
+	"bbolt 스키마 생성 오류: %w":   "failed to create bbolt schema: %w",
+	"잘못된 마이그레이션 버전입니다: %d":  "invalid migration version: %d",
+	"데이터베이스 스키마 버전 %d이 지원하는 버전 %d보다 높습니다": "database schema version %d is newer than the supported version %d",
+	"마이그레이션 %d_%s 실패: %w":                 "migration %d_%s failed: %w",
+	"스케줄러 %s 설정 파싱 오류: %w":                "failed to parse config of scheduler %s: %w",
+	"스케줄러 %s 버전 %d 파싱 오류: %w":             "failed to parse version %[2]d of scheduler %[1]s: %[3]w",
+	"템플릿 %s 설정 파싱 오류: %w":                 "failed to parse config of template %s: %w",
+}
@@ -0,0 +1,165 @@
+// pkg/i18n/i18n.go
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Supported languages.
+const (
+	Korean  = "ko"
+	English = "en"
+)
+
+// catalogs maps a language to its translations. Messages are written in
+// Korean in the source and the Korean text is the key, so Korean needs no
+// catalog and messages missing from a catalog fall back to Korean.
+var catalogs = map[string]map[string]string{
+	English: english,
+}
+
+var (
+	mu          sync.RWMutex
+	defaultLang = Korean
+)
+
+// Supported reports whether lang is a supported language.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok || lang == Korean
+}
+
+// SetDefault sets the language used for log messages and for API responses
+// to requests without a supported Accept-Language.
+func SetDefault(lang string) error {
+	if !Supported(lang) {
+		return Errorf("지원하지 않는 언어입니다: %q", lang)
+	}
+	mu.Lock()
+	defaultLang = lang
+	mu.Unlock()
+	return nil
+}
+
+// Default returns the default language.
+func Default() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultLang
+}
+
+// Translate returns msg in lang.
+func Translate(lang, msg string) string {
+	if t, ok := catalogs[lang][msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// T returns msg in the default language.
+func T(msg string) string {
+	return Translate(Default(), msg)
+}
+
+// Sprintf formats according to the translation of format in lang.
+// Localized errors among args are rendered in lang too.
+func Sprintf(lang, format string, args ...any) string {
+	return fmt.Sprintf(Translate(lang, format), localizeArgs(lang, args)...)
+}
+
+// Negotiate returns the supported language preferred by an Accept-Language
+// header value, or the default language when there's none.
+func Negotiate(acceptLanguage string) string {
+	type choice struct {
+		lang string
+		q    float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		// Only the primary subtag matters: en-US and en-GB are both English.
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if q > 0 && Supported(primary) {
+			choices = append(choices, choice{primary, q})
+		}
+	}
+	if len(choices) == 0 {
+		return Default()
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].lang
+}
+
+// Error is an error whose message is translated when it's rendered.
+type Error struct {
+	format string
+	args   []any
+	err    error
+}
+
+// Errorf is like fmt.Errorf, except the message is translated into the
+// default language by Error and into any language by Localize.
+func Errorf(format string, args ...any) error {
+	return &Error{format: format, args: args, err: fmt.Errorf(format, args...)}
+}
+
+// Error returns the message in the default language.
+func (e *Error) Error() string {
+	return e.Localize(Default())
+}
+
+// Localize returns the message in lang.
+func (e *Error) Localize(lang string) string {
+	return fmt.Errorf(Translate(lang, e.format), localizeArgs(lang, e.args)...).Error()
+}
+
+// Unwrap returns the errors wrapped with %w.
+func (e *Error) Unwrap() []error {
+	switch u := e.err.(type) {
+	case interface{ Unwrap() error }:
+		return []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	}
+	return nil
+}
+
+// Localize returns the message of err in lang. Errors not created by
+// Errorf are returned as they are.
+func Localize(err error, lang string) string {
+	if e, ok := err.(*Error); ok {
+		return e.Localize(lang)
+	}
+	return err.Error()
+}
+
+// localizeArgs replaces the localized errors in args with errors holding
+// their message in lang, so they keep working with %w.
+func localizeArgs(lang string, args []any) []any {
+	var out []any
+	for i, a := range args {
+		if e, ok := a.(*Error); ok {
+			if out == nil {
+				out = append([]any(nil), args...)
+			}
+			out[i] = errors.New(e.Localize(lang))
+		}
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+// pkg/scheduler/amqpexec/amqpexec.go
+package amqpexec
+
+import (
+	"context"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// Executor publishes the message configured in Config.Publish to an AMQP
+// exchange. A message confirmed by the broker is treated as success.
+type Executor struct {
+	url string
+
+	// mu protects conn, which is dialed lazily and redialed once closed.
+	mu   sync.Mutex
+	conn *amqp.Connection
+}
+
+// New creates an Executor that publishes to the broker at url.
+func New(url string) *Executor {
+	return &Executor{url: url}
+}
+
+// Execute publishes a single message and waits for the broker's confirmation.
+func (e *Executor) Execute(ctx context.Context, config scheduler.Config) (scheduler.Result, error) {
+	pc := config.Publish
+	if pc == nil || (pc.Exchange == "" && pc.RoutingKey == "") {
+		return scheduler.Result{}, i18n.Errorf("AMQP 익스체인지 또는 라우팅 키가 설정되지 않았습니다")
+	}
+
+	conn, err := e.connection()
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("AMQP 연결 오류: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("AMQP 채널 생성 오류: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return scheduler.Result{}, i18n.Errorf("AMQP 확인 모드 설정 오류: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		ContentType:  pc.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         []byte(pc.Message),
+	}
+	if len(pc.Headers) > 0 {
+		msg.Headers = amqp.Table{}
+		for key, value := range pc.Headers {
+			msg.Headers[key] = value
+		}
+	}
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, pc.Exchange, pc.RoutingKey, false, false, msg)
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("AMQP 메시지 발행 오류: %w", err)
+	}
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("AMQP 발행 확인 대기 오류: %w", err)
+	}
+	if !acked {
+		return scheduler.Result{Output: i18n.T("브로커가 메시지를 거부했습니다 (nack)")}, nil
+	}
+	return scheduler.Result{
+		Success: true,
+		Output:  i18n.Sprintf(i18n.Default(), "익스체인지 %q, 라우팅 키 %q 로 메시지를 발행했습니다", pc.Exchange, pc.RoutingKey),
+	}, nil
+}
+
+// connection returns the shared connection, dialing it if necessary.
+func (e *Executor) connection() (*amqp.Connection, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil && !e.conn.IsClosed() {
+		return e.conn, nil
+	}
+	conn, err := amqp.Dial(e.url)
+	if err != nil {
+		return nil, err
+	}
+	e.conn = conn
+	return conn, nil
+}
+
+// Close closes the broker connection.
+func (e *Executor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil || e.conn.IsClosed() {
+		return nil
+	}
+	return e.conn.Close()
+}
@@ -0,0 +1,285 @@
+// pkg/scheduler/approval.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// StepTypeApproval is the WorkflowStep.Type of a step that waits for an
+// operator to approve the run before the steps after it continue.
+const StepTypeApproval = "approval"
+
+// defaultApprovalTimeout is how long an approval step waits when
+// ApprovalConfig.Timeout is empty, and maxApprovalTimeout bounds it.
+const (
+	defaultApprovalTimeout = time.Hour
+	maxApprovalTimeout     = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrApprovalNotFound is returned by Decide when no step of the
+	// execution waits for approval.
+	ErrApprovalNotFound = errors.New("scheduler: approval not found")
+	// ErrNotApprover is returned by Decide for a user who isn't one of
+	// the step's approvers.
+	ErrNotApprover = errors.New("scheduler: not an approver")
+)
+
+// ApprovalConfig configures a StepTypeApproval step.
+type ApprovalConfig struct {
+	// Message tells the operators what they approve.
+	Message string `json:"message,omitempty"`
+	// Timeout is a Go duration string, at most 168h. The step is
+	// rejected when nobody decides in time. Empty means 1h.
+	Timeout string `json:"timeout,omitempty"`
+	// Approvers are the users allowed to decide. Empty means any
+	// authenticated user; anonymous callers never may.
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+// validate checks the approval settings. A nil ApprovalConfig is valid.
+func (c *ApprovalConfig) validate(step string) error {
+	if _, ok := c.timeout(); !ok {
+		return i18n.Errorf("%w: %s 단계의 approval.timeout은 0보다 크고 %s 이하여야 합니다", ErrInvalidConfig, step, maxApprovalTimeout)
+	}
+	return nil
+}
+
+// timeout returns how long the step waits for a decision, and reports
+// whether Timeout is valid.
+func (c *ApprovalConfig) timeout() (time.Duration, bool) {
+	if c == nil || c.Timeout == "" {
+		return defaultApprovalTimeout, true
+	}
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil || d <= 0 || d > maxApprovalTimeout {
+		return 0, false
+	}
+	return d, true
+}
+
+// Approval is a workflow step waiting for an operator's decision.
+type Approval struct {
+	SchedulerID string    `json:"schedulerId"`
+	ExecutionID string    `json:"executionId"`
+	Step        string    `json:"step"`
+	Message     string    `json:"message,omitempty"`
+	Approvers   []string  `json:"approvers,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+	// Deadline is when the step is rejected if nobody decided.
+	Deadline time.Time `json:"deadline"`
+}
+
+// ApprovalDecision is the outcome of an approval step, and the response
+// body its When conditions see.
+type ApprovalDecision struct {
+	Approved bool   `json:"approved"`
+	By       string `json:"by,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	// TimedOut is set when nobody decided before the deadline.
+	TimedOut  bool      `json:"timedOut,omitempty"`
+	DecidedAt time.Time `json:"decidedAt"`
+}
+
+// ApprovalEvent is sent to the webhook of a scheduler when one of its
+// workflow steps starts waiting for approval.
+type ApprovalEvent struct {
+	SchedulerID string   `json:"schedulerId"`
+	Name        string   `json:"name,omitempty"`
+	Approval    Approval `json:"approval"`
+}
+
+// pendingApproval is an Approval and the channel its decision is sent on.
+type pendingApproval struct {
+	Approval
+	decision chan ApprovalDecision
+}
+
+// key returns the key of p in the scheduler's approvals map.
+func (p *pendingApproval) key() string {
+	return p.ExecutionID + "/" + p.Step
+}
+
+// approvalGateKey is the context key of the approvalGate of an execution.
+type approvalGateKey struct{}
+
+// approvalGate lets the approval steps of an execution of j wait for a
+// decision.
+type approvalGate struct {
+	j           *job
+	executionID string
+}
+
+// withApprovalGate returns ctx with the approval gate of the execution in
+// progress, for workflows.
+func (j *job) withApprovalGate(ctx context.Context) context.Context {
+	if j.config.jobType() != JobTypeWorkflow {
+		return ctx
+	}
+	return context.WithValue(ctx, approvalGateKey{}, &approvalGate{j: j, executionID: j.execID})
+}
+
+// await registers an approval for step and waits for its decision, its
+// deadline or the end of ctx.
+func (g *approvalGate) await(ctx context.Context, step WorkflowStep) (ApprovalDecision, error) {
+	j, s := g.j, g.j.sched
+	timeout, _ := step.Approval.timeout()
+	now := s.clock.Now()
+	p := &pendingApproval{
+		Approval: Approval{
+			SchedulerID: j.id,
+			ExecutionID: g.executionID,
+			Step:        step.Name,
+			RequestedAt: now,
+			Deadline:    now.Add(timeout),
+		},
+		decision: make(chan ApprovalDecision, 1),
+	}
+	if step.Approval != nil {
+		p.Message = step.Approval.Message
+		p.Approvers = slices.Clone(step.Approval.Approvers)
+	}
+	s.apprMu.Lock()
+	s.approvals[p.key()] = p
+	s.apprMu.Unlock()
+	defer func() {
+		s.apprMu.Lock()
+		delete(s.approvals, p.key())
+		s.apprMu.Unlock()
+	}()
+
+	j.logAt(LogInfo, "%s 단계의 승인을 기다립니다. 기한: %s", step.Name, p.Deadline.Format(time.RFC3339))
+	approval := p.Approval
+	s.bus.publish(Event{
+		Type:        EventApprovalRequested,
+		Time:        now,
+		SchedulerID: j.id,
+		ExecutionID: g.executionID,
+		Approval:    &approval,
+		job:         j,
+	})
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	expired := make(chan bool, 1)
+	go func() { expired <- s.clock.WaitUntil(waitCtx, p.Deadline) }()
+	select {
+	case d := <-p.decision:
+		by := d.By
+		if by == "" {
+			by = "-"
+		}
+		if d.Approved {
+			j.logAt(LogInfo, "%s 단계가 승인되었습니다. 승인자: %s", step.Name, by)
+		} else {
+			j.logAt(LogWarn, "%s 단계가 거부되었습니다. 거부한 사용자: %s", step.Name, by)
+		}
+		return d, nil
+	case ok := <-expired:
+		if !ok {
+			return ApprovalDecision{}, ctx.Err()
+		}
+		j.logAt(LogWarn, "승인 기한이 지나 %s 단계를 거부했습니다.", step.Name)
+		return ApprovalDecision{TimedOut: true, DecidedAt: s.clock.Now()}, nil
+	}
+}
+
+// approve runs the approval step of a workflow, which succeeds when an
+// operator approves it.
+func (e *WorkflowExecutor) approve(ctx context.Context, step WorkflowStep) *workflowStepRun {
+	run := &workflowStepRun{report: WorkflowStepReport{Name: step.Name, State: StepFailed, Attempts: 1}}
+	gate, ok := ctx.Value(approvalGateKey{}).(*approvalGate)
+	if !ok {
+		run.report.Error = i18n.Sprintf(i18n.Default(), "승인 단계는 스케줄러가 실행할 때만 쓸 수 있습니다")
+		return run
+	}
+	started := time.Now()
+	d, err := gate.await(ctx, step)
+	run.report.DurationMs = ms(time.Since(started))
+	if err != nil {
+		run.report.Error = err.Error()
+		return run
+	}
+	out, _ := json.Marshal(d)
+	run.res = Result{Success: d.Approved, Output: string(out)}
+	switch {
+	case d.Approved:
+		run.report.State = StepSucceeded
+	case d.TimedOut:
+		run.report.Error = i18n.Sprintf(i18n.Default(), "승인 기한이 지났습니다")
+	case d.Comment != "":
+		run.report.Error = i18n.Sprintf(i18n.Default(), "거부되었습니다: %s", d.Comment)
+	default:
+		run.report.Error = i18n.Sprintf(i18n.Default(), "거부되었습니다")
+	}
+	return run
+}
+
+// Approvals returns the workflow steps waiting for approval, oldest first.
+func (s *Scheduler) Approvals() []Approval {
+	s.apprMu.Lock()
+	defer s.apprMu.Unlock()
+	list := make([]Approval, 0, len(s.approvals))
+	for _, p := range s.approvals {
+		list = append(list, p.Approval)
+	}
+	sort.Slice(list, func(a, b int) bool {
+		if !list[a].RequestedAt.Equal(list[b].RequestedAt) {
+			return list[a].RequestedAt.Before(list[b].RequestedAt)
+		}
+		return list[a].Step < list[b].Step
+	})
+	return list
+}
+
+// Decide approves or rejects, as user, the step of the execution
+// executionID that waits for approval. step may be empty when only one
+// step of the execution waits. It returns ErrApprovalNotFound when none
+// does, and ErrNotApprover when user is empty, so that the decision is
+// recorded with who made it, or when the step has approvers and user
+// isn't one of them.
+func (s *Scheduler) Decide(executionID, step, user string, approve bool, comment string) (Approval, error) {
+	s.apprMu.Lock()
+	defer s.apprMu.Unlock()
+
+	var matches []*pendingApproval
+	for _, p := range s.approvals {
+		if p.ExecutionID == executionID && (step == "" || p.Step == step) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Approval{}, ErrApprovalNotFound
+	case 1:
+	default:
+		names := make([]string, len(matches))
+		for i, p := range matches {
+			names[i] = p.Step
+		}
+		sort.Strings(names)
+		return Approval{}, i18n.Errorf("%w: 승인을 기다리는 단계가 여러 개라 step을 지정해야 합니다: %s", ErrInvalidState, strings.Join(names, ", "))
+	}
+	p := matches[0]
+	if user == "" {
+		return Approval{}, i18n.Errorf("%w: 익명 사용자는 %s 단계를 승인하거나 거부할 수 없습니다", ErrNotApprover, p.Step)
+	}
+	if len(p.Approvers) > 0 && !slices.Contains(p.Approvers, user) {
+		return Approval{}, i18n.Errorf("%w: %s 단계의 승인자가 아닙니다", ErrNotApprover, p.Step)
+	}
+	select {
+	case p.decision <- ApprovalDecision{Approved: approve, By: user, Comment: comment, DecidedAt: s.clock.Now()}:
+	default:
+		return Approval{}, ErrApprovalNotFound
+	}
+	delete(s.approvals, p.key())
+	return p.Approval, nil
+}
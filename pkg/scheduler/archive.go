@@ -0,0 +1,509 @@
+// pkg/scheduler/archive.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// archiveTimeout bounds the upload of an archived response.
+const archiveTimeout = 30 * time.Second
+
+// maxStreamedOutput bounds the part of a streamed response that is kept as
+// the output of the execution.
+const maxStreamedOutput = 1 << 20
+
+// emptyHash is the hex SHA-256 of an empty body.
+var emptyHash = sha256Hex(nil)
+
+// pruneInterval is how often a job deletes archived responses past the
+// retention.
+const pruneInterval = time.Hour
+
+// ArchiveConfig keeps the full response body of every successful execution
+// in a local directory or an S3-compatible bucket. Responses are stored
+// under <scheduler ID>/YYYY/MM/DD/, named after the start time of the
+// execution.
+type ArchiveConfig struct {
+	// Dir is the local directory responses are written to. It must be
+	// below the directory set by WithArchiveRoot, against which relative
+	// directories are resolved.
+	Dir string `json:"dir,omitempty"`
+	// S3 uploads responses to a bucket instead.
+	S3 *S3Archive `json:"s3,omitempty"`
+	// Retention is a Go duration string after which archived responses
+	// are deleted, e.g. "720h". Empty keeps them forever.
+	Retention string `json:"retention,omitempty"`
+	// OnFailure also archives the responses of failed executions.
+	OnFailure bool `json:"onFailure,omitempty"`
+}
+
+// S3Archive is an S3-compatible bucket. Objects are addressed path-style,
+// which AWS and self-hosted stores such as MinIO both accept.
+type S3Archive struct {
+	// Endpoint is the URL of the store. Empty means AWS S3 in Region.
+	Endpoint string `json:"endpoint,omitempty"`
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region"`
+	// Prefix is put before the object keys, e.g. "scheduler/".
+	Prefix string `json:"prefix,omitempty"`
+	// AccessKeyID and SecretAccessKey are required: Endpoint may be any
+	// host, so the credentials of the server are never sent to it.
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+// validate checks the archive settings. A nil ArchiveConfig is valid.
+func (a *ArchiveConfig) validate() error {
+	if a == nil {
+		return nil
+	}
+	if (a.Dir == "") == (a.S3 == nil) {
+		return i18n.Errorf("%w: archive에는 dir과 s3 중 하나만 지정해야 합니다", ErrInvalidConfig)
+	}
+	if a.S3 != nil {
+		if a.S3.Bucket == "" || a.S3.Region == "" {
+			return i18n.Errorf("%w: archive.s3에는 bucket과 region이 필요합니다", ErrInvalidConfig)
+		}
+		if a.S3.AccessKeyID == "" || a.S3.SecretAccessKey == "" {
+			return i18n.Errorf("%w: archive.s3에는 accessKeyId와 secretAccessKey가 필요합니다", ErrInvalidConfig)
+		}
+		if _, err := a.S3.endpoint(); err != nil {
+			return i18n.Errorf("%w: archive.s3.endpoint 오류: %v", ErrInvalidConfig, err)
+		}
+	}
+	if _, err := a.retention(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WithArchiveRoot sets the directory below which ArchiveConfig.Dir must
+// be. Without it, or with an empty dir, archives can't be kept in local
+// directories, so that configs sent through the API can't write to the
+// files of the server.
+func WithArchiveRoot(dir string) Option {
+	return func(s *Scheduler) {
+		if dir != "" {
+			if abs, err := filepath.Abs(dir); err == nil {
+				dir = abs
+			}
+		}
+		s.archiveRoot = dir
+	}
+}
+
+// checkArchive rejects the archive directory of c if it's outside the
+// directory set by WithArchiveRoot.
+func (s *Scheduler) checkArchive(c Config) error {
+	if c.Archive == nil || c.Archive.Dir == "" {
+		return nil
+	}
+	if _, err := s.archivePath(c.Archive.Dir); err != nil {
+		return i18n.Errorf("%w: archive.dir: %v", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// archivePath returns the path of the archive directory dir under the
+// directory set by WithArchiveRoot, as resolveFile does for files.
+func (s *Scheduler) archivePath(dir string) (string, error) {
+	if s.archiveRoot == "" {
+		return "", i18n.Errorf("서버에 보관 디렉터리(-archive-root)가 설정되지 않아 응답을 보관할 수 없습니다: %s", dir)
+	}
+	path, err := resolveFile(s.archiveRoot, dir)
+	if err != nil {
+		return "", i18n.Errorf("보관 디렉터리가 -archive-root 밖에 있습니다: %s", dir)
+	}
+	return path, nil
+}
+
+// retention returns the parsed Retention, or zero when unset.
+func (a *ArchiveConfig) retention() (time.Duration, error) {
+	if a.Retention == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(a.Retention)
+	if err != nil || d <= 0 {
+		return 0, i18n.Errorf("%w: archive.retention은 양의 기간이어야 합니다: %q", ErrInvalidConfig, a.Retention)
+	}
+	return d, nil
+}
+
+// archiveDir returns the directory name of the responses of the scheduler
+// registered under id, with the characters unsafe in paths replaced.
+func archiveDir(id string) string {
+	name := []byte(id)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' && i > 0) {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+// archiveKey returns the slash-separated name, below the archive root, of
+// the response of the execution of id started at t.
+func archiveKey(id string, t time.Time, isJSON bool) string {
+	ext := ".txt"
+	if isJSON {
+		ext = ".json"
+	}
+	return archiveDir(id) + "/" + t.Format("2006/01/02/150405.000000000") + ext
+}
+
+// archive stores the response of rec, and deletes the responses past the
+// retention at most every pruneInterval.
+func (j *job) archive(rec Execution, output string) {
+	a := j.config.Archive
+	if a == nil || (!rec.Success && !a.OnFailure) {
+		return
+	}
+	sink := j.sink
+	streamed := sink != nil && sink.used.Load()
+	isJSON := json.Valid([]byte(output))
+	if streamed {
+		// output is only the beginning of the body in the spool file.
+		isJSON = sink.json
+	}
+	key := archiveKey(j.id, rec.StartedAt, isJSON)
+	ctx, cancel := context.WithTimeout(context.Background(), archiveTimeout)
+	defer cancel()
+
+	// The directory is resolved again, in case a symbolic link below the
+	// root changed since the config was validated.
+	var dir string
+	var err error
+	if a.Dir != "" {
+		if dir, err = j.sched.archivePath(a.Dir); err != nil {
+			j.logAt(LogError, "응답 보관 오류: %v", err)
+			return
+		}
+	}
+	var where string
+	switch {
+	case streamed:
+		if a.S3 != nil {
+			if _, err = sink.file.Seek(0, io.SeekStart); err == nil {
+				where, err = j.sched.putS3(ctx, a.S3, a.S3.Prefix+key, sink.file, sink.size, hex.EncodeToString(sink.hash.Sum(nil)))
+			}
+		} else {
+			where = filepath.Join(dir, filepath.FromSlash(key))
+			if err = os.MkdirAll(filepath.Dir(where), 0o755); err == nil {
+				sink.file.Chmod(0o644)
+				err = os.Rename(sink.file.Name(), where)
+			}
+		}
+	case a.S3 != nil:
+		where, err = j.sched.putS3(ctx, a.S3, a.S3.Prefix+key, strings.NewReader(output), int64(len(output)), sha256Hex([]byte(output)))
+	default:
+		where = filepath.Join(dir, filepath.FromSlash(key))
+		err = writeArchiveFile(where, []byte(output))
+	}
+	if err != nil {
+		j.logAt(LogError, "응답 보관 오류: %v", err)
+		return
+	}
+	j.logAt(LogInfo, "응답을 보관했습니다: %s", where)
+
+	retention, _ := a.retention()
+	if retention == 0 || time.Since(j.pruned) < pruneInterval {
+		return
+	}
+	j.pruned = time.Now()
+	cutoff := time.Now().Add(-retention)
+	var deleted int
+	if a.S3 != nil {
+		deleted, err = j.sched.pruneS3(ctx, a.S3, a.S3.Prefix+archiveDir(j.id)+"/", cutoff)
+	} else {
+		deleted, err = pruneArchiveDir(filepath.Join(dir, archiveDir(j.id)), cutoff)
+	}
+	if err != nil {
+		j.logAt(LogError, "보관 기간이 지난 응답 삭제 오류: %v", err)
+	}
+	if deleted > 0 {
+		j.logAt(LogInfo, "보관 기간이 지난 응답 %d개를 삭제했습니다.", deleted)
+	}
+}
+
+// writeArchiveFile writes data to name, creating its directories.
+func writeArchiveFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
+
+// pruneArchiveDir deletes the files below dir modified before cutoff, and
+// the directories left empty.
+func pruneArchiveDir(dir string, cutoff time.Time) (int, error) {
+	var deleted int
+	var dirs []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if p != dir {
+				dirs = append(dirs, p)
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(p); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	// Deepest first, so that parents emptied by their children go too.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i])
+	}
+	return deleted, err
+}
+
+// endpoint returns the URL of the bucket.
+func (s *S3Archive) endpoint() (*url.URL, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + s.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, i18n.Errorf("http 또는 https URL이어야 합니다: %q", endpoint)
+	}
+	u.Path = path.Join("/", u.Path, s.Bucket) + "/"
+	return u, nil
+}
+
+// signer returns the SigV4 signer of the bucket.
+func (s *S3Archive) signer() *AWSSigning {
+	return &AWSSigning{
+		Region:          s.Region,
+		Service:         "s3",
+		AccessKeyID:     s.AccessKeyID,
+		SecretAccessKey: s.SecretAccessKey,
+		SessionToken:    s.SessionToken,
+	}
+}
+
+// s3Do sends a signed request to the bucket and returns the response body,
+// failing on statuses other than 2xx. body is size bytes long with the hex
+// SHA-256 payloadHash.
+func (s *Scheduler) s3Do(ctx context.Context, b *S3Archive, method, key string, query url.Values, body io.Reader, size int64, payloadHash string) ([]byte, error) {
+	u, err := b.endpoint()
+	if err != nil {
+		return nil, err
+	}
+	u.Path += key
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if err := b.signer().signHash(req, payloadHash, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	resp, err := s.clients.Client(TransportConfig{}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, i18n.Errorf("S3 요청 실패 - 상태 코드: %d, 응답: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+// putS3 uploads body under key and returns its s3:// location.
+func (s *Scheduler) putS3(ctx context.Context, b *S3Archive, key string, body io.Reader, size int64, payloadHash string) (string, error) {
+	if _, err := s.s3Do(ctx, b, http.MethodPut, key, nil, body, size, payloadHash); err != nil {
+		return "", err
+	}
+	return "s3://" + b.Bucket + "/" + key, nil
+}
+
+// s3ListResult is the part of a ListObjectsV2 response used by pruneS3.
+type s3ListResult struct {
+	Contents []struct {
+		Key          string
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// pruneS3 deletes the objects under prefix last modified before cutoff.
+func (s *Scheduler) pruneS3(ctx context.Context, b *S3Archive, prefix string, cutoff time.Time) (int, error) {
+	var deleted int
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		body, err := s.s3Do(ctx, b, http.MethodGet, "", q, nil, 0, emptyHash)
+		if err != nil {
+			return deleted, err
+		}
+		var list s3ListResult
+		if err := xml.Unmarshal(body, &list); err != nil {
+			return deleted, err
+		}
+		for _, obj := range list.Contents {
+			if !obj.LastModified.Before(cutoff) {
+				continue
+			}
+			if _, err := s.s3Do(ctx, b, http.MethodDelete, obj.Key, nil, nil, 0, emptyHash); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if !list.IsTruncated || list.NextContinuationToken == "" {
+			return deleted, nil
+		}
+		token = list.NextContinuationToken
+	}
+}
+
+// archiveSink spools the response body of an execution to a temporary
+// file, so that large responses reach the archive without being held in
+// memory. It is created by the job before each attempt of a JobTypeHTTP or
+// JobTypeMonitor job with Config.Archive and handed to the executor
+// through the context.
+type archiveSink struct {
+	file *os.File
+	// hash is the SHA-256 of the body, which S3 uploads are signed with.
+	hash hash.Hash
+	size int64
+	// json reports whether the beginning of the body looks like JSON.
+	json bool
+	// used is set by the first response read into the sink; later ones,
+	// if any, are read into memory as usual.
+	used atomic.Bool
+}
+
+// archiveSinkKey is the context key of the archiveSink of an execution.
+type archiveSinkKey struct{}
+
+// newSink replaces the archive sink of j with a new one, or with none if j
+// doesn't stream its responses. Transformed responses are archived as
+// transformed, so they aren't streamed.
+func (j *job) newSink(ctx context.Context) context.Context {
+	j.discardSink()
+	a := j.config.Archive
+	if a == nil || j.config.Transform != nil {
+		return ctx
+	}
+	if t := j.config.jobType(); t != JobTypeHTTP && t != JobTypeMonitor {
+		return ctx
+	}
+	// Spooling into the archive directory lets the file be renamed into
+	// place.
+	dir := ""
+	if a.Dir != "" {
+		var err error
+		if dir, err = j.sched.archivePath(a.Dir); err != nil {
+			j.logAt(LogError, "응답 보관 오류: %v", err)
+			return ctx
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			j.logAt(LogError, "응답 보관 오류: %v", err)
+			return ctx
+		}
+	}
+	f, err := os.CreateTemp(dir, ".archive-*")
+	if err != nil {
+		j.logAt(LogError, "응답 보관 오류: %v", err)
+		return ctx
+	}
+	j.sink = &archiveSink{file: f, hash: sha256.New()}
+	return context.WithValue(ctx, archiveSinkKey{}, j.sink)
+}
+
+// discardSink closes the archive sink of j and removes its file unless it
+// was moved into the archive.
+func (j *job) discardSink() {
+	if j.sink == nil {
+		return
+	}
+	j.sink.file.Close()
+	os.Remove(j.sink.file.Name())
+	j.sink = nil
+}
+
+// readBody reads the decoded body of resp, failing when it's longer than
+// limit so that a small compressed response can't fill the memory. Inside
+// an execution with an archive sink the body is streamed into the sink
+// instead, and only its first maxStreamedOutput bytes are returned.
+func readBody(ctx context.Context, resp *http.Response, limit int64) (string, error) {
+	body, err := decodeBody(resp)
+	if err != nil {
+		return "", err
+	}
+	sink, _ := ctx.Value(archiveSinkKey{}).(*archiveSink)
+	if sink == nil || !sink.used.CompareAndSwap(false, true) {
+		b, err := io.ReadAll(io.LimitReader(body, limit+1))
+		if err == nil && int64(len(b)) > limit {
+			return "", i18n.Errorf("응답 본문이 %d바이트를 넘습니다", limit)
+		}
+		return string(b), err
+	}
+	head := &prefixBuffer{limit: maxStreamedOutput}
+	sink.size, err = io.Copy(io.MultiWriter(sink.file, sink.hash, head), body)
+	trimmed := bytes.TrimLeft(head.buf.Bytes(), " \t\r\n")
+	sink.json = len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+	return head.buf.String(), err
+}
+
+// prefixBuffer keeps the first limit bytes written to it and discards the
+// rest.
+type prefixBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *prefixBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
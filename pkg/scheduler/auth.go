@@ -0,0 +1,173 @@
+// pkg/scheduler/auth.go
+package scheduler
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Authentication schemes of AuthConfig.
+const (
+	AuthBasic  = "basic"
+	AuthDigest = "digest"
+)
+
+// AuthConfig authenticates the requests of HTTP-based job types with a
+// username and password, instead of a hand-written Authorization header.
+type AuthConfig struct {
+	// Type is AuthBasic (default) or AuthDigest. Digest authentication
+	// answers the server's challenge, so every execution makes two
+	// requests.
+	Type     string `json:"type,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	// PasswordEnv names an environment variable holding the password, which
+	// keeps it out of the stored config. It is used when Password is empty,
+	// and must be allowed by WithAllowedEnv like {{env "NAME"}} references.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+}
+
+// validate checks the auth settings. A nil AuthConfig is valid.
+func (a *AuthConfig) validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.Type != "" && a.Type != AuthBasic && a.Type != AuthDigest {
+		return i18n.Errorf("%w: auth.type은 basic 또는 digest여야 합니다", ErrInvalidConfig)
+	}
+	if a.Username == "" {
+		return i18n.Errorf("%w: auth.username이 필요합니다", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// do sends req with client, authenticated as configured. With digest
+// authentication a 401 challenge is answered by sending req again.
+func (a *AuthConfig) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	if a == nil {
+		return client.Do(req)
+	}
+	if a.Type != AuthDigest {
+		req.SetBasicAuth(a.Username, a.Password)
+		return client.Do(req)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := parseDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if challenge == nil {
+		return resp, nil
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	header, err := a.digest(challenge, req.Method, req.URL.RequestURI())
+	if err != nil {
+		return nil, err
+	}
+	retry.Header.Set("Authorization", header)
+	return client.Do(retry)
+}
+
+// parseDigestChallenge returns the parameters of the first Digest challenge
+// in the WWW-Authenticate headers, or nil if there is none.
+func parseDigestChallenge(headers []string) map[string]string {
+	for _, h := range headers {
+		scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+		params := make(map[string]string)
+		for rest != "" {
+			var key, value string
+			key, rest, _ = strings.Cut(rest, "=")
+			key = strings.ToLower(strings.Trim(key, " ,"))
+			rest = strings.TrimSpace(rest)
+			if strings.HasPrefix(rest, `"`) {
+				end := strings.IndexByte(rest[1:], '"')
+				if end < 0 {
+					end = len(rest) - 1
+				}
+				value, rest = rest[1:end+1], rest[min(end+2, len(rest)):]
+			} else {
+				value, rest, _ = strings.Cut(rest, ",")
+			}
+			params[key] = strings.TrimSpace(value)
+			rest = strings.TrimLeft(rest, " ,")
+		}
+		return params
+	}
+	return nil
+}
+
+// digest returns the Authorization header answering challenge (RFC 7616).
+func (a *AuthConfig) digest(challenge map[string]string, method, uri string) (string, error) {
+	algorithm := challenge["algorithm"]
+	var newHash func() hash.Hash
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", i18n.Errorf("지원하지 않는 digest 알고리즘입니다: %q", algorithm)
+	}
+	h := func(s string) string {
+		d := newHash()
+		d.Write([]byte(s))
+		return hex.EncodeToString(d.Sum(nil))
+	}
+
+	nonce, realm := challenge["nonce"], challenge["realm"]
+	b := make([]byte, 8)
+	rand.Read(b)
+	cnonce := hex.EncodeToString(b)
+	const nc = "00000001"
+
+	ha1 := h(a.Username + ":" + realm + ":" + a.Password)
+	if strings.HasSuffix(strings.ToUpper(algorithm), "-SESS") {
+		ha1 = h(ha1 + ":" + nonce + ":" + cnonce)
+	}
+	ha2 := h(method + ":" + uri)
+	qop := ""
+	for _, q := range strings.Split(challenge["qop"], ",") {
+		if strings.TrimSpace(q) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	var response string
+	if qop != "" {
+		response = h(ha1 + ":" + nonce + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	} else {
+		response = h(ha1 + ":" + nonce + ":" + ha2)
+	}
+	header := `Digest username="` + a.Username + `", realm="` + realm + `", nonce="` + nonce +
+		`", uri="` + uri + `", response="` + response + `"`
+	if algorithm != "" {
+		header += ", algorithm=" + algorithm
+	}
+	if opaque, ok := challenge["opaque"]; ok {
+		header += `, opaque="` + opaque + `"`
+	}
+	if qop != "" {
+		header += ", qop=" + qop + ", nc=" + nc + `, cnonce="` + cnonce + `"`
+	}
+	return header, nil
+}
@@ -0,0 +1,121 @@
+// pkg/scheduler/batch.go
+package scheduler
+
+import (
+	"errors"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Operations of a BatchOp.
+const (
+	BatchStart = "start"
+	BatchStop  = "stop"
+)
+
+// ErrBatchAborted is the error of the operations of a batch that weren't
+// executed because another operation of the batch was invalid.
+var ErrBatchAborted = errors.New("scheduler: batch aborted")
+
+// BatchOp is one operation of a batch: starting a scheduler with Config
+// under ID, which is generated when empty, or stopping the scheduler
+// registered under ID.
+type BatchOp struct {
+	Op     string  `json:"op"`
+	ID     string  `json:"id,omitempty"`
+	Config *Config `json:"config,omitempty"`
+}
+
+// BatchResult is the outcome of a BatchOp.
+type BatchResult struct {
+	Op string `json:"op"`
+	ID string `json:"id,omitempty"`
+	// Stopped reports for a successful BatchStop whether the scheduler was
+	// running, as Stop does.
+	Stopped bool `json:"stopped,omitempty"`
+	// Err is nil when the operation succeeded.
+	Err error `json:"-"`
+}
+
+// Batch executes ops in order and returns their results in the same
+// order. Every operation is checked first: when one of them is invalid,
+// such as a config that doesn't validate or an ID that isn't registered,
+// none is executed, the others fail with ErrBatchAborted and so does
+// Batch. An operation can still fail while executing, e.g. when another
+// request started the same ID meanwhile; the operations before it are not
+// undone.
+func (s *Scheduler) Batch(ops []BatchOp) ([]BatchResult, error) {
+	results := make([]BatchResult, len(ops))
+	configs := make([]Config, len(ops))
+	starting := make(map[string]bool)
+	aborted := false
+	for i, op := range ops {
+		results[i] = BatchResult{Op: op.Op, ID: op.ID}
+		switch op.Op {
+		case BatchStart:
+			if results[i].ID == "" {
+				results[i].ID = NewID()
+			}
+			configs[i], results[i].Err = s.checkStart(results[i].ID, op.Config)
+			if results[i].Err == nil && starting[results[i].ID] {
+				results[i].Err = ErrAlreadyExists
+			}
+			starting[results[i].ID] = true
+		case BatchStop:
+			s.mu.Lock()
+			if _, ok := s.jobs[op.ID]; !ok {
+				results[i].Err = ErrNotFound
+			}
+			s.mu.Unlock()
+		default:
+			results[i].Err = i18n.Errorf("%w: op는 start 또는 stop이어야 합니다: %q", ErrInvalidConfig, op.Op)
+		}
+		if results[i].Err != nil {
+			aborted = true
+		}
+	}
+	if aborted {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = ErrBatchAborted
+			}
+		}
+		return results, ErrBatchAborted
+	}
+
+	for i := range results {
+		if results[i].Op == BatchStart {
+			results[i].Err = s.Start(results[i].ID, configs[i])
+		} else {
+			results[i].Stopped, results[i].Err = s.Stop(results[i].ID)
+		}
+	}
+	return results, nil
+}
+
+// checkStart returns the error Start would return for config under id,
+// without starting it, and otherwise config to pass to Start.
+func (s *Scheduler) checkStart(id string, config *Config) (Config, error) {
+	if config == nil {
+		return Config{}, i18n.Errorf("%w: start 작업에는 config가 필요합니다", ErrInvalidConfig)
+	}
+	applied, err := s.applyTemplate(*config)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := s.validate(applied); err != nil {
+		return Config{}, err
+	}
+	if _, err := applied.firstFire(s.clock.Now()); err != nil {
+		return Config{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; ok {
+		return Config{}, ErrAlreadyExists
+	}
+	if err := s.checkLimitsLocked(id, applied); err != nil {
+		return Config{}, err
+	}
+	return *config, nil
+}
@@ -0,0 +1,139 @@
+// pkg/scheduler/blackout.go
+package scheduler
+
+import (
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Layouts accepted for the bounds of a BlackoutWindow.
+var (
+	dailyLayouts  = []string{"15:04:05", "15:04"}
+	oneOffLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04"}
+)
+
+// BlackoutWindow is a period in which fire times are skipped, e.g. while
+// the target system is under maintenance. Start and End are either times
+// of day (HH:mm or HH:mm:ss), for a window repeating every day, or local
+// date-times (YYYY-MM-DD HH:mm[:ss]) for a single window. A daily window
+// whose End is before its Start runs past midnight.
+type BlackoutWindow struct {
+	// Name is shown in the skip event instead of the bounds.
+	Name  string `json:"name,omitempty"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Weekdays limits a daily window to the days it starts on, named as in
+	// CalendarConfig.Weekdays. Empty means every day.
+	Weekdays []string `json:"weekdays,omitempty"`
+}
+
+// Validate checks the bounds and weekdays of the window.
+func (w BlackoutWindow) Validate() error {
+	start, daily, ok := parseBound(w.Start)
+	end, endDaily, endOK := parseBound(w.End)
+	if !ok || !endOK || daily != endDaily {
+		return i18n.Errorf("%w: 점검 시간대의 시작과 끝은 HH:mm 또는 YYYY-MM-DD HH:mm 형식이어야 합니다: %q ~ %q", ErrInvalidConfig, w.Start, w.End)
+	}
+	if !daily && !end.After(start) {
+		return i18n.Errorf("%w: 점검 시간대의 끝은 시작 이후여야 합니다: %q ~ %q", ErrInvalidConfig, w.Start, w.End)
+	}
+	for _, d := range w.Weekdays {
+		if _, ok := parseWeekdays(d); !ok {
+			return i18n.Errorf("%w: 알 수 없는 요일입니다: %q", ErrInvalidConfig, d)
+		}
+	}
+	return nil
+}
+
+// String returns the name of the window, or its bounds.
+func (w BlackoutWindow) String() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.Start + " ~ " + w.End
+}
+
+// contains reports whether t lies in the window. Invalid windows contain
+// nothing.
+func (w BlackoutWindow) contains(t time.Time) bool {
+	start, daily, ok := parseBound(w.Start)
+	end, _, endOK := parseBound(w.End)
+	if !ok || !endOK {
+		return false
+	}
+	t = t.Local()
+	if !daily {
+		return !t.Before(start) && t.Before(end)
+	}
+
+	from, to := secondOfDay(start), secondOfDay(end)
+	now := secondOfDay(t)
+	if from <= to {
+		return now >= from && now < to && w.on(t)
+	}
+	// The window runs past midnight: the part after midnight belongs to
+	// the window that started the day before.
+	if now >= from {
+		return w.on(t)
+	}
+	return now < to && w.on(t.AddDate(0, 0, -1))
+}
+
+// on reports whether the daily window starts on t's day.
+func (w BlackoutWindow) on(t time.Time) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, name := range w.Weekdays {
+		days, _ := parseWeekdays(name)
+		for _, d := range days {
+			if d == t.Weekday() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseBound parses a bound of a BlackoutWindow and reports whether it's a
+// time of day.
+func parseBound(s string) (t time.Time, daily, ok bool) {
+	for _, layout := range dailyLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true, true
+		}
+	}
+	for _, layout := range oneOffLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// secondOfDay returns the seconds since midnight of t's clock time.
+func secondOfDay(t time.Time) int {
+	h, m, s := t.Clock()
+	return (h*60+m)*60 + s
+}
+
+// WithBlackouts skips the fire times of every scheduler that fall in one of
+// the windows. Invalid windows are ignored; check them with Validate.
+func WithBlackouts(windows []BlackoutWindow) Option {
+	return func(s *Scheduler) {
+		s.settings.blackouts = windows
+	}
+}
+
+// blackout returns the global or per-scheduler window containing t.
+func (s *Scheduler) blackout(c Config, t time.Time) (BlackoutWindow, bool) {
+	for _, windows := range [][]BlackoutWindow{s.blackouts(), c.Blackouts} {
+		for _, w := range windows {
+			if w.contains(t) {
+				return w, true
+			}
+		}
+	}
+	return BlackoutWindow{}, false
+}
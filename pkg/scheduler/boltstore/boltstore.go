@@ -0,0 +1,370 @@
+// pkg/scheduler/boltstore/boltstore.go
+package boltstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+	"go-api-scheduler/pkg/storage"
+)
+
+// Retention of the capped histories.
+const (
+	maxExecutionsPerScheduler = 1000
+	maxAuditEntries           = 10000
+)
+
+// schemaVersion is the layout of the buckets written by this release,
+// kept in the meta bucket.
+const schemaVersion = 1
+
+// Top-level buckets. Executions and versions have a nested bucket per
+// scheduler, keyed by a big-endian sequence number.
+var (
+	bucketMeta       = []byte("meta")
+	bucketSchedulers = []byte("schedulers")
+	bucketExecutions = []byte("executions")
+	bucketAudit      = []byte("audit")
+	bucketVersions   = []byte("versions")
+	bucketTemplates  = []byte("templates")
+	bucketVariables  = []byte("variables")
+	// BucketLogs holds the log tail, for logger.BoltBackend.
+	BucketLogs = []byte("logs")
+)
+
+// Store keeps schedulers, executions, audit entries, config versions,
+// templates and variables in a bbolt database file. It needs no cgo, unlike SQLite, so
+// it suits single-binary deployments. The file is locked by one process
+// at a time. It implements scheduler.Store, scheduler.ExecutionStore,
+// scheduler.ExecutionRangeStore, scheduler.PurgeStore,
+// scheduler.AuditStore, scheduler.VersionStore, scheduler.TemplateStore
+// and scheduler.VariableStore.
+type Store struct {
+	db *bbolt.DB
+}
+
+func init() {
+	storage.Register("bbolt", func(o storage.Options) (storage.Store, error) {
+		s, err := Open(storage.DefaultPath(o.Path, "api-scheduler.bolt"))
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// Open opens (creating if needed) the bbolt database at path. It fails
+// when another process holds the file for more than a few seconds.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, i18n.Errorf("bbolt 열기 오류: %w", err)
+	}
+	if err := db.Update(migrate); err != nil {
+		db.Close()
+		return nil, i18n.Errorf("bbolt 스키마 생성 오류: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// migrate creates the buckets and records the schema version. It refuses
+// a file written by a newer release.
+func migrate(tx *bbolt.Tx) error {
+	meta, err := tx.CreateBucketIfNotExists(bucketMeta)
+	if err != nil {
+		return err
+	}
+	version := 0
+	if v := meta.Get([]byte("schemaVersion")); v != nil {
+		version, _ = strconv.Atoi(string(v))
+	}
+	if version > schemaVersion {
+		return i18n.Errorf("데이터베이스 스키마 버전 %d이 지원하는 버전 %d보다 높습니다", version, schemaVersion)
+	}
+	for _, name := range [][]byte{bucketSchedulers, bucketExecutions, bucketAudit, bucketVersions, bucketTemplates, bucketVariables, BucketLogs} {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+	return meta.Put([]byte("schemaVersion"), []byte(strconv.Itoa(schemaVersion)))
+}
+
+// DB returns the underlying database, e.g. to keep the log tail in it.
+func (s *Store) DB() *bbolt.DB {
+	return s.db
+}
+
+// Close closes the database and releases its file lock.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save creates or replaces the record with rec.ID.
+func (s *Store) Save(rec scheduler.Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketSchedulers).Put([]byte(rec.ID), data)
+	})
+}
+
+// Delete removes the record with the given ID, if any.
+func (s *Store) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketSchedulers).Delete([]byte(id))
+	})
+}
+
+// Load returns every stored record, ordered by ID.
+func (s *Store) Load() ([]scheduler.Record, error) {
+	var records []scheduler.Record
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketSchedulers).ForEach(func(id, data []byte) error {
+			var rec scheduler.Record
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return i18n.Errorf("스케줄러 %s 레코드 파싱 오류: %w", id, err)
+			}
+			records = append(records, rec)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// SaveExecution appends an execution to the scheduler's capped history.
+func (s *Store) SaveExecution(e scheduler.Execution) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.Bucket(bucketExecutions).CreateBucketIfNotExists([]byte(e.SchedulerID))
+		if err != nil {
+			return err
+		}
+		return appendCapped(b, e, maxExecutionsPerScheduler)
+	})
+}
+
+// ListExecutions returns the latest executions of a scheduler, newest first.
+func (s *Store) ListExecutions(schedulerID string, limit int) ([]scheduler.Execution, error) {
+	var list []scheduler.Execution
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucketExecutions).Bucket([]byte(schedulerID))
+		if b == nil {
+			return nil
+		}
+		return readNewest(b, limit, func(data []byte) error {
+			var e scheduler.Execution
+			if err := json.Unmarshal(data, &e); err != nil {
+				return err
+			}
+			list = append(list, e)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// ListExecutionsBetween returns the executions of every scheduler that
+// started in [from, to], oldest first. It reads the history of every
+// scheduler, so it's meant for occasional use such as the timeline.
+func (s *Store) ListExecutionsBetween(from, to time.Time) ([]scheduler.Execution, error) {
+	var list []scheduler.Execution
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketExecutions).ForEachBucket(func(id []byte) error {
+			return tx.Bucket(bucketExecutions).Bucket(id).ForEach(func(_, data []byte) error {
+				var e scheduler.Execution
+				if err := json.Unmarshal(data, &e); err != nil {
+					return err
+				}
+				if !e.StartedAt.Before(from) && !e.StartedAt.After(to) {
+					list = append(list, e)
+				}
+				return nil
+			})
+		})
+	})
+	sort.SliceStable(list, func(a, b int) bool { return list[a].StartedAt.Before(list[b].StartedAt) })
+	return list, err
+}
+
+// Purge deletes the execution history and config versions of a scheduler.
+func (s *Store) Purge(schedulerID string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{bucketExecutions, bucketVersions} {
+			err := tx.Bucket(name).DeleteBucket([]byte(schedulerID))
+			if err != nil && err != bbolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveAudit appends an entry to the capped audit trail.
+func (s *Store) SaveAudit(e scheduler.AuditEntry) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return appendCapped(tx.Bucket(bucketAudit), e, maxAuditEntries)
+	})
+}
+
+// ListAudit returns the latest audit entries, newest first.
+func (s *Store) ListAudit(limit int) ([]scheduler.AuditEntry, error) {
+	var list []scheduler.AuditEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return readNewest(tx.Bucket(bucketAudit), limit, func(data []byte) error {
+			var e scheduler.AuditEntry
+			if err := json.Unmarshal(data, &e); err != nil {
+				return err
+			}
+			list = append(list, e)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// SaveVersion appends a version to the config history of a scheduler.
+func (s *Store) SaveVersion(schedulerID string, v scheduler.Version) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.Bucket(bucketVersions).CreateBucketIfNotExists([]byte(schedulerID))
+		if err != nil {
+			return err
+		}
+		return appendCapped(b, v, 0)
+	})
+}
+
+// ListVersions returns the versions of a scheduler, oldest first.
+func (s *Store) ListVersions(schedulerID string) ([]scheduler.Version, error) {
+	var list []scheduler.Version
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucketVersions).Bucket([]byte(schedulerID))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, data []byte) error {
+			var v scheduler.Version
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			list = append(list, v)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// SaveTemplate creates or replaces the template with t.Name.
+func (s *Store) SaveTemplate(t scheduler.Template) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketTemplates).Put([]byte(t.Name), data)
+	})
+}
+
+// DeleteTemplate removes the template with the given name, if any.
+func (s *Store) DeleteTemplate(name string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketTemplates).Delete([]byte(name))
+	})
+}
+
+// LoadTemplates returns every stored template, ordered by name.
+func (s *Store) LoadTemplates() ([]scheduler.Template, error) {
+	var list []scheduler.Template
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketTemplates).ForEach(func(name, data []byte) error {
+			var t scheduler.Template
+			if err := json.Unmarshal(data, &t); err != nil {
+				return i18n.Errorf("템플릿 %s 파싱 오류: %w", name, err)
+			}
+			list = append(list, t)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// SaveVariable creates or replaces the variable with v.Name.
+func (s *Store) SaveVariable(v scheduler.Variable) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketVariables).Put([]byte(v.Name), data)
+	})
+}
+
+// DeleteVariable removes the variable with the given name, if any.
+func (s *Store) DeleteVariable(name string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketVariables).Delete([]byte(name))
+	})
+}
+
+// LoadVariables returns every stored variable, ordered by name.
+func (s *Store) LoadVariables() ([]scheduler.Variable, error) {
+	var list []scheduler.Variable
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketVariables).ForEach(func(name, data []byte) error {
+			var v scheduler.Variable
+			if err := json.Unmarshal(data, &v); err != nil {
+				return i18n.Errorf("변수 %s 파싱 오류: %w", name, err)
+			}
+			list = append(list, v)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// Key returns the key of the entry numbered seq in a bucket kept in
+// insertion order.
+func Key(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// appendCapped adds v as JSON under the next sequence number of b and
+// drops the entries beyond max, oldest first. A max of zero keeps all.
+func appendCapped(b *bbolt.Bucket, v any, max int) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	if err := b.Put(Key(seq), data); err != nil {
+		return err
+	}
+	if max > 0 && seq > uint64(max) {
+		return b.Delete(Key(seq - uint64(max)))
+	}
+	return nil
+}
+
+// readNewest calls fn for the newest limit entries of b, newest first. A
+// limit of zero or less reads all of them.
+func readNewest(b *bbolt.Bucket, limit int, fn func([]byte) error) error {
+	c := b.Cursor()
+	n := 0
+	for k, data := c.Last(); k != nil && (limit <= 0 || n < limit); k, data = c.Prev() {
+		if err := fn(data); err != nil {
+			return err
+		}
+		n++
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// pkg/scheduler/bus.go
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published on the event bus of a Scheduler.
+const (
+	// EventLog carries a log message in Event.Message.
+	EventLog = "log"
+	// EventStarted is published when the run goroutine of a scheduler
+	// starts, including after Update and Restore.
+	EventStarted = "started"
+	// EventStopped is published when the run goroutine of a scheduler
+	// returns.
+	EventStopped = "stopped"
+	// EventFired is published when a fire time starts an execution, after
+	// the blackout windows and the OnlyIf and SkipIf conditions.
+	EventFired = "fired"
+	// EventExecution is published for every finished execution attempt,
+	// including failed attempts that are retried.
+	EventExecution = "execution"
+	// EventSucceeded and EventFailed are published with the final
+	// execution of a fire time, once the retries are done.
+	EventSucceeded = "succeeded"
+	EventFailed    = "failed"
+	// EventCrashed is published when a scheduler fails: when its run
+	// goroutine recovers from a panic, after its EventStopped, or when its
+	// executions failed RestartThreshold times in a row.
+	EventCrashed = "crashed"
+	// EventRestarted is published when the RestartPolicy of a failed
+	// scheduler started it again, with the Crash it failed on.
+	EventRestarted = "restarted"
+	// EventState is published when a scheduler changes to the state in
+	// Event.State.
+	EventState = "state"
+	// EventDeleted is published when a scheduler is deleted.
+	EventDeleted = "deleted"
+	// EventStalled is published when CheckStalls finds a scheduler stuck,
+	// and EventUnstalled when it made progress again, with the Stall.
+	EventStalled   = "stalled"
+	EventUnstalled = "unstalled"
+	// EventSLAViolated is published when a scheduler violates its
+	// Config.SLA, with the SLAViolation.
+	EventSLAViolated = "sla-violated"
+	// EventApprovalRequested is published when a workflow step starts
+	// waiting for approval, with the Approval.
+	EventApprovalRequested = "approval-requested"
+)
+
+// Event is something that happened to a scheduler, published on the
+// scheduler's event bus.
+type Event struct {
+	Type        string    `json:"type"`
+	Time        time.Time `json:"time"`
+	SchedulerID string    `json:"schedulerId"`
+	// ExecutionID is the Execution.ID of the run an event is about, for
+	// EventFired and the log messages of the run.
+	ExecutionID string `json:"executionId,omitempty"`
+	// Message is the translated message of an EventLog event.
+	Message string `json:"message,omitempty"`
+	// Repeat counts how often the message of an EventLog event was
+	// logged in a row for the same failure, including this time, when it
+	// is more than one. Subscribers may collapse the repeats into one
+	// entry.
+	Repeat int `json:"repeat,omitempty"`
+	// Execution is set for EventExecution, EventSucceeded and EventFailed.
+	Execution *Execution `json:"execution,omitempty"`
+	// Crash is set for EventCrashed and EventRestarted.
+	Crash *Crash `json:"crash,omitempty"`
+	// Stall is set for EventStalled and EventUnstalled.
+	Stall *Stall `json:"stall,omitempty"`
+	// SLA is set for EventSLAViolated.
+	SLA *SLAViolation `json:"sla,omitempty"`
+	// Approval is set for EventApprovalRequested.
+	Approval *Approval `json:"approval,omitempty"`
+	// FailureStreak is set for EventSucceeded and EventFailed: the
+	// executions failed in a row up to a failed one, or the failures a
+	// successful one ended.
+	FailureStreak int `json:"failureStreak,omitempty"`
+	// State is the new state of an EventState event.
+	State string `json:"state,omitempty"`
+
+	// job is the job the event is about, for the built-in subscribers.
+	job *job
+}
+
+// Subscriber receives the events of a Scheduler. HandleEvent is called
+// synchronously from the scheduler goroutines, in the order the
+// subscribers were added, and must not block.
+type Subscriber interface {
+	HandleEvent(Event)
+}
+
+// SubscriberFunc adapts a function to a Subscriber.
+type SubscriberFunc func(Event)
+
+// HandleEvent calls f(ev).
+func (f SubscriberFunc) HandleEvent(ev Event) {
+	f(ev)
+}
+
+// bus delivers events to subscribers.
+type bus struct {
+	mu     sync.RWMutex
+	subs   []busSubscription
+	nextID int
+}
+
+type busSubscription struct {
+	id  int
+	sub Subscriber
+}
+
+// add registers sub and returns a function that removes it.
+func (b *bus) add(sub Subscriber) (remove func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subs = append(b.subs, busSubscription{id: id, sub: sub})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subs {
+			if s.id == id {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publish delivers ev to every subscriber.
+func (b *bus) publish(ev Event) {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+	for _, s := range subs {
+		s.sub.HandleEvent(ev)
+	}
+}
+
+// AddSubscriber registers sub to receive every event of the scheduler and
+// returns a function that removes it.
+func (s *Scheduler) AddSubscriber(sub Subscriber) (remove func()) {
+	return s.bus.add(sub)
+}
+
+// Subscribe registers fn to receive the log messages of the scheduler
+// (EventLog events) and returns a function that removes the subscription.
+// Like a Subscriber, fn must not block.
+func (s *Scheduler) Subscribe(fn func(Event)) (unsubscribe func()) {
+	return s.AddSubscriber(SubscriberFunc(func(ev Event) {
+		if ev.Type == EventLog {
+			fn(ev)
+		}
+	}))
+}
+
+// SubscribeExecutions registers fn to receive every finished execution,
+// including failed attempts that are retried, and returns a function that
+// removes the subscription. Like Subscribe, fn must not block.
+func (s *Scheduler) SubscribeExecutions(fn func(Execution)) (unsubscribe func()) {
+	return s.AddSubscriber(SubscriberFunc(func(ev Event) {
+		if ev.Type == EventExecution {
+			fn(*ev.Execution)
+		}
+	}))
+}
+
+// publish publishes an event of type typ about j.
+func (j *job) publish(typ string, e *Execution) {
+	j.sched.bus.publish(Event{
+		Type:        typ,
+		Time:        j.sched.clock.Now(),
+		SchedulerID: j.id,
+		ExecutionID: j.execID,
+		Execution:   e,
+		job:         j,
+	})
+}
+
+// publishResult publishes the final execution rec of a fire time as
+// EventSucceeded or EventFailed, with the failure streak it extended or
+// ended.
+func (j *job) publishResult(rec *Execution, streak int) {
+	typ := EventFailed
+	if rec.Success {
+		typ = EventSucceeded
+	}
+	j.sched.bus.publish(Event{
+		Type:          typ,
+		Time:          j.sched.clock.Now(),
+		SchedulerID:   j.id,
+		ExecutionID:   j.execID,
+		Execution:     rec,
+		FailureStreak: streak,
+		job:           j,
+	})
+}
+
+// historySubscriber saves executions to an ExecutionStore.
+type historySubscriber struct {
+	s     *Scheduler
+	store ExecutionStore
+}
+
+// HandleEvent saves the executions of EventExecution events, truncating
+// their output.
+func (h historySubscriber) HandleEvent(ev Event) {
+	if ev.Type != EventExecution {
+		return
+	}
+	e := *ev.Execution
+	if len(e.Output) > maxStoredOutput {
+		e.Output = e.Output[:maxStoredOutput]
+	}
+	if err := h.store.SaveExecution(e); err != nil {
+		h.s.emit(e.SchedulerID, "실행 기록 저장 오류: %v", err)
+	}
+}
+
+// notifySubscriber sends the final executions of fire times to the
+// webhook of their job. Monitors notify on state changes instead, and jobs
+// with Config.Diff only when the response changed.
+type notifySubscriber struct {
+	s *Scheduler
+}
+
+// HandleEvent notifies the EventSucceeded, EventFailed, EventCrashed,
+// EventRestarted, EventStalled, EventUnstalled, EventSLAViolated and
+// EventApprovalRequested events.
+func (n notifySubscriber) HandleEvent(ev Event) {
+	if ev.job == nil {
+		return
+	}
+	config := ev.job.config
+	notify := n.s.notifyConfig(config)
+	if ev.Type == EventCrashed {
+		n.s.notifyEvent(notify, ev.job.notification(ev.Type, CrashEvent{
+			SchedulerID: ev.SchedulerID,
+			Name:        config.Name,
+			Crash:       *ev.Crash,
+			Restarting:  config.restarts(ev.Crash.Reason),
+		}))
+		return
+	}
+	if ev.Type == EventStalled || ev.Type == EventUnstalled {
+		n.s.notifyEvent(notify, ev.job.notification(ev.Type, StallEvent{
+			SchedulerID: ev.SchedulerID,
+			Name:        config.Name,
+			Stall:       *ev.Stall,
+			Recovered:   ev.Type == EventUnstalled,
+		}))
+		return
+	}
+	if ev.Type == EventSLAViolated {
+		n.s.notifyEvent(notify, ev.job.notification(ev.Type, SLAEvent{
+			SchedulerID: ev.SchedulerID,
+			Name:        config.Name,
+			Violation:   *ev.SLA,
+		}))
+		return
+	}
+	if ev.Type == EventApprovalRequested {
+		n.s.notifyEvent(notify, ev.job.notification(ev.Type, ApprovalEvent{
+			SchedulerID: ev.SchedulerID,
+			Name:        config.Name,
+			Approval:    *ev.Approval,
+		}))
+		return
+	}
+	if ev.Type == EventRestarted {
+		n.s.notifyEvent(notify, ev.job.notification(ev.Type, RestartEvent{
+			SchedulerID: ev.SchedulerID,
+			Name:        config.Name,
+			Time:        ev.Time,
+			Crash:       *ev.Crash,
+		}))
+		return
+	}
+	if ev.Type != EventSucceeded && ev.Type != EventFailed {
+		return
+	}
+	if config.jobType() == JobTypeMonitor {
+		return
+	}
+	if e := ev.Execution; config.Diff == nil || !e.Success || len(e.Changes) > 0 {
+		msg := ev.job.notification(ev.Type, *e)
+		msg.FailureStreak = ev.FailureStreak
+		n.s.notify(notify, msg, e.Success)
+	}
+}
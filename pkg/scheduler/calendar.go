@@ -0,0 +1,356 @@
+// pkg/scheduler/calendar.go
+package scheduler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+const (
+	// dateLayout is the layout of the dates in a CalendarConfig.
+	dateLayout = "2006-01-02"
+	// maxCalendarDays bounds how many days ahead the next allowed day is
+	// searched for.
+	maxCalendarDays = 3660
+	// holidayRefresh is how long a loaded holiday calendar is used before
+	// it's loaded again.
+	holidayRefresh = 24 * time.Hour
+	// holidayTimeout bounds the download of a holiday calendar.
+	holidayTimeout = 30 * time.Second
+	// maxEventDays bounds the days marked by a single iCal event.
+	maxEventDays = 366
+)
+
+// weekdays maps the names accepted in CalendarConfig.Weekdays.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// CalendarConfig restricts the days on which fire times are executed. Fire
+// times on other days are skipped. Days are in local time.
+type CalendarConfig struct {
+	// Weekdays lists the allowed days of the week ("mon" to "sun", or
+	// "weekdays" and "weekends"). Empty means every day.
+	Weekdays []string `json:"weekdays,omitempty"`
+	// Dates lists the allowed dates (YYYY-MM-DD). Empty means every date.
+	Dates []string `json:"dates,omitempty"`
+	// ExcludeDates lists dates (YYYY-MM-DD) on which nothing is executed.
+	ExcludeDates []string `json:"excludeDates,omitempty"`
+	// Holidays is the path or http(s) URL of a holiday calendar whose days
+	// are excluded: an iCal file, or a text file with one YYYY-MM-DD date
+	// per line. It's loaded again every day. Paths must be below the
+	// directory set by WithFileRoot.
+	Holidays string `json:"holidays,omitempty"`
+}
+
+// validate checks the calendar settings. A nil CalendarConfig is valid.
+func (c *CalendarConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, d := range c.Weekdays {
+		if _, ok := parseWeekdays(d); !ok {
+			return i18n.Errorf("%w: 알 수 없는 요일입니다: %q", ErrInvalidConfig, d)
+		}
+	}
+	for _, dates := range [][]string{c.Dates, c.ExcludeDates} {
+		for _, d := range dates {
+			if _, err := time.Parse(dateLayout, d); err != nil {
+				return i18n.Errorf("%w: 날짜 형식은 YYYY-MM-DD여야 합니다: %q", ErrInvalidConfig, d)
+			}
+		}
+	}
+	return nil
+}
+
+// parseWeekdays returns the days named by s.
+func parseWeekdays(s string) ([]time.Weekday, bool) {
+	switch s = strings.ToLower(s); s {
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, true
+	case "weekends":
+		return []time.Weekday{time.Saturday, time.Sunday}, true
+	}
+	if d, ok := weekdays[s]; ok {
+		return []time.Weekday{d}, true
+	}
+	if len(s) > 3 {
+		// Accept full names such as "monday".
+		if d, ok := weekdays[s[:3]]; ok && strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return []time.Weekday{d}, true
+		}
+	}
+	return nil, false
+}
+
+// calendar decides whether fire times may be executed.
+type calendar struct {
+	weekdays map[time.Weekday]bool
+	dates    map[string]bool
+	excluded map[string]bool
+	// holidays returns the holiday dates, or nil when there are none.
+	holidays func() map[string]bool
+}
+
+// newCalendar returns the calendar of c, or nil when c allows every day.
+func (s *Scheduler) newCalendar(id string, c *CalendarConfig) *calendar {
+	if c == nil {
+		return nil
+	}
+	cal := &calendar{
+		dates:    dateSet(c.Dates),
+		excluded: dateSet(c.ExcludeDates),
+	}
+	for _, name := range c.Weekdays {
+		days, _ := parseWeekdays(name)
+		for _, d := range days {
+			if cal.weekdays == nil {
+				cal.weekdays = make(map[time.Weekday]bool)
+			}
+			cal.weekdays[d] = true
+		}
+	}
+	if c.Holidays != "" {
+		cal.holidays = func() map[string]bool {
+			return s.holidays.get(c.Holidays, s.loadHolidays, func(err error) {
+				s.emit(id, "공휴일 달력 로드 오류: %v", err)
+			})
+		}
+	}
+	return cal
+}
+
+// dateSet returns the dates as a set, or nil when there are none.
+func dateSet(dates []string) map[string]bool {
+	if len(dates) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(dates))
+	for _, d := range dates {
+		set[d] = true
+	}
+	return set
+}
+
+// allows reports whether t's day may have executions. A nil calendar
+// allows every day.
+func (c *calendar) allows(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	t = t.Local()
+	day := t.Format(dateLayout)
+	if c.weekdays != nil && !c.weekdays[t.Weekday()] {
+		return false
+	}
+	if c.dates != nil && !c.dates[day] {
+		return false
+	}
+	if c.excluded[day] {
+		return false
+	}
+	if c.holidays != nil && c.holidays()[day] {
+		return false
+	}
+	return true
+}
+
+// holidayCache shares loaded holiday calendars between schedulers.
+type holidayCache struct {
+	// mu protects entries, but isn't held while a calendar loads.
+	mu      sync.Mutex
+	entries map[string]*holidayEntry
+}
+
+type holidayEntry struct {
+	days     map[string]bool
+	loadedAt time.Time
+	// loading is closed when the load in progress ends. It is nil when
+	// none is.
+	loading chan struct{}
+}
+
+// get returns the holiday dates of source, loading it with load when it
+// hasn't been loaded for holidayRefresh. Only one load of a source runs at
+// a time: callers wait for a first load, and use the previously loaded
+// dates while the calendar is loaded again. Load errors are reported to
+// onError and the previously loaded dates, if any, are used.
+func (h *holidayCache) get(source string, load func(string) (map[string]bool, error), onError func(error)) map[string]bool {
+	h.mu.Lock()
+	if h.entries == nil {
+		h.entries = make(map[string]*holidayEntry)
+	}
+	e := h.entries[source]
+	if e == nil {
+		e = &holidayEntry{}
+		h.entries[source] = e
+	}
+	if !e.loadedAt.IsZero() && (time.Since(e.loadedAt) < holidayRefresh || e.loading != nil) {
+		days := e.days
+		h.mu.Unlock()
+		return days
+	}
+	if e.loading != nil {
+		loading := e.loading
+		h.mu.Unlock()
+		<-loading
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return e.days
+	}
+	e.loading = make(chan struct{})
+	h.mu.Unlock()
+
+	days, err := load(source)
+	if err != nil {
+		onError(err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil {
+		e.days = days
+	}
+	// Failed loads aren't retried on every fire time either.
+	e.loadedAt = time.Now()
+	close(e.loading)
+	e.loading = nil
+	return e.days
+}
+
+// isURL reports whether the holiday calendar source is an http(s) URL
+// rather than a path.
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// loadHolidays reads the holiday calendar at source, an http(s) URL
+// downloaded with the shared clients or a path below the directory set by
+// WithFileRoot.
+func (s *Scheduler) loadHolidays(source string) (map[string]bool, error) {
+	var r io.Reader
+	if isURL(source) {
+		ctx, cancel := context.WithTimeout(context.Background(), holidayTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.clients.Client(TransportConfig{}).Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, i18n.Errorf("공휴일 달력 다운로드 오류: 상태 코드 %d", resp.StatusCode)
+		}
+		r = resp.Body
+	} else {
+		path, err := resolveFile(s.fileRoot, source)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return parseHolidays(r)
+}
+
+// parseHolidays reads an iCal calendar, whose all-day and timed events
+// mark their days as holidays, or a list of YYYY-MM-DD dates with one date
+// per line and "#" starting comments.
+func parseHolidays(r io.Reader) (map[string]bool, error) {
+	days := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, strings.TrimRight(sc.Text(), "\r"))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 || !strings.HasPrefix(strings.TrimSpace(lines[0]), "BEGIN:VCALENDAR") {
+		for _, line := range lines {
+			line, _, _ = strings.Cut(line, "#")
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			if _, err := time.Parse(dateLayout, line); err != nil {
+				return nil, i18n.Errorf("공휴일 날짜 형식이 올바르지 않습니다: %q", line)
+			}
+			days[line] = true
+		}
+		return days, nil
+	}
+
+	// iCal folds long lines by starting the continuation with a space.
+	var unfolded []string
+	for _, line := range lines {
+		if n := len(unfolded); n > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			unfolded[n-1] += line[1:]
+			continue
+		}
+		unfolded = append(unfolded, line)
+	}
+
+	var start, end time.Time
+	for _, line := range unfolded {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			start, end = time.Time{}, time.Time{}
+		case "DTSTART":
+			start = icalDate(value)
+		case "DTEND":
+			end = icalDate(value)
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || start.IsZero() {
+				continue
+			}
+			// DTEND is exclusive; events without one last a day.
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d, n := start, 0; d.Before(end) && n < maxEventDays; d, n = d.AddDate(0, 0, 1), n+1 {
+				days[d.Format(dateLayout)] = true
+			}
+		}
+	}
+	return days, nil
+}
+
+// icalDate returns the day of an iCal DATE or DATE-TIME value, or the zero
+// time when it isn't one.
+func icalDate(value string) time.Time {
+	if len(value) < 8 {
+		return time.Time{}
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,322 @@
+// pkg/scheduler/chain.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeChain runs a sequence of HTTP requests on every fire time, each
+// step using values extracted from the responses before it.
+const JobTypeChain = "chain"
+
+// maxChainSteps bounds the steps of a chain.
+const maxChainSteps = 20
+
+// ChainConfig configures a JobTypeChain job.
+type ChainConfig struct {
+	// Vars are the initial variables. They are referenced as {{name}} in
+	// the URL, payload and header values of the steps.
+	Vars map[string]string `json:"vars,omitempty"`
+	// Steps run in order; the chain stops at the first failed step.
+	Steps []ChainStep `json:"steps"`
+}
+
+// ChainStep is one HTTP request of a chain. The request is sent like a
+// JobTypeHTTP job's, with the job's Headers and Transport.
+type ChainStep struct {
+	// Name identifies the step in the output. Empty means "step N".
+	Name       string            `json:"name,omitempty"`
+	APIURL     string            `json:"apiURL"`
+	HTTPMethod string            `json:"httpMethod,omitempty"`
+	Payload    string            `json:"payload,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	// ExpectStatus lists the status codes that count as success. Empty
+	// means 200 only.
+	ExpectStatus []int `json:"expectStatus,omitempty"`
+	// SuccessIf is a further condition on the response, such as
+	// "response.body.status == 'ok'". Paths start at response (statusCode,
+	// body, durationMs) or vars.
+	SuccessIf string `json:"successIf,omitempty"`
+	// Extract sets variables from the JSON response body for the steps
+	// after this one, e.g. {"token": "$.data.accessToken"}.
+	Extract map[string]string `json:"extract,omitempty"`
+}
+
+// validate checks the chain settings. A nil ChainConfig is valid.
+func (c *ChainConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Steps) == 0 || len(c.Steps) > maxChainSteps {
+		return i18n.Errorf("%w: chain.steps는 1개에서 %d개 사이여야 합니다", ErrInvalidConfig, maxChainSteps)
+	}
+	for i, step := range c.Steps {
+		name := step.name(i)
+		if step.APIURL == "" {
+			return i18n.Errorf("%w: %s 단계에 apiURL이 없습니다", ErrInvalidConfig, name)
+		}
+		// Chain variables are taken as values, as variable references are.
+		if err := validateFormPayload(name+".payload", chainVar.ReplaceAllString(step.Payload, "0")); err != nil {
+			return err
+		}
+		if step.SuccessIf != "" {
+			if _, err := parseExpr(step.SuccessIf, "response", "vars"); err != nil {
+				return i18n.Errorf("%w: %s 단계의 successIf 식 오류: %v", ErrInvalidConfig, name, err)
+			}
+		}
+		for v, path := range step.Extract {
+			if _, err := splitJSONPath(path); err != nil {
+				return i18n.Errorf("%w: %s 단계의 extract.%s 경로 오류: %v", ErrInvalidConfig, name, v, err)
+			}
+		}
+	}
+	return nil
+}
+
+// name returns the name of the i-th step.
+func (s ChainStep) name(i int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return "step " + strconv.Itoa(i+1)
+}
+
+// ChainStepReport is the outcome of one step in a ChainReport.
+type ChainStepReport struct {
+	Name       string  `json:"name"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	Success    bool    `json:"success"`
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// ChainReport is the JSON output of a JobTypeChain execution. Output is the
+// response body of the last step that ran. Variable values are left out,
+// since they often hold credentials.
+type ChainReport struct {
+	Steps  []ChainStepReport `json:"steps"`
+	Output string            `json:"output,omitempty"`
+}
+
+// ChainExecutor runs the steps of a JobTypeChain job through Executor,
+// which is the HTTP executor when it's registered by New.
+type ChainExecutor struct {
+	Executor Executor
+}
+
+// Execute runs the steps in order and succeeds when every step does. The
+// status code of the result is the last step's.
+func (e *ChainExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	cc := config.Chain
+	if cc == nil || len(cc.Steps) == 0 {
+		return Result{}, i18n.Errorf("체인 설정(chain)이 없습니다")
+	}
+	vars := make(map[string]string, len(cc.Vars))
+	for k, v := range cc.Vars {
+		vars[k] = v
+	}
+
+	var report ChainReport
+	var result Result
+	var stepErr error
+	for i, step := range cc.Steps {
+		sr, res, err := e.step(ctx, config, step, i, vars)
+		if err != nil && sr.Error == "" {
+			sr.Error = err.Error()
+		}
+		report.Steps = append(report.Steps, sr)
+		report.Output = res.Output
+		result.StatusCode = res.StatusCode
+		result.Timing = res.Timing
+		if err != nil {
+			stepErr = i18n.Errorf("%s 단계 오류: %w", sr.Name, err)
+			break
+		}
+		if !sr.Success {
+			break
+		}
+	}
+
+	out, err := json.Marshal(report)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Output = string(out)
+	last := report.Steps[len(report.Steps)-1]
+	result.Success = stepErr == nil && last.Success && len(report.Steps) == len(cc.Steps)
+	return result, stepErr
+}
+
+// step sends the i-th request and stores its extracted values in vars.
+func (e *ChainExecutor) step(ctx context.Context, config Config, step ChainStep, i int, vars map[string]string) (ChainStepReport, Result, error) {
+	sr := ChainStepReport{Name: step.name(i)}
+
+	req := config
+	var err error
+	if req.APIURL, err = expand(step.APIURL, vars, inURL); err != nil {
+		return sr, Result{}, err
+	}
+	if req.Payload, err = expand(step.Payload, vars, inJSON); err != nil {
+		return sr, Result{}, err
+	}
+	req.HTTPMethod = step.HTTPMethod
+	req.PayloadFrom = nil
+	req.PayloadType, req.ContentType, req.RawQuery = "", "", false
+	req.Files = nil
+	headers := make(map[string]string, len(step.Headers))
+	for name, value := range step.Headers {
+		if headers[name], err = expand(value, vars, inText); err != nil {
+			return sr, Result{}, err
+		}
+	}
+	req.Headers = mergeMaps(config.Headers, headers)
+
+	started := time.Now()
+	res, err := e.Executor.Execute(ctx, req)
+	sr.DurationMs = ms(time.Since(started))
+	sr.StatusCode = res.StatusCode
+	if err != nil {
+		return sr, res, err
+	}
+
+	if len(step.ExpectStatus) > 0 {
+		sr.Success = slices.Contains(step.ExpectStatus, res.StatusCode)
+	} else {
+		sr.Success = res.Success
+	}
+	var body any = res.Output
+	var parsed any
+	if json.Unmarshal([]byte(res.Output), &parsed) == nil {
+		body = parsed
+	}
+	if sr.Success && step.SuccessIf != "" {
+		c, err := parseExpr(step.SuccessIf, "response", "vars")
+		if err != nil {
+			return sr, res, err
+		}
+		scope := make(map[string]any, len(vars))
+		for k, v := range vars {
+			scope[k] = v
+		}
+		sr.Success = evalCondition(c, map[string]any{
+			"response": map[string]any{
+				"statusCode": float64(res.StatusCode),
+				"body":       body,
+				"durationMs": sr.DurationMs,
+			},
+			"vars": scope,
+		})
+		if !sr.Success {
+			sr.Error = i18n.Sprintf(i18n.Default(), "성공 조건(successIf)을 만족하지 않습니다: %s", step.SuccessIf)
+		}
+	}
+	if !sr.Success {
+		return sr, res, nil
+	}
+
+	for name, path := range step.Extract {
+		steps, err := splitJSONPath(path)
+		if err != nil {
+			return sr, res, err
+		}
+		v, ok := lookupJSONPath(body, steps)
+		if !ok {
+			sr.Success = false
+			sr.Error = i18n.Sprintf(i18n.Default(), "응답에서 %s 값을 찾을 수 없습니다: %s", name, path)
+			return sr, res, nil
+		}
+		if s, ok := v.(string); ok {
+			vars[name] = s
+		} else {
+			b, _ := json.Marshal(v)
+			vars[name] = string(b)
+		}
+	}
+	return sr, res, nil
+}
+
+// chainVar matches a {{name}} variable reference.
+var chainVar = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// expand replaces the {{name}} references in s with the values in vars,
+// escaped for ctx.
+func expand(s string, vars map[string]string, ctx refContext) (string, error) {
+	var err error
+	out := replaceRefs(s, chainVar, ctx, func(m []string) string {
+		v, ok := vars[m[1]]
+		if !ok && err == nil {
+			err = i18n.Errorf("정의되지 않은 변수입니다: %s", m[1])
+		}
+		return v
+	})
+	return out, err
+}
+
+// splitJSONPath splits a path such as "$.items[0].id" into member names
+// and indexes.
+func splitJSONPath(path string) ([]any, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, i18n.Errorf("경로는 $로 시작해야 합니다: %q", path)
+	}
+	var steps []any
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			n := strings.IndexAny(rest[1:], ".[")
+			if n < 0 {
+				n = len(rest) - 1
+			}
+			if n == 0 {
+				return nil, i18n.Errorf("'.' 뒤에 이름이 필요합니다")
+			}
+			steps = append(steps, rest[1:n+1])
+			rest = rest[n+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, i18n.Errorf("닫는 대괄호가 필요합니다")
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, i18n.Errorf("'[' 뒤에 0 이상의 인덱스가 필요합니다")
+			}
+			steps = append(steps, i)
+			rest = rest[end+1:]
+		default:
+			return nil, i18n.Errorf("예상하지 못한 %q", rest[:1])
+		}
+	}
+	return steps, nil
+}
+
+// lookupJSONPath returns the value at steps below v.
+func lookupJSONPath(v any, steps []any) (any, bool) {
+	for _, step := range steps {
+		switch step := step.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[step]; !ok {
+				return nil, false
+			}
+		case int:
+			a, ok := v.([]any)
+			if !ok || step >= len(a) {
+				return nil, false
+			}
+			v = a[step]
+		}
+	}
+	return v, true
+}
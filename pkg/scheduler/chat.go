@@ -0,0 +1,97 @@
+// pkg/scheduler/chat.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// DefaultTelegramAPIURL is the Bot API server of TelegramNotifier.
+const DefaultTelegramAPIURL = "https://api.telegram.org"
+
+// The longest message texts Telegram and Discord accept.
+const (
+	maxTelegramText = 4096
+	maxDiscordText  = 2000
+)
+
+// defaultChatTemplate is the message of a Telegram or Discord channel
+// without a Template.
+const defaultChatTemplate = `{{if eq .Event "report"}}📊 {{.Payload.summary}}{{else}}` +
+	`{{if eq .Event "failed" "crashed" "stalled" "sla-violated"}}🚨{{else if eq .Event "approval-requested"}}✋{{else}}✅{{end}} {{or .Name .SchedulerID}} {{.Event}}` +
+	`{{with .Payload.statusCode}} (HTTP {{.}}){{end}}{{with .Payload.error}}: {{.}}{{end}}{{with .Payload.state}} {{.}}{{end}}{{end}}`
+
+// chatMessage returns the message of a rendered template: the template
+// itself when it is a JSON object, or else a message with the text in
+// field, cut to max bytes when max is positive.
+func chatMessage(text, field string, max int) map[string]any {
+	var msg map[string]any
+	if json.Unmarshal([]byte(strings.TrimSpace(text)), &msg) == nil && msg != nil {
+		return msg
+	}
+	if max > 0 {
+		text = truncate(text, max)
+	}
+	return map[string]any{field: text}
+}
+
+// TelegramNotifier sends notifications through a Telegram bot to the
+// chat of ChannelTelegram channels.
+type TelegramNotifier struct {
+	Clients *ClientManager
+	// Token is the token of the bot, as given by @BotFather.
+	Token string
+	// APIURL is the Bot API server. Empty means DefaultTelegramAPIURL.
+	APIURL string
+}
+
+// Notify sends n to the chat of ch with sendMessage. The rendered template
+// is the text of the message, or the sendMessage parameters themselves
+// when it is a JSON object, e.g. with a parse_mode.
+func (t *TelegramNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	if t.Token == "" {
+		return i18n.Errorf("텔레그램 봇 토큰이 설정되지 않았습니다")
+	}
+	text, err := n.Render(ch.Template, defaultChatTemplate)
+	if err != nil {
+		return err
+	}
+	msg := chatMessage(text, "text", maxTelegramText)
+	if _, ok := msg["chat_id"]; !ok {
+		msg["chat_id"] = ch.ChatID
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	base := t.APIURL
+	if base == "" {
+		base = DefaultTelegramAPIURL
+	}
+	ch.URL = strings.TrimSuffix(base, "/") + "/bot" + t.Token + "/sendMessage"
+	return postNotification(ctx, t.Clients, ch, "application/json", body)
+}
+
+// DiscordNotifier posts notifications to the Discord webhook URL of
+// ChannelDiscord channels.
+type DiscordNotifier struct {
+	Clients *ClientManager
+}
+
+// Notify posts n to ch.URL. The rendered template is the content of the
+// message, or the message itself when it is a JSON object, e.g. with
+// embeds.
+func (d *DiscordNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	text, err := n.Render(ch.Template, defaultChatTemplate)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(chatMessage(text, "content", maxDiscordText))
+	if err != nil {
+		return err
+	}
+	return postNotification(ctx, d.Clients, ch, "application/json", body)
+}
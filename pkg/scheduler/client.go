@@ -0,0 +1,432 @@
+// pkg/scheduler/client.go
+package scheduler
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/http2"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Defaults used by NewClientManager for zero arguments.
+const (
+	defaultMaxIdleConnsPerHost = 10
+	defaultRequestTimeout      = 10 * time.Second
+	defaultMaxResponseSize     = 10 << 20
+)
+
+// HTTP versions selectable by TransportConfig.Protocol.
+const (
+	ProtocolHTTP1 = "http1"
+	ProtocolHTTP2 = "http2"
+	ProtocolHTTP3 = "http3"
+)
+
+// TransportConfig holds per-scheduler transport settings. Schedulers with
+// equal settings share one transport and its connection pool.
+type TransportConfig struct {
+	// InsecureSkipVerify disables TLS certificate verification.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// Protocol selects the HTTP version. Empty negotiates HTTP/2 or
+	// HTTP/1.1 over TLS and uses HTTP/1.1 otherwise. ProtocolHTTP1 forces
+	// HTTP/1.1, ProtocolHTTP2 requires HTTP/2, over cleartext (h2c) for
+	// http URLs, and ProtocolHTTP3 uses HTTP/3 over QUIC, which needs
+	// https URLs.
+	Protocol string `json:"protocol,omitempty"`
+	// CAFile is the path of a PEM bundle of CA certificates trusted in
+	// addition to the system ones, for internally signed endpoints. Like
+	// payload files, it must be below the directory set by WithFileRoot.
+	CAFile string `json:"caFile,omitempty"`
+	// PinnedSHA256 lists SHA-256 fingerprints of certificates, in hex
+	// with or without colons. When set, a server is trusted if and only if
+	// its own certificate, the first it presents, is one of them, instead
+	// of by its certificate chain.
+	PinnedSHA256 []string `json:"pinnedSHA256,omitempty"`
+	// Proxy is the URL of the proxy requests go through, with the scheme
+	// http, https, socks5 or socks5h, or ProxyDirect to bypass any proxy.
+	// Empty means the ClientManager's proxy, which defaults to the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. Proxies
+	// only apply to the HTTP/1.1 and negotiated protocols.
+	Proxy string `json:"proxy,omitempty"`
+}
+
+// ProxyDirect as TransportConfig.Proxy connects without a proxy.
+const ProxyDirect = "direct"
+
+// validate checks the settings. Nil settings are valid.
+func (tc *TransportConfig) validate() error {
+	if tc == nil {
+		return nil
+	}
+	switch tc.Protocol {
+	case "", ProtocolHTTP1, ProtocolHTTP2, ProtocolHTTP3:
+	default:
+		return i18n.Errorf("%w: 알 수 없는 transport.protocol입니다: %q", ErrInvalidConfig, tc.Protocol)
+	}
+	if _, err := parsePins(tc.PinnedSHA256); err != nil {
+		return i18n.Errorf("%w: transport TLS 설정 오류: %v", ErrInvalidConfig, err)
+	}
+	if tc.Proxy == "" || tc.Proxy == ProxyDirect {
+		return nil
+	}
+	if _, err := parseProxy(tc.Proxy); err != nil {
+		return i18n.Errorf("%w: transport.proxy 오류: %v", ErrInvalidConfig, err)
+	}
+	if tc.Protocol == ProtocolHTTP2 || tc.Protocol == ProtocolHTTP3 {
+		return i18n.Errorf("%w: transport.proxy는 %s 프로토콜과 함께 쓸 수 없습니다", ErrInvalidConfig, tc.Protocol)
+	}
+	return nil
+}
+
+// parseProxy parses a proxy URL.
+func parseProxy(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, i18n.Errorf("지원하지 않는 프록시 스킴입니다: %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, i18n.Errorf("프록시 주소에 호스트가 없습니다: %q", raw)
+	}
+	return u, nil
+}
+
+// key returns a string identifying the transport for these settings.
+func (tc TransportConfig) key() string {
+	b, _ := json.Marshal(tc)
+	return string(b)
+}
+
+// ClientStats is a snapshot of the outbound connection pools.
+type ClientStats struct {
+	// Transports is the number of distinct transports (connection pools).
+	Transports int `json:"transports"`
+	// OpenConns is the number of currently open connections.
+	OpenConns int64 `json:"openConns"`
+	// DialedTotal is the number of connections dialed since start.
+	DialedTotal int64 `json:"dialedTotal"`
+	// ReusedTotal is the number of requests served by a kept-alive connection.
+	ReusedTotal int64 `json:"reusedTotal"`
+	// RequestsTotal is the number of requests sent since start.
+	RequestsTotal int64 `json:"requestsTotal"`
+}
+
+// ClientManager hands out HTTP clients that share transports, keyed by
+// TransportConfig, so that keep-alive connections are reused across
+// executions and schedulers.
+type ClientManager struct {
+	maxIdleConnsPerHost int
+	timeout             time.Duration
+	// proxy picks the proxy of transports without their own.
+	proxy func(*http.Request) (*url.URL, error)
+	// transport replaces the transports of every client when set.
+	transport http.RoundTripper
+	// fileRoot is the directory CA files are read from.
+	fileRoot string
+	// maxResponseSize bounds the decoded response bodies read into memory.
+	maxResponseSize int64
+
+	// mu protects clients.
+	mu      sync.Mutex
+	clients map[string]*http.Client
+
+	openConns atomic.Int64
+	dialed    atomic.Int64
+	reused    atomic.Int64
+	requests  atomic.Int64
+}
+
+// NewClientManager creates a ClientManager. maxIdleConnsPerHost bounds the
+// kept-alive connections per target host and timeout bounds each request;
+// zero values select the defaults of 10 connections and 10 seconds.
+func NewClientManager(maxIdleConnsPerHost int, timeout time.Duration) *ClientManager {
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	return &ClientManager{
+		maxIdleConnsPerHost: maxIdleConnsPerHost,
+		timeout:             timeout,
+		proxy:               http.ProxyFromEnvironment,
+		clients:             make(map[string]*http.Client),
+		maxResponseSize:     defaultMaxResponseSize,
+	}
+}
+
+// SetProxy makes requests go through the proxy at proxyURL, except to the
+// hosts matched by noProxy, a comma-separated list in the NO_PROXY format.
+// It applies to schedulers without their own TransportConfig.Proxy and
+// must be called before the first Client. An empty proxyURL keeps the
+// proxy settings of the environment.
+func (m *ClientManager) SetProxy(proxyURL, noProxy string) error {
+	if proxyURL == "" {
+		m.proxy = http.ProxyFromEnvironment
+		return nil
+	}
+	if _, err := parseProxy(proxyURL); err != nil {
+		return err
+	}
+	cfg := &httpproxy.Config{HTTPProxy: proxyURL, HTTPSProxy: proxyURL, NoProxy: noProxy}
+	proxy := cfg.ProxyFunc()
+	m.proxy = func(req *http.Request) (*url.URL, error) { return proxy(req.URL) }
+	return nil
+}
+
+// SetTransport makes every client send its requests through rt instead
+// of the network, whatever its TransportConfig, so tests can answer them
+// in memory. It must be called before the first Client.
+func (m *ClientManager) SetTransport(rt http.RoundTripper) {
+	m.transport = rt
+}
+
+// SetFileRoot makes TransportConfig.CAFile be read from below dir, as
+// WithFileRoot does for payload files; with an empty dir, none can be
+// read. It must be called before the first Client.
+func (m *ClientManager) SetFileRoot(dir string) {
+	m.fileRoot = dir
+}
+
+// SetMaxResponseSize bounds the response bodies read into memory by the
+// HTTP-based executors, after they are decompressed, to n bytes. Larger
+// responses fail the execution. Zero or less keeps the default of 10MB.
+// Responses streamed into an archive are only bounded by the disk.
+func (m *ClientManager) SetMaxResponseSize(n int64) {
+	if n <= 0 {
+		n = defaultMaxResponseSize
+	}
+	m.maxResponseSize = n
+}
+
+// Client returns the shared client for the given transport settings.
+func (m *ClientManager) Client(tc TransportConfig) *http.Client {
+	key := tc.key()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[key]; ok {
+		return c
+	}
+	if m.transport != nil {
+		c := &http.Client{
+			Timeout:   m.timeout,
+			Transport: &trackingTransport{base: injectedTransport{m.transport}, m: m},
+		}
+		m.clients[key] = c
+		return c
+	}
+	base, err := m.newTransport(tc)
+	if err != nil {
+		// Not cached, so that a fixed CA file is picked up.
+		return &http.Client{Transport: failingTransport{err}}
+	}
+	c := &http.Client{
+		Timeout:   m.timeout,
+		Transport: &trackingTransport{base: base, m: m},
+	}
+	m.clients[key] = c
+	return c
+}
+
+// roundTripper is a transport whose idle connections can be closed.
+type roundTripper interface {
+	http.RoundTripper
+	CloseIdleConnections()
+}
+
+// newTransport builds a transport for the given settings.
+func (m *ClientManager) newTransport(tc TransportConfig) (roundTripper, error) {
+	tlsConfig, err := tc.tlsConfig(m.fileRoot)
+	if err != nil {
+		return nil, err
+	}
+	switch tc.Protocol {
+	case ProtocolHTTP2:
+		return &h2Transport{
+			tls: &http2.Transport{TLSClientConfig: tlsConfig, DialTLSContext: m.dialH2},
+			h2c: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					return m.dial(ctx, network, addr)
+				},
+			},
+		}, nil
+	case ProtocolHTTP3:
+		return &http3.Transport{TLSClientConfig: tlsConfig}, nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = m.maxIdleConnsPerHost
+	t.DialContext = m.dial
+	t.TLSClientConfig = tlsConfig
+	switch tc.Proxy {
+	case "":
+		t.Proxy = m.proxy
+	case ProxyDirect:
+		t.Proxy = nil
+	default:
+		u, _ := parseProxy(tc.Proxy)
+		t.Proxy = http.ProxyURL(u)
+	}
+	if tc.Protocol == ProtocolHTTP1 {
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return t, nil
+}
+
+// injectedTransport is a transport set with SetTransport.
+type injectedTransport struct {
+	http.RoundTripper
+}
+
+// CloseIdleConnections closes the idle connections of the transport, if it
+// keeps any.
+func (t injectedTransport) CloseIdleConnections() {
+	if c, ok := t.RoundTripper.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// failingTransport fails every request with err.
+type failingTransport struct {
+	err error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// dial opens a tracked TCP connection.
+func (m *ClientManager) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	m.dialed.Add(1)
+	m.openConns.Add(1)
+	return &trackedConn{Conn: conn, m: m}, nil
+}
+
+// dialH2 opens a tracked TLS connection and checks that the server agreed
+// to HTTP/2.
+func (m *ClientManager) dialH2(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+	conn, err := m.dial(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	tc := tls.Client(conn, cfg)
+	if err := tc.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if p := tc.ConnectionState().NegotiatedProtocol; p != http2.NextProtoTLS {
+		tc.Close()
+		return nil, i18n.Errorf("서버가 HTTP/2를 지원하지 않습니다 (ALPN: %q)", p)
+	}
+	return tc, nil
+}
+
+// h2Transport sends requests over HTTP/2, with TLS for https URLs and in
+// cleartext for http URLs.
+type h2Transport struct {
+	tls, h2c *http2.Transport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *h2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of both transports.
+func (t *h2Transport) CloseIdleConnections() {
+	t.tls.CloseIdleConnections()
+	t.h2c.CloseIdleConnections()
+}
+
+// Stats returns a snapshot of the connection pool counters.
+func (m *ClientManager) Stats() ClientStats {
+	m.mu.Lock()
+	transports := len(m.clients)
+	m.mu.Unlock()
+
+	return ClientStats{
+		Transports:    transports,
+		OpenConns:     m.openConns.Load(),
+		DialedTotal:   m.dialed.Load(),
+		ReusedTotal:   m.reused.Load(),
+		RequestsTotal: m.requests.Load(),
+	}
+}
+
+// CloseIdleConnections closes idle connections on every transport.
+func (m *ClientManager) CloseIdleConnections() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.clients {
+		c.CloseIdleConnections()
+	}
+}
+
+// trackingTransport counts requests and connection reuse.
+type trackingTransport struct {
+	base roundTripper
+	m    *ClientManager
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.m.requests.Add(1)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.m.reused.Add(1)
+			}
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections lets http.Client.CloseIdleConnections reach the base transport.
+func (t *trackingTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
+
+// trackedConn decrements the open connection count when closed.
+type trackedConn struct {
+	net.Conn
+	m      *ClientManager
+	closed atomic.Bool
+}
+
+// Close closes the connection once.
+func (c *trackedConn) Close() error {
+	if c.closed.CompareAndSwap(false, true) {
+		c.m.openConns.Add(-1)
+	}
+	return c.Conn.Close()
+}
@@ -0,0 +1,222 @@
+// pkg/scheduler/clock.go
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to a Scheduler and waits for it. Fire times, the
+// start of executions, heartbeats, SLAs and events all follow the clock,
+// while timeouts and the measurements of requests use the system clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// WaitUntil blocks until the clock reaches t or ctx is done. It
+	// reports whether t was reached.
+	WaitUntil(ctx context.Context, t time.Time) bool
+}
+
+// WithClock makes s follow c instead of the system clock, such as a
+// VirtualClock in tests.
+func WithClock(c Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = c
+	}
+}
+
+// Now returns the time of the clock of s, for windows such as the
+// timeline's that must match its fire times.
+func (s *Scheduler) Now() time.Time {
+	return s.clock.Now()
+}
+
+// systemClock is the Clock of the system, the default.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) WaitUntil(ctx context.Context, t time.Time) bool {
+	return waitUntil(ctx, t, time.Now, nil)
+}
+
+// VirtualClock is a Clock that runs with the system clock but can be
+// moved forward, so tests can check a daily schedule without waiting a
+// day. Waits are re-checked when it moves, and fire times passed by a
+// jump count as missed, as if the process had been suspended.
+type VirtualClock struct {
+	mu     sync.Mutex
+	offset time.Duration
+	// moved is closed and replaced when the clock moves.
+	moved chan struct{}
+}
+
+// NewVirtualClock returns a VirtualClock showing the system time.
+func NewVirtualClock() *VirtualClock {
+	return &VirtualClock{moved: make(chan struct{})}
+}
+
+// Now returns the system time moved forward by all calls to Advance.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Now().Add(c.offset)
+}
+
+// Offset returns how far c is ahead of the system clock.
+func (c *VirtualClock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offset
+}
+
+// Advance moves c forward by d and returns the new time. The clock never
+// goes back, so negative durations are ignored.
+func (c *VirtualClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	if d > 0 {
+		c.offset += d
+		close(c.moved)
+		c.moved = make(chan struct{})
+	}
+	now := time.Now().Add(c.offset)
+	c.mu.Unlock()
+	return now
+}
+
+// WaitUntil blocks until c reaches t or ctx is done. It reports whether t
+// was reached.
+func (c *VirtualClock) WaitUntil(ctx context.Context, t time.Time) bool {
+	return waitUntil(ctx, t, c.Now, c.movedChan)
+}
+
+// movedChan returns the channel closed when c next moves.
+func (c *VirtualClock) movedChan() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.moved
+}
+
+// ManualClock is a Clock that only moves when told, for deterministic
+// tests of the run loop. Goroutines waiting on it, such as run loops
+// waiting for their next fire time or a retry backoff, are counted, so a
+// test can wait until they are idle instead of sleeping.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters map[*manualWaiter]struct{}
+	// changed is closed and replaced when the waiters change.
+	changed chan struct{}
+}
+
+// manualWaiter is a goroutine waiting on a ManualClock.
+type manualWaiter struct {
+	until time.Time
+	// woken is closed when the clock reaches until.
+	woken chan struct{}
+}
+
+// NewManualClock returns a ManualClock showing t.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t.Round(0), waiters: map[*manualWaiter]struct{}{}, changed: make(chan struct{})}
+}
+
+// Now returns the time c was set to.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// WaitUntil blocks until c is moved to t or past it, or ctx is done. It
+// reports whether t was reached.
+func (c *ManualClock) WaitUntil(ctx context.Context, t time.Time) bool {
+	c.mu.Lock()
+	if !t.After(c.now) {
+		c.mu.Unlock()
+		return true
+	}
+	w := &manualWaiter{until: t, woken: make(chan struct{})}
+	c.waiters[w] = struct{}{}
+	c.changedLocked()
+	c.mu.Unlock()
+
+	select {
+	case <-w.woken:
+		return true
+	case <-ctx.Done():
+		c.mu.Lock()
+		if _, ok := c.waiters[w]; ok {
+			delete(c.waiters, w)
+			c.changedLocked()
+		}
+		c.mu.Unlock()
+		return false
+	}
+}
+
+// Set moves c to t and wakes the goroutines waiting for a time up to t.
+// They no longer count as waiting when Set returns. The clock never goes
+// back, so earlier times are ignored.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t.Round(0)
+	}
+	for w := range c.waiters {
+		if !w.until.After(c.now) {
+			delete(c.waiters, w)
+			close(w.woken)
+		}
+	}
+	c.changedLocked()
+}
+
+// Advance moves c forward by d like Set.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Waiters returns how many goroutines wait on c.
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n goroutines wait on c or ctx is done.
+// After starting n schedulers, or after moving the clock past their fire
+// times, it returns once their run loops are idle again: the executions
+// due by then have finished, unless they wait for a retry backoff.
+func (c *ManualClock) BlockUntil(ctx context.Context, n int) error {
+	for {
+		c.mu.Lock()
+		count, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+		if count >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// AdvanceAndWait moves c forward by d and blocks until n goroutines wait
+// on it again, so the executions due by the new time run as if
+// synchronously with the caller. It fails when ctx is done first.
+func (c *ManualClock) AdvanceAndWait(ctx context.Context, d time.Duration, n int) error {
+	c.Advance(d)
+	return c.BlockUntil(ctx, n)
+}
+
+// changedLocked tells BlockUntil that the waiters changed. c.mu must be
+// held.
+func (c *ManualClock) changedLocked() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
@@ -0,0 +1,116 @@
+// pkg/scheduler/command.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeCommand runs a local command. Its executor is not registered by
+// New; embedders opt in with RegisterExecutor(JobTypeCommand, &CommandExecutor{}).
+const JobTypeCommand = "command"
+
+// defaultCommandTimeout bounds a command when CommandConfig.Timeout is empty.
+const defaultCommandTimeout = time.Minute
+
+// CommandConfig configures a JobTypeCommand job.
+type CommandConfig struct {
+	// Path is the program to run, or the script passed to the shell when Shell is set.
+	Path string   `json:"path"`
+	Args []string `json:"args,omitempty"`
+	// Shell runs Path through "sh -c" ("cmd /C" on Windows).
+	Shell bool `json:"shell,omitempty"`
+	// Dir is the working directory. Empty means the server's working directory.
+	Dir string `json:"dir,omitempty"`
+	// Env is added to the server's environment.
+	Env map[string]string `json:"env,omitempty"`
+	// Timeout is a Go duration string such as "30s". Empty means one minute.
+	Timeout string `json:"timeout,omitempty"`
+	// SuccessExitCodes lists the exit codes treated as success. Empty means 0.
+	SuccessExitCodes []int `json:"successExitCodes,omitempty"`
+}
+
+// CommandExecutor runs the command configured in Config.Command and reports
+// its exit code as the result status code.
+type CommandExecutor struct{}
+
+// Execute runs the command and captures its stdout and stderr.
+func (e *CommandExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	cc := config.Command
+	if cc == nil || cc.Path == "" {
+		return Result{}, i18n.Errorf("실행할 명령이 설정되지 않았습니다")
+	}
+
+	timeout := defaultCommandTimeout
+	if cc.Timeout != "" {
+		d, err := time.ParseDuration(cc.Timeout)
+		if err != nil {
+			return Result{}, i18n.Errorf("명령 타임아웃 파싱 오류: %w", err)
+		}
+		timeout = d
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch {
+	case cc.Shell && runtime.GOOS == "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/C", cc.Path)
+	case cc.Shell:
+		cmd = exec.CommandContext(ctx, "sh", "-c", cc.Path)
+	default:
+		cmd = exec.CommandContext(ctx, cc.Path, cc.Args...)
+	}
+	cmd.Dir = cc.Dir
+	if len(cc.Env) > 0 {
+		cmd.Env = os.Environ()
+		for key, value := range cc.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return Result{}, i18n.Errorf("명령 실행 시간이 초과되었습니다 (%s)", timeout)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return Result{}, i18n.Errorf("명령 실행 오류: %w", err)
+	}
+
+	code := cmd.ProcessState.ExitCode()
+	output := stdout.String()
+	if stderr.Len() > 0 {
+		output += "\n[stderr]\n" + stderr.String()
+	}
+
+	return Result{
+		Success:    commandSucceeded(code, cc.SuccessExitCodes),
+		StatusCode: code,
+		Output:     output,
+	}, nil
+}
+
+// commandSucceeded reports whether code is one of the accepted exit codes.
+func commandSucceeded(code int, accepted []int) bool {
+	if len(accepted) == 0 {
+		return code == 0
+	}
+	for _, c := range accepted {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
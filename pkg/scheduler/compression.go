@@ -0,0 +1,66 @@
+// pkg/scheduler/compression.go
+package scheduler
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// acceptEncoding is sent by the HTTP-based executors unless the job sets
+// its own Accept-Encoding header.
+const acceptEncoding = "gzip, deflate, br"
+
+// setAcceptEncoding asks for a compressed response. Setting the header
+// turns off the transport's transparent gzip handling, so the response
+// must be read with decodeBody.
+func setAcceptEncoding(req *http.Request) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+}
+
+// decodeBody returns the body of resp decoded according to its
+// Content-Encoding, which may list several encodings.
+func decodeBody(resp *http.Response) (io.Reader, error) {
+	var body io.Reader = resp.Body
+	encodings := strings.Split(resp.Header.Get("Content-Encoding"), ",")
+	// Encodings are listed in the order they were applied.
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+		switch enc := strings.ToLower(strings.TrimSpace(encodings[i])); enc {
+		case "", "identity":
+		case "gzip", "x-gzip":
+			body, err = gzip.NewReader(body)
+		case "deflate":
+			body, err = newDeflateReader(body)
+		case "br":
+			body = brotli.NewReader(body)
+		default:
+			return nil, i18n.Errorf("지원하지 않는 Content-Encoding입니다: %q", enc)
+		}
+		if err != nil {
+			return nil, i18n.Errorf("응답 압축 해제 오류: %w", err)
+		}
+	}
+	return body, nil
+}
+
+// newDeflateReader reads an HTTP deflate body, which should be zlib data
+// but is sent as raw DEFLATE by some servers.
+func newDeflateReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 && header[0]&0x0f == 8 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
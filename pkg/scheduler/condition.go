@@ -0,0 +1,405 @@
+// pkg/scheduler/condition.go
+package scheduler
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// A condition is an expression such as Config.OnlyIf and Config.SkipIf. It
+// supports the literals true, false, null, numbers and quoted strings,
+// paths below a root name such as last.statusCode or
+// last.body.items[0].id, the comparisons == != < <= > >=, and !, && and ||
+// with parentheses.
+type condition func(vars map[string]any) any
+
+// conditionVars returns the variables of the conditions of a job whose
+// previous execution is e.
+func conditionVars(e *Execution) map[string]any {
+	var body any = e.Output
+	var parsed any
+	if json.Unmarshal([]byte(e.Output), &parsed) == nil {
+		body = parsed
+	}
+	return map[string]any{
+		"last": map[string]any{
+			"success":    e.Success,
+			"failed":     !e.Success,
+			"statusCode": float64(e.StatusCode),
+			"error":      e.Error,
+			"body":       body,
+			"durationMs": ms(e.Duration),
+			"changed":    len(e.Changes) > 0,
+		},
+	}
+}
+
+// parseCondition compiles a condition expression over the previous
+// execution.
+func parseCondition(expr string) (condition, error) {
+	return parseExpr(expr, "last")
+}
+
+// parseExpr compiles an expression whose paths start at one of roots.
+func parseExpr(expr string, roots ...string) (condition, error) {
+	p := &condParser{src: expr, roots: roots}
+	p.next()
+	c, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok != "" {
+		return nil, i18n.Errorf("예상하지 못한 %q", p.tok)
+	}
+	return c, nil
+}
+
+// evalCondition reports whether c holds for vars.
+func evalCondition(c condition, vars map[string]any) bool {
+	return truthy(c(vars))
+}
+
+// condParser is a recursive-descent parser of condition expressions.
+type condParser struct {
+	src   string
+	pos   int
+	roots []string
+	// tok is the current token, empty at the end. str reports whether it
+	// is a string literal, whose value is then in tok.
+	tok string
+	str bool
+	err error
+}
+
+// next reads the next token.
+func (p *condParser) next() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	p.str = false
+	if p.pos >= len(p.src) {
+		p.tok = ""
+		return
+	}
+	rest := p.src[p.pos:]
+	for _, op := range []string{"==", "!=", "<=", ">=", "&&", "||"} {
+		if strings.HasPrefix(rest, op) {
+			p.tok = op
+			p.pos += len(op)
+			return
+		}
+	}
+	switch c := rest[0]; {
+	case strings.IndexByte("<>!()[].", c) >= 0:
+		p.tok = string(c)
+		p.pos++
+	case c == '"' || c == '\'':
+		end := strings.IndexByte(rest[1:], c)
+		if end < 0 {
+			p.err = i18n.Errorf("닫히지 않은 문자열입니다")
+			p.tok = ""
+			p.pos = len(p.src)
+			return
+		}
+		p.tok = rest[1 : end+1]
+		p.str = true
+		p.pos += end + 2
+	default:
+		word := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' }
+		if c >= '0' && c <= '9' || c == '-' && len(rest) > 1 && rest[1] >= '0' && rest[1] <= '9' {
+			word = func(r rune) bool { return r >= '0' && r <= '9' || strings.ContainsRune(".eE+-", r) }
+		}
+		n := strings.IndexFunc(rest, func(r rune) bool { return !word(r) })
+		if n < 0 {
+			n = len(rest)
+		}
+		if n == 0 {
+			p.err = i18n.Errorf("예상하지 못한 %q", rest[:1])
+			p.tok = ""
+			p.pos = len(p.src)
+			return
+		}
+		p.tok = rest[:n]
+		p.pos += n
+	}
+}
+
+// or parses a || b || ...
+func (p *condParser) or() (condition, error) {
+	left, err := p.and()
+	for err == nil && p.tok == "||" && !p.str {
+		p.next()
+		var right condition
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(vars map[string]any) any { return truthy(l(vars)) || truthy(right(vars)) }
+		}
+	}
+	return left, err
+}
+
+// and parses a && b && ...
+func (p *condParser) and() (condition, error) {
+	left, err := p.not()
+	for err == nil && p.tok == "&&" && !p.str {
+		p.next()
+		var right condition
+		if right, err = p.not(); err == nil {
+			l := left
+			left = func(vars map[string]any) any { return truthy(l(vars)) && truthy(right(vars)) }
+		}
+	}
+	return left, err
+}
+
+// not parses !a or a comparison.
+func (p *condParser) not() (condition, error) {
+	if p.tok == "!" && !p.str {
+		p.next()
+		c, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return func(vars map[string]any) any { return !truthy(c(vars)) }, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses a, or a compared with b.
+func (p *condParser) comparison() (condition, error) {
+	left, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	op := p.tok
+	switch {
+	case p.str:
+		return left, nil
+	case op == "==" || op == "!=" || op == "<" || op == "<=" || op == ">" || op == ">=":
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars map[string]any) any { return compare(op, left(vars), right(vars)) }, nil
+}
+
+// primary parses a literal, a path or a parenthesized expression.
+func (p *condParser) primary() (condition, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok, str := p.tok, p.str
+	switch {
+	case tok == "" && !str:
+		return nil, i18n.Errorf("식이 끝나지 않았습니다")
+	case str:
+		p.next()
+		return constant(tok), nil
+	case tok == "(":
+		p.next()
+		c, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" || p.str {
+			return nil, i18n.Errorf("닫는 괄호가 필요합니다")
+		}
+		p.next()
+		return c, nil
+	case tok == "true" || tok == "false":
+		p.next()
+		return constant(tok == "true"), nil
+	case tok == "null":
+		p.next()
+		return constant(nil), nil
+	}
+	if f, err := strconv.ParseFloat(tok, 64); err == nil {
+		p.next()
+		return constant(f), nil
+	}
+	for _, root := range p.roots {
+		if tok == root {
+			return p.path(root)
+		}
+	}
+	return nil, i18n.Errorf("알 수 없는 이름입니다: %q", tok)
+}
+
+// path parses a root name followed by .name and [index] selectors.
+func (p *condParser) path(root string) (condition, error) {
+	var steps []any
+	p.next()
+	for !p.str && (p.tok == "." || p.tok == "[") {
+		if p.tok == "." {
+			p.next()
+			if p.tok == "" || p.str || !isName(p.tok) {
+				return nil, i18n.Errorf("'.' 뒤에 이름이 필요합니다")
+			}
+			steps = append(steps, p.tok)
+			p.next()
+			continue
+		}
+		p.next()
+		i, err := strconv.Atoi(p.tok)
+		if err != nil || p.str || i < 0 {
+			return nil, i18n.Errorf("'[' 뒤에 0 이상의 인덱스가 필요합니다")
+		}
+		p.next()
+		if p.tok != "]" || p.str {
+			return nil, i18n.Errorf("닫는 대괄호가 필요합니다")
+		}
+		steps = append(steps, i)
+		p.next()
+	}
+	return func(vars map[string]any) any {
+		var v any = vars[root]
+		for _, step := range steps {
+			switch step := step.(type) {
+			case string:
+				m, _ := v.(map[string]any)
+				v = m[step]
+			case int:
+				a, _ := v.([]any)
+				if step >= len(a) {
+					return nil
+				}
+				v = a[step]
+			}
+		}
+		return v
+	}, nil
+}
+
+// isName reports whether s can be a path member name.
+func isName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// constant returns a condition that always evaluates to v.
+func constant(v any) condition {
+	return func(map[string]any) any { return v }
+}
+
+// truthy reports whether v counts as true: false, null, 0 and "" don't.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+// compare applies a comparison operator. Numbers and strings are ordered;
+// values of different types are only unequal.
+func compare(op string, a, b any) bool {
+	switch op {
+	case "==":
+		return equal(a, b)
+	case "!=":
+		return !equal(a, b)
+	}
+	var c int
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case a < b:
+			c = -1
+		case a > b:
+			c = 1
+		}
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(a, b)
+	default:
+		return false
+	}
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// equal compares JSON values; objects and arrays are compared as JSON.
+func equal(a, b any) bool {
+	switch a.(type) {
+	case map[string]any, []any:
+		x, _ := json.Marshal(a)
+		y, _ := json.Marshal(b)
+		return string(x) == string(y)
+	}
+	switch b.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return a == b
+}
+
+// loadLastExecution seeds the previous execution of a job with conditions
+// from the execution history, so they keep working across a restart.
+func (j *job) loadLastExecution() {
+	es, ok := j.sched.store.(ExecutionStore)
+	if !ok {
+		return
+	}
+	history, err := es.ListExecutions(j.id, 1)
+	if err != nil || len(history) == 0 {
+		return
+	}
+	j.last = &history[0]
+}
+
+// conditionsAllow checks the OnlyIf and SkipIf conditions against the
+// previous execution and logs why an execution is skipped.
+func (j *job) conditionsAllow() bool {
+	if j.last == nil {
+		return true
+	}
+	vars := conditionVars(j.last)
+	if j.config.OnlyIf != "" {
+		if c, err := parseCondition(j.config.OnlyIf); err == nil && !evalCondition(c, vars) {
+			j.logAt(LogInfo, "실행 조건(onlyIf)을 만족하지 않아 실행을 건너뜁니다: %s", j.config.OnlyIf)
+			return false
+		}
+	}
+	if j.config.SkipIf != "" {
+		if c, err := parseCondition(j.config.SkipIf); err == nil && evalCondition(c, vars) {
+			j.logAt(LogInfo, "건너뛰기 조건(skipIf)을 만족하여 실행을 건너뜁니다: %s", j.config.SkipIf)
+			return false
+		}
+	}
+	return true
+}
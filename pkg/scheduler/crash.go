@@ -0,0 +1,243 @@
+// pkg/scheduler/crash.go
+package scheduler
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Restart policies for Config.RestartPolicy.
+const (
+	// RestartNever, the default, leaves a failed scheduler stopped until
+	// it is updated or stopped.
+	RestartNever = "never"
+	// RestartOnCrash starts a scheduler whose run goroutine crashed again
+	// after its cooldown.
+	RestartOnCrash = "on-crash"
+	// RestartOnFailureStreak also fails a scheduler whose executions
+	// failed Config.RestartThreshold times in a row, and starts it again
+	// after its cooldown.
+	RestartOnFailureStreak = "on-failure-streak"
+)
+
+// Reasons of a Crash.
+const (
+	// CrashPanic is a panic in the run goroutine or an executor.
+	CrashPanic = "panic"
+	// CrashFailureStreak is a streak of failed executions reaching
+	// Config.RestartThreshold.
+	CrashFailureStreak = "failure-streak"
+)
+
+// defaultRestartCooldown is how long a failed scheduler waits before it is
+// started again without Config.RestartCooldown, so a scheduler that fails
+// on every execution doesn't spin.
+const defaultRestartCooldown = 10 * time.Second
+
+// defaultRestartThreshold is the failure streak failing a scheduler with
+// RestartOnFailureStreak without Config.RestartThreshold.
+const defaultRestartThreshold = 5
+
+// validateRestart checks the restart policy of c.
+func (c Config) validateRestart() error {
+	switch c.RestartPolicy {
+	case "", RestartNever, RestartOnCrash, RestartOnFailureStreak:
+	default:
+		return i18n.Errorf("%w: restartPolicy는 never, on-crash 또는 on-failure-streak여야 합니다: %q", ErrInvalidConfig, c.RestartPolicy)
+	}
+	if c.RestartThreshold < 0 {
+		return i18n.Errorf("%w: restartThreshold는 0 이상이어야 합니다", ErrInvalidConfig)
+	}
+	if _, err := c.restartCooldown(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// restartCooldown returns Config.RestartCooldown, defaultRestartCooldown
+// when it is empty.
+func (c Config) restartCooldown() (time.Duration, error) {
+	if c.RestartCooldown == "" {
+		return defaultRestartCooldown, nil
+	}
+	d, err := time.ParseDuration(c.RestartCooldown)
+	if err != nil || d <= 0 {
+		return 0, i18n.Errorf("%w: restartCooldown은 양수 Go duration이어야 합니다: %q", ErrInvalidConfig, c.RestartCooldown)
+	}
+	return d, nil
+}
+
+// restartThreshold returns Config.RestartThreshold, defaultRestartThreshold
+// when it is zero.
+func (c Config) restartThreshold() int {
+	if c.RestartThreshold > 0 {
+		return c.RestartThreshold
+	}
+	return defaultRestartThreshold
+}
+
+// restarts reports whether the restart policy of c starts a scheduler that
+// failed for reason again.
+func (c Config) restarts(reason string) bool {
+	switch c.RestartPolicy {
+	case RestartOnCrash:
+		return reason == CrashPanic
+	case RestartOnFailureStreak:
+		return true
+	}
+	return false
+}
+
+// Crash describes why a scheduler failed: a panic that stopped its run
+// goroutine, or a streak of failed executions.
+type Crash struct {
+	Time time.Time `json:"time"`
+	// Reason is CrashPanic or CrashFailureStreak.
+	Reason string `json:"reason"`
+	Error  string `json:"error"`
+}
+
+// CrashEvent is sent to the webhook of a scheduler that failed.
+type CrashEvent struct {
+	SchedulerID string `json:"schedulerId"`
+	Name        string `json:"name,omitempty"`
+	Crash       Crash  `json:"crash"`
+	// Restarting reports whether the scheduler will be started again.
+	Restarting bool `json:"restarting"`
+}
+
+// recoverCrash is deferred by the run goroutine. It recovers from a panic,
+// logs it with the stack and fails the job.
+func (j *job) recoverCrash() {
+	v := recover()
+	if v == nil {
+		return
+	}
+	j.logf("스케줄러가 비정상 종료되었습니다: %v\n%s", v, debug.Stack())
+	j.fail(&Crash{Time: j.sched.clock.Now(), Reason: CrashPanic, Error: fmt.Sprint(v)})
+}
+
+// panics keeps the first panic recovered in the goroutines an executor
+// starts, so that the execution fails with it instead of the panic
+// crashing the process.
+type panics struct {
+	mu  sync.Mutex
+	err error
+}
+
+// catch is deferred by the goroutines. It recovers from a panic and keeps
+// it with the stack.
+func (p *panics) catch() {
+	v := recover()
+	if v == nil {
+		return
+	}
+	stack := debug.Stack()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = i18n.Errorf("실행 중 패닉이 발생했습니다: %v\n%s", v, stack)
+	}
+}
+
+// checkStreak fails j when its RestartPolicy is RestartOnFailureStreak and
+// streak failed executions in a row reached its threshold. It reports
+// whether j failed.
+func (j *job) checkStreak(streak int, rec *Execution) bool {
+	if j.config.RestartPolicy != RestartOnFailureStreak || streak < j.config.restartThreshold() {
+		return false
+	}
+	j.logAt(LogError, "%d회 연속 실행에 실패하여 스케줄러를 중지합니다.", streak)
+	reason := rec.Error
+	if reason == "" {
+		reason = i18n.Sprintf(i18n.Default(), "상태 코드 %d", rec.StatusCode)
+	}
+	j.fail(&Crash{
+		Time:   j.sched.clock.Now(),
+		Reason: CrashFailureStreak,
+		Error:  i18n.Sprintf(i18n.Default(), "%d회 연속 실행 실패: %s", streak, reason),
+	})
+	return true
+}
+
+// fail cancels j, marks it as failed with crash and publishes an
+// EventCrashed event, after which the job stays registered in StateFailed
+// unless its RestartPolicy starts it again after the cooldown. A job that
+// was stopped or updated meanwhile just ends.
+func (j *job) fail(crash *Crash) {
+	j.cancel()
+
+	j.sched.mu.Lock()
+	if j.state == StateStopping || j.sched.jobs[j.id] != j {
+		j.sched.mu.Unlock()
+		return
+	}
+	failed := j.setStateLocked(StateFailed) == nil
+	if failed {
+		j.crash = crash
+	}
+	j.sched.mu.Unlock()
+	if !failed {
+		return
+	}
+	j.publishState(StateFailed)
+
+	j.sched.bus.publish(Event{
+		Type:        EventCrashed,
+		Time:        crash.Time,
+		SchedulerID: j.id,
+		Crash:       crash,
+		job:         j,
+	})
+	if j.config.restarts(crash.Reason) {
+		cooldown, _ := j.config.restartCooldown()
+		j.logf("%s 후 스케줄러를 다시 시작합니다.", cooldown)
+		time.AfterFunc(cooldown, func() { j.sched.restartFailed(j) })
+	}
+}
+
+// RestartEvent is sent to the webhook of a failed scheduler that its
+// RestartPolicy started again.
+type RestartEvent struct {
+	SchedulerID string    `json:"schedulerId"`
+	Name        string    `json:"name,omitempty"`
+	Time        time.Time `json:"time"`
+	// Crash is why the scheduler failed.
+	Crash Crash `json:"crash"`
+}
+
+// restartFailed replaces the failed job j by a new one with the same
+// config, which resumes from j's last fire time, unless j was stopped or
+// updated meanwhile.
+func (s *Scheduler) restartFailed(j *job) {
+	s.syncMu.Lock()
+	s.mu.Lock()
+	if s.jobs[j.id] != j || j.state != StateFailed {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		return
+	}
+	j.setStateLocked(StateStopped)
+	restarted := s.newJob(j.id, j.config, j.anchor, j.interval, j.lastFire)
+	restarted.next = j.next
+	s.jobs[j.id] = restarted
+	s.mu.Unlock()
+	s.persist(restarted)
+	s.syncMu.Unlock()
+	j.publishState(StateStopped)
+
+	s.audit(j.id, "restart", j.crash.Error)
+	s.emit(j.id, "실패한 스케줄러를 다시 시작합니다.")
+	s.bus.publish(Event{
+		Type:        EventRestarted,
+		Time:        s.clock.Now(),
+		SchedulerID: j.id,
+		Crash:       j.crash,
+		job:         restarted,
+	})
+	go restarted.run()
+}
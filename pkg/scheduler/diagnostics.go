@@ -0,0 +1,144 @@
+// pkg/scheduler/diagnostics.go
+package scheduler
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Connection phases of an HTTP request, in order.
+const (
+	PhaseDNS      = "dns"
+	PhaseConnect  = "connect"
+	PhaseTLS      = "tls"
+	PhaseRequest  = "request"
+	PhaseResponse = "response"
+)
+
+// RequestTiming breaks down where the time of an HTTP request went, so
+// that a failure can be told apart as DNS, connection or server trouble.
+// Timings are in milliseconds and zero for phases that didn't happen,
+// such as DNS and connect on a reused connection.
+type RequestTiming struct {
+	Host string `json:"host"`
+	// Addr is the remote address connected to.
+	Addr string `json:"addr,omitempty"`
+	// Reused reports whether an idle connection was reused.
+	Reused bool `json:"reused,omitempty"`
+
+	DNSMs     float64 `json:"dnsMs,omitempty"`
+	ConnectMs float64 `json:"connectMs,omitempty"`
+	TLSMs     float64 `json:"tlsMs,omitempty"`
+	// TTFBMs is the time from sending the request to the first response
+	// byte.
+	TTFBMs  float64 `json:"ttfbMs,omitempty"`
+	TotalMs float64 `json:"totalMs"`
+
+	// FailedPhase is the phase a request that got no response failed in,
+	// one of the Phase constants.
+	FailedPhase string `json:"failedPhase,omitempty"`
+}
+
+// requestTracer collects a RequestTiming through an httptrace.ClientTrace.
+// The hooks may run on other goroutines.
+type requestTracer struct {
+	mu      sync.Mutex
+	timing  RequestTiming
+	started time.Time
+	// phase is the phase in progress.
+	phase string
+
+	dnsStart, connectStart, tlsStart, wrote time.Time
+}
+
+// traceRequest returns req with a client trace that records its timing
+// into the returned tracer.
+func traceRequest(req *http.Request) (*http.Request, *requestTracer) {
+	t := &requestTracer{started: time.Now(), phase: PhaseConnect}
+	t.timing.Host = req.URL.Host
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phase = PhaseConnect
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phase = PhaseDNS
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timing.DNSMs = ms(time.Since(t.dnsStart))
+			if info.Err == nil {
+				t.phase = PhaseConnect
+			}
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if err == nil {
+				t.timing.ConnectMs = ms(time.Since(t.connectStart))
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phase = PhaseTLS
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timing.TLSMs = ms(time.Since(t.tlsStart))
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phase = PhaseRequest
+			t.timing.Reused = info.Reused
+			if info.Conn != nil {
+				t.timing.Addr = info.Conn.RemoteAddr().String()
+			}
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phase = PhaseResponse
+			t.wrote = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.wrote.IsZero() {
+				t.timing.TTFBMs = ms(time.Since(t.wrote))
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), t
+}
+
+// finish returns the timing of the request. failed reports whether it got
+// no response, which records the phase it failed in.
+func (t *requestTracer) finish(failed bool) *RequestTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing := t.timing
+	timing.TotalMs = ms(time.Since(t.started))
+	if failed {
+		timing.FailedPhase = t.phase
+	}
+	return &timing
+}
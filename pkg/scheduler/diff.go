@@ -0,0 +1,218 @@
+// pkg/scheduler/diff.go
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+const (
+	// maxChanges bounds the changes recorded for one response.
+	maxChanges = 50
+	// maxChangeValue bounds the old and new values shown in a change.
+	maxChangeValue = 200
+	// diffHistory is how many stored executions are searched for the
+	// previous response when a job starts.
+	diffHistory = 20
+)
+
+// DiffConfig compares each successful response with the previous one, so
+// that a scheduler can poll for changes. JSON responses are compared value
+// by value, other responses as a whole.
+type DiffConfig struct {
+	// Ignore lists the JSON paths left out of the comparison, such as
+	// "$.meta.requestId" or "$.items[0].updatedAt". A path also covers
+	// everything below it.
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// validate checks the diff settings. A nil DiffConfig is valid.
+func (c *DiffConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.Ignore {
+		if p != "$" && !strings.HasPrefix(p, "$.") && !strings.HasPrefix(p, "$[") {
+			return i18n.Errorf("%w: diff.ignore 경로는 $로 시작해야 합니다: %q", ErrInvalidConfig, p)
+		}
+	}
+	return nil
+}
+
+// ignores reports whether path is covered by an ignored path.
+func (c *DiffConfig) ignores(path string) bool {
+	for _, p := range c.Ignore {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// ResponseChange is a difference between two consecutive responses. Old is
+// empty for added values and New for removed ones. Values are JSON, or the
+// whole body when the responses aren't JSON.
+type ResponseChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// String formats the change for the logs.
+func (c ResponseChange) String() string {
+	switch {
+	case c.Old == "":
+		return "+ " + c.Path + ": " + c.New
+	case c.New == "":
+		return "- " + c.Path + ": " + c.Old
+	}
+	return "~ " + c.Path + ": " + c.Old + " → " + c.New
+}
+
+// diffResponses returns the changes from old to new, which are compared as
+// JSON when both parse, skipping the paths ignored by c.
+func (c *DiffConfig) diffResponses(old, new string) []ResponseChange {
+	var o, n any
+	if json.Unmarshal([]byte(old), &o) != nil || json.Unmarshal([]byte(new), &n) != nil {
+		if old == new {
+			return nil
+		}
+		return []ResponseChange{{Path: "$", Old: clip(old), New: clip(new)}}
+	}
+	var changes []ResponseChange
+	c.diffValues("$", o, n, &changes)
+	if len(changes) > maxChanges {
+		changes = changes[:maxChanges]
+	}
+	return changes
+}
+
+// diffValues appends the changes from o to n at path.
+func (c *DiffConfig) diffValues(path string, o, n any, changes *[]ResponseChange) {
+	if len(*changes) > maxChanges || c.ignores(path) {
+		return
+	}
+	switch o := o.(type) {
+	case map[string]any:
+		if n, ok := n.(map[string]any); ok {
+			keys := make([]string, 0, len(o)+len(n))
+			for k := range o {
+				keys = append(keys, k)
+			}
+			for k := range n {
+				if _, ok := o[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				c.diffValues(path+"."+k, member(o, k), member(n, k), changes)
+			}
+			return
+		}
+	case []any:
+		if n, ok := n.([]any); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				var ov, nv any = absent{}, absent{}
+				if i < len(o) {
+					ov = o[i]
+				}
+				if i < len(n) {
+					nv = n[i]
+				}
+				c.diffValues(fmt.Sprintf("%s[%d]", path, i), ov, nv, changes)
+			}
+			return
+		}
+	}
+	if reflect.DeepEqual(o, n) {
+		return
+	}
+	*changes = append(*changes, ResponseChange{Path: path, Old: jsonValue(o), New: jsonValue(n)})
+}
+
+// absent stands for a member or element missing from one of the values.
+type absent struct{}
+
+// member returns the member k of m, or absent.
+func member(m map[string]any, k string) any {
+	if v, ok := m[k]; ok {
+		return v
+	}
+	return absent{}
+}
+
+// jsonValue renders v as clipped JSON. Absent values render empty.
+func jsonValue(v any) string {
+	if _, ok := v.(absent); ok {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return clip(string(b))
+}
+
+// clip shortens s to maxChangeValue bytes without splitting a rune.
+func clip(s string) string {
+	if len(s) <= maxChangeValue {
+		return s
+	}
+	i := maxChangeValue
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + "…"
+}
+
+// loadPreviousOutput seeds the previous response of a diffing job from the
+// execution history, so that a restart doesn't count as a change.
+func (j *job) loadPreviousOutput() {
+	es, ok := j.sched.store.(ExecutionStore)
+	if !ok {
+		return
+	}
+	history, err := es.ListExecutions(j.id, diffHistory)
+	if err != nil {
+		return
+	}
+	for _, e := range history {
+		if e.Success {
+			out := e.Output
+			j.prevOutput = &out
+			return
+		}
+	}
+}
+
+// diff records the changes of a successful response in rec and keeps the
+// response for the next comparison.
+func (j *job) diff(rec *Execution) {
+	if j.prevOutput != nil {
+		rec.Changes = j.config.Diff.diffResponses(*j.prevOutput, rec.Output)
+	}
+	out := rec.Output
+	j.prevOutput = &out
+}
+
+// logChanges logs the changes of a successful response. The first
+// response of the job is logged in full as the baseline.
+func (j *job) logChanges(rec *Execution, first bool) {
+	switch {
+	case first:
+		j.logAt(LogInfo, "비교 기준 응답을 저장했습니다: %s", rec.Output)
+	case len(rec.Changes) > 0:
+		lines := make([]string, len(rec.Changes))
+		for i, c := range rec.Changes {
+			lines[i] = c.String()
+		}
+		j.logAt(LogInfo, "응답이 변경되었습니다 (%d건): %s", len(rec.Changes), strings.Join(lines, "; "))
+	}
+}
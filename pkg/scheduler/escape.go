@@ -0,0 +1,116 @@
+// pkg/scheduler/escape.go
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// refContext is the kind of text a reference to a variable is replaced
+// in, which decides how the value is escaped.
+type refContext int
+
+const (
+	// inText inserts values as they are, such as in header values.
+	inText refContext = iota
+	// inURL escapes values in the path of a URL with url.PathEscape and
+	// in its query or fragment with url.QueryEscape. Values before the
+	// path, such as a base URL or a host name, are inserted as they are.
+	inURL
+	// inJSON JSON-escapes values inside string literals. Elsewhere, values
+	// that are JSON themselves are inserted as they are and the others
+	// as string literals.
+	inJSON
+	// inQuery escapes values with url.QueryEscape, for a raw query.
+	inQuery
+)
+
+// replaceRefs replaces the matches of re in s with the values value
+// returns for their submatches, escaped for where they are in s.
+func replaceRefs(s string, re *regexp.Regexp, ctx refContext, value func(m []string) string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+	var b strings.Builder
+	var js jsonScanner
+	last := 0
+	for _, loc := range matches {
+		b.WriteString(s[last:loc[0]])
+		js.scan(s[last:loc[0]])
+		m := make([]string, len(loc)/2)
+		for i := range m {
+			if loc[2*i] >= 0 {
+				m[i] = s[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		v := value(m)
+		switch ctx {
+		case inURL:
+			v = escapeURLValue(s[:loc[0]], v)
+		case inJSON:
+			v = escapeJSONValue(v, js.inString)
+		case inQuery:
+			v = url.QueryEscape(v)
+		}
+		b.WriteString(v)
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// escapeURLValue escapes v for the URL position after prefix.
+func escapeURLValue(prefix, v string) string {
+	if strings.ContainsAny(prefix, "?#") {
+		return url.QueryEscape(v)
+	}
+	if i := strings.Index(prefix, "://"); i >= 0 {
+		prefix = prefix[i+len("://"):]
+	}
+	if strings.Contains(prefix, "/") {
+		return url.PathEscape(v)
+	}
+	return v
+}
+
+// escapeJSONValue escapes v for a JSON document, inside a string literal
+// when inString is set.
+func escapeJSONValue(v string, inString bool) string {
+	if !inString && json.Valid([]byte(v)) {
+		return v
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	quoted := strings.TrimSuffix(b.String(), "\n")
+	if inString {
+		return quoted[1 : len(quoted)-1]
+	}
+	return quoted
+}
+
+// jsonScanner tracks whether the text scanned so far ends inside a JSON
+// string literal.
+type jsonScanner struct {
+	inString bool
+	escaped  bool
+}
+
+// scan continues the scan with text.
+func (js *jsonScanner) scan(text string) {
+	for i := 0; i < len(text); i++ {
+		switch {
+		case js.escaped:
+			js.escaped = false
+		case js.inString && text[i] == '\\':
+			js.escaped = true
+		case text[i] == '"':
+			js.inString = !js.inString
+		}
+	}
+}
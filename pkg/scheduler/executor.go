@@ -0,0 +1,208 @@
+// pkg/scheduler/executor.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Job types understood by the default executors.
+const (
+	JobTypeHTTP = "http"
+	JobTypeNoop = "noop"
+)
+
+// JobTypeGRPC calls a unary gRPC method. Its executor lives in the grpcexec
+// subpackage so that embedders who don't need it avoid the gRPC dependency.
+const JobTypeGRPC = "grpc"
+
+// Message-publish job types. Their executors live in the kafkaexec and
+// amqpexec subpackages and take broker connection settings from the embedder.
+const (
+	JobTypeKafka = "kafka"
+	JobTypeAMQP  = "amqp"
+)
+
+// PublishConfig configures JobTypeKafka and JobTypeAMQP jobs.
+type PublishConfig struct {
+	// Topic is the Kafka topic.
+	Topic string `json:"topic,omitempty"`
+	// Key is the Kafka message key.
+	Key string `json:"key,omitempty"`
+	// Exchange is the AMQP exchange. Empty means the default exchange.
+	Exchange string `json:"exchange,omitempty"`
+	// RoutingKey is the AMQP routing key.
+	RoutingKey string `json:"routingKey,omitempty"`
+	// ContentType is the AMQP content type of the message.
+	ContentType string `json:"contentType,omitempty"`
+	// Message is the message body.
+	Message string `json:"message"`
+	// Headers are sent as message headers.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// GRPCConfig configures a JobTypeGRPC job.
+type GRPCConfig struct {
+	// Target is the server address, e.g. "localhost:50051".
+	Target string `json:"target"`
+	// Method is the full method name, e.g. "helloworld.Greeter/SayHello".
+	Method string `json:"method"`
+	// Request is the JSON-encoded request message. Empty means an empty message.
+	Request string `json:"request,omitempty"`
+	// DescriptorSet is the path to a protoc descriptor set (built with
+	// --include_imports), below the directory set by WithFileRoot. Empty
+	// means the server's reflection service is used.
+	DescriptorSet string `json:"descriptorSet,omitempty"`
+	// Metadata is sent as request headers.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Timeout is the call deadline as a Go duration string. Empty means 10s.
+	Timeout string `json:"timeout,omitempty"`
+	// Plaintext disables TLS.
+	Plaintext bool `json:"plaintext,omitempty"`
+}
+
+// Result is the outcome of a single job execution.
+type Result struct {
+	// Success reports whether the execution met the job's success criteria.
+	// A successful execution stops the scheduler.
+	Success bool `json:"success"`
+	// StatusCode is the protocol status code, if the job type has one.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Output is the response body or other textual output of the execution.
+	Output string `json:"output,omitempty"`
+	// Timing is the connection timing of HTTP-based job types.
+	Timing *RequestTiming `json:"timing,omitempty"`
+}
+
+// Executor runs a single execution of a job.
+type Executor interface {
+	Execute(ctx context.Context, config Config) (Result, error)
+}
+
+// ExecutorFunc adapts an ordinary function to the Executor interface.
+type ExecutorFunc func(ctx context.Context, config Config) (Result, error)
+
+// Execute calls f(ctx, config).
+func (f ExecutorFunc) Execute(ctx context.Context, config Config) (Result, error) {
+	return f(ctx, config)
+}
+
+// NoopExecutor does nothing and returns a fixed result. It is useful for
+// testing and for exercising the scheduling loop without side effects.
+type NoopExecutor struct {
+	Result Result
+	Err    error
+}
+
+// Execute returns the configured result and error.
+func (e NoopExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	return e.Result, e.Err
+}
+
+// HTTPExecutor calls the configured API URL. GET requests add the payload
+// to the query parameters of the URL and POST requests send it as a form
+// body, which is multipart/form-data when Config.Files is set, or as the
+// body of another type given by Config.PayloadType. A 200 OK response is
+// treated as success.
+type HTTPExecutor struct {
+	Clients *ClientManager
+	// FileRoot is the directory Config.PayloadFrom and Config.Files files
+	// are read from, as set by WithFileRoot. Empty rejects them.
+	FileRoot string
+}
+
+// Execute makes the HTTP request based on the scheduler's configuration.
+func (e *HTTPExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	var req *http.Request
+	var err error
+
+	text := config.Payload
+	if config.PayloadFrom != nil {
+		if text, err = config.PayloadFrom.load(ctx, e.Clients.Client(config.transport()), e.FileRoot); err != nil {
+			return Result{}, err
+		}
+	}
+	var payload url.Values
+	if config.payloadType() == PayloadForm && !config.RawQuery {
+		if payload, err = parsePayload(text); err != nil {
+			return Result{}, err
+		}
+	}
+
+	if config.payloadType() != PayloadForm {
+		body, err := requestBody(config, text)
+		if err != nil {
+			return Result{}, err
+		}
+		req, err = http.NewRequestWithContext(ctx, "POST", config.APIURL, bytes.NewReader(body))
+		if err != nil {
+			return Result{}, i18n.Errorf("요청 생성 오류: %w", err)
+		}
+		req.Header.Set("Content-Type", config.contentType())
+	} else if len(config.Files) > 0 {
+		body, contentType, err := multipartBody(payload, config.Files, e.FileRoot)
+		if err != nil {
+			return Result{}, err
+		}
+		req, err = http.NewRequestWithContext(ctx, "POST", config.APIURL, body)
+		if err != nil {
+			return Result{}, i18n.Errorf("요청 생성 오류: %w", err)
+		}
+		req.Header.Set("Content-Type", contentType)
+	} else if strings.ToUpper(config.HTTPMethod) == "POST" {
+		req, err = http.NewRequestWithContext(ctx, "POST", config.APIURL, strings.NewReader(payload.Encode()))
+		if err != nil {
+			return Result{}, i18n.Errorf("요청 생성 오류: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		baseURL, err := url.Parse(config.APIURL)
+		if err != nil {
+			return Result{}, i18n.Errorf("URL 파싱 오류: %w", err)
+		}
+		query := payload.Encode()
+		if config.RawQuery {
+			if err := checkRawQuery(text); err != nil {
+				return Result{}, i18n.Errorf("%w: %v", ErrPayloadRender, err)
+			}
+			query = text
+		}
+		req, err = http.NewRequestWithContext(ctx, "GET", appendQuery(baseURL, query).String(), nil)
+		if err != nil {
+			return Result{}, i18n.Errorf("요청 생성 오류: %w", err)
+		}
+	}
+
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+	setAcceptEncoding(req)
+	injectTrace(ctx, req)
+	if err := config.Signing.sign(req); err != nil {
+		return Result{}, i18n.Errorf("요청 서명 오류: %w", err)
+	}
+	req, tracer := traceRequest(req)
+
+	resp, err := config.Auth.do(withCookieJar(ctx, e.Clients.Client(config.transport())), req)
+	if err != nil {
+		return Result{Timing: tracer.finish(true)}, i18n.Errorf("API 호출 오류: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readBody(ctx, resp, e.Clients.maxResponseSize)
+	if err != nil {
+		return Result{StatusCode: resp.StatusCode, Timing: tracer.finish(true)}, i18n.Errorf("응답 본문 읽기 오류: %w", err)
+	}
+
+	return Result{
+		Success:    resp.StatusCode == http.StatusOK,
+		StatusCode: resp.StatusCode,
+		Output:     body,
+		Timing:     tracer.finish(false),
+	}, nil
+}
@@ -0,0 +1,157 @@
+// pkg/scheduler/fanout.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeFanout calls a list of URLs in parallel on every fire time and
+// records the results as a single execution, e.g. to sweep the health
+// endpoints of a fleet.
+const JobTypeFanout = "fanout"
+
+// maxFanoutTargets bounds the targets of a FanoutConfig.
+const maxFanoutTargets = 1000
+
+// FanoutConfig configures a JobTypeFanout job. Every target is called with
+// the job's HTTPMethod, Payload, Headers and Transport, as for JobTypeHTTP.
+type FanoutConfig struct {
+	// Targets are the URLs called on every fire time.
+	Targets []string `json:"targets"`
+	// Concurrency is how many targets are called at once. Zero means all
+	// of them.
+	Concurrency int `json:"concurrency,omitempty"`
+	// MinSuccessRate is the fraction of targets (0 to 1) that must succeed
+	// for the execution to succeed. Zero means all of them.
+	MinSuccessRate float64 `json:"minSuccessRate,omitempty"`
+}
+
+// validate checks the fan-out settings. A nil FanoutConfig is valid.
+func (c *FanoutConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Targets) == 0 || len(c.Targets) > maxFanoutTargets {
+		return i18n.Errorf("%w: fanout.targets는 1개에서 %d개 사이여야 합니다", ErrInvalidConfig, maxFanoutTargets)
+	}
+	for _, t := range c.Targets {
+		if t == "" {
+			return i18n.Errorf("%w: fanout.targets에 빈 URL이 있습니다", ErrInvalidConfig)
+		}
+	}
+	if c.Concurrency < 0 || c.Concurrency > maxFanoutTargets {
+		return i18n.Errorf("%w: fanout.concurrency는 0에서 %d 사이여야 합니다", ErrInvalidConfig, maxFanoutTargets)
+	}
+	if c.MinSuccessRate < 0 || c.MinSuccessRate > 1 {
+		return i18n.Errorf("%w: fanout.minSuccessRate는 0에서 1 사이여야 합니다", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// concurrency returns the number of targets called at once.
+func (c *FanoutConfig) concurrency() int {
+	if c.Concurrency <= 0 || c.Concurrency > len(c.Targets) {
+		return len(c.Targets)
+	}
+	return c.Concurrency
+}
+
+// FanoutTargetResult is the outcome of the call to one target.
+type FanoutTargetResult struct {
+	URL        string  `json:"url"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	Success    bool    `json:"success"`
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+	// Timing is the connection timing of a failed call.
+	Timing *RequestTiming `json:"timing,omitempty"`
+}
+
+// FanoutReport is the JSON output of a JobTypeFanout execution. Results are
+// in the order of the targets.
+type FanoutReport struct {
+	Targets     int                  `json:"targets"`
+	Successes   int                  `json:"successes"`
+	Failures    int                  `json:"failures"`
+	SuccessRate float64              `json:"successRate"`
+	Results     []FanoutTargetResult `json:"results"`
+}
+
+// FanoutExecutor calls the targets of a JobTypeFanout job through Executor,
+// which is the HTTP executor when it's registered by New, and reports a
+// FanoutReport.
+type FanoutExecutor struct {
+	Executor Executor
+}
+
+// Execute calls every target with the configured concurrency. Response
+// bodies are left out of the report.
+func (e *FanoutExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	fc := config.Fanout
+	if fc == nil || len(fc.Targets) == 0 {
+		return Result{}, i18n.Errorf("다중 대상 설정(fanout)이 없습니다")
+	}
+
+	report := FanoutReport{Targets: len(fc.Targets), Results: make([]FanoutTargetResult, len(fc.Targets))}
+	sem := make(chan struct{}, fc.concurrency())
+	var wg sync.WaitGroup
+	var p panics
+	for i, target := range fc.Targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			defer p.catch()
+			req := config
+			req.APIURL = target
+			started := time.Now()
+			res, err := e.Executor.Execute(ctx, req)
+			tr := FanoutTargetResult{
+				URL:        target,
+				StatusCode: res.StatusCode,
+				Success:    err == nil && res.Success,
+				DurationMs: ms(time.Since(started)),
+			}
+			if err != nil {
+				tr.Error = err.Error()
+			}
+			if !tr.Success {
+				tr.Timing = res.Timing
+			}
+			report.Results[i] = tr
+		}()
+	}
+	wg.Wait()
+	if p.err != nil {
+		return Result{}, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	for _, tr := range report.Results {
+		if tr.Success {
+			report.Successes++
+		}
+	}
+	report.Failures = report.Targets - report.Successes
+	report.SuccessRate = float64(report.Successes) / float64(report.Targets)
+
+	out, err := json.Marshal(report)
+	if err != nil {
+		return Result{}, err
+	}
+	minRate := fc.MinSuccessRate
+	if minRate == 0 {
+		minRate = 1
+	}
+	return Result{
+		Success: report.SuccessRate >= minRate,
+		Output:  string(out),
+	}, nil
+}
@@ -0,0 +1,126 @@
+// pkg/scheduler/filestore/filestore.go
+package filestore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+	"go-api-scheduler/pkg/storage"
+)
+
+// Store keeps scheduler records in a single JSON file. Every change rewrites
+// the file atomically, which is fine for the handful of schedulers a single
+// instance runs.
+type Store struct {
+	path string
+
+	// mu protects records and serializes writes to the file.
+	mu      sync.Mutex
+	records map[string]scheduler.Record
+}
+
+func init() {
+	storage.Register("file", func(o storage.Options) (storage.Store, error) {
+		s, err := Open(storage.DefaultPath(o.Path, "api-scheduler-state.json"))
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// Open loads the store at path, creating an empty one if the file does not exist.
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		records: make(map[string]scheduler.Record),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("상태 파일 읽기 오류: %w", err)
+	}
+
+	var records []scheduler.Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, i18n.Errorf("상태 파일 파싱 오류: %w", err)
+	}
+	for _, rec := range records {
+		s.records[rec.ID] = rec
+	}
+	return s, nil
+}
+
+// Close does nothing: every change is already written to the file.
+func (s *Store) Close() error {
+	return nil
+}
+
+// Save creates or replaces the record with rec.ID.
+func (s *Store) Save(rec scheduler.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[rec.ID] = rec
+	return s.flush()
+}
+
+// Delete removes the record with the given ID, if any.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[id]; !ok {
+		return nil
+	}
+	delete(s.records, id)
+	return s.flush()
+}
+
+// Load returns every stored record, ordered by ID.
+func (s *Store) Load() ([]scheduler.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sorted(), nil
+}
+
+// sorted returns the records ordered by ID. The caller must hold mu.
+func (s *Store) sorted() []scheduler.Record {
+	records := make([]scheduler.Record, 0, len(s.records))
+	for _, rec := range s.records {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].ID < records[b].ID })
+	return records
+}
+
+// flush writes all records to a temporary file and renames it over the
+// store file. The caller must hold mu.
+func (s *Store) flush() error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,128 @@
+// pkg/scheduler/fire.go
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Misfire policies decide what happens to fire times that passed without an
+// execution, e.g. because the process was suspended or an execution ran
+// longer than the interval.
+const (
+	// MisfireRunOnce executes once for all missed fire times. It is the default.
+	MisfireRunOnce = "run-once"
+	// MisfireRunAll executes once per missed fire time, up to maxCatchUpRuns.
+	MisfireRunAll = "run-all"
+	// MisfireSkip drops missed fire times and waits for the next one.
+	MisfireSkip = "skip"
+)
+
+const (
+	// misfireThreshold is how late a fire time may be handled before it
+	// counts as missed.
+	misfireThreshold = time.Second
+	// maxCatchUpRuns bounds the executions made by MisfireRunAll at once.
+	maxCatchUpRuns = 100
+	// maxTimerWait bounds a single timer wait. Timers follow the monotonic
+	// clock, which stops while the machine sleeps, so long waits are split
+	// up and re-checked against the wall clock.
+	maxTimerWait = time.Minute
+)
+
+// fireSchedule computes fire times at a fixed interval from an anchor.
+type fireSchedule struct {
+	anchor   time.Time
+	interval time.Duration
+	// calendar skips the fire times on days it doesn't allow. Nil allows
+	// every day.
+	calendar *calendar
+}
+
+// next returns the first fire time strictly after t on a day the calendar
+// allows.
+func (f fireSchedule) next(t time.Time) time.Time {
+	n := f.after(t)
+	for i := 0; i < maxCalendarDays && !f.calendar.allows(n); i++ {
+		y, m, d := n.Local().Date()
+		n = f.after(time.Date(y, m, d+1, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond))
+	}
+	return n
+}
+
+// after returns the first fire time strictly after t. Intervals of whole
+// days are counted in calendar days, so the fire times keep their time of
+// day across daylight saving time changes.
+func (f fireSchedule) after(t time.Time) time.Time {
+	if t.Before(f.anchor) {
+		return f.anchor
+	}
+	n := t.Sub(f.anchor)/f.interval + 1
+	if f.interval%(24*time.Hour) != 0 {
+		return f.anchor.Add(n * f.interval)
+	}
+
+	days := int(f.interval / (24 * time.Hour))
+	at := func(n time.Duration) time.Time { return f.anchor.AddDate(0, 0, int(n)*days) }
+	// A day is 23 or 25 hours long across a change, so the estimate may be
+	// one off either way.
+	for n > 1 && at(n-1).After(t) {
+		n--
+	}
+	for !at(n).After(t) {
+		n++
+	}
+	return at(n)
+}
+
+// due returns how many fire times lie in [from, to].
+func (f fireSchedule) due(from, to time.Time) int {
+	if to.Before(from) {
+		return 0
+	}
+	return int(to.Sub(from)/f.interval) + 1
+}
+
+// waitUntil blocks until the time told by now reaches t or ctx is done.
+// It reports whether t was reached. When moved is not nil, the channel it
+// returns is closed when now jumps, which ends the current timer early.
+func waitUntil(ctx context.Context, t time.Time, now func() time.Time, moved func() <-chan struct{}) bool {
+	timer := time.NewTimer(0)
+	<-timer.C
+	defer timer.Stop()
+
+	for {
+		var jumped <-chan struct{}
+		if moved != nil {
+			jumped = moved()
+		}
+		// Round(0) strips the monotonic reading so the comparison uses
+		// the wall clock, which keeps counting while the machine sleeps.
+		wait := t.Sub(now().Round(0))
+		if wait <= 0 {
+			return true
+		}
+		if wait > maxTimerWait {
+			wait = maxTimerWait
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-jumped:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// validMisfirePolicy reports whether p names a known misfire policy.
+func validMisfirePolicy(p string) bool {
+	switch p {
+	case "", MisfireRunOnce, MisfireRunAll, MisfireSkip:
+		return true
+	}
+	return false
+}
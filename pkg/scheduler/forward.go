@@ -0,0 +1,138 @@
+// pkg/scheduler/forward.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// forwardTimeout bounds the delivery of a forwarded response.
+const forwardTimeout = 30 * time.Second
+
+// ForwardConfig sends the response of every successful execution to a
+// downstream URL, so that a scheduler can poll one API and push what it
+// gets to another.
+type ForwardConfig struct {
+	URL string `json:"url"`
+	// Method is POST (default), PUT or PATCH.
+	Method string `json:"method,omitempty"`
+	// Headers are added to the forwarded requests, e.g. for the
+	// credentials of the downstream API.
+	Headers map[string]string `json:"headers,omitempty"`
+	// BodyOnly sends the response body as it is, with the metadata in
+	// X-Scheduler-* headers, instead of a ForwardedResponse.
+	BodyOnly bool `json:"bodyOnly,omitempty"`
+}
+
+// ForwardedResponse is the body sent by a ForwardConfig.
+type ForwardedResponse struct {
+	SchedulerID string    `json:"schedulerId"`
+	Name        string    `json:"name,omitempty"`
+	ExecutionID string    `json:"executionId,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	DurationMs  int64     `json:"durationMs"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	// Body is the response body, embedded as JSON when it is JSON and as
+	// a string otherwise.
+	Body any `json:"body"`
+}
+
+// validate checks the forward settings. A nil ForwardConfig is valid.
+func (f *ForwardConfig) validate() error {
+	if f == nil {
+		return nil
+	}
+	u, err := url.Parse(f.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return i18n.Errorf("%w: forward.url은 http 또는 https URL이어야 합니다: %q", ErrInvalidConfig, f.URL)
+	}
+	switch f.method() {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return i18n.Errorf("%w: forward.method는 POST, PUT, PATCH 중 하나여야 합니다: %q", ErrInvalidConfig, f.Method)
+	}
+	return nil
+}
+
+// method returns the HTTP method of the forwarded requests.
+func (f *ForwardConfig) method() string {
+	if f.Method == "" {
+		return http.MethodPost
+	}
+	return strings.ToUpper(f.Method)
+}
+
+// forward sends the response output of the successful execution rec to
+// the URL of the job's ForwardConfig. The output is sent unredacted, as
+// the executor returned it. Failures are logged and not retried.
+func (j *job) forward(rec Execution, output string) {
+	f := j.config.Forward
+	if f == nil || !rec.Success {
+		return
+	}
+	var body []byte
+	contentType := "application/json"
+	if f.BodyOnly {
+		body = []byte(output)
+		if !json.Valid(body) {
+			contentType = "text/plain; charset=utf-8"
+		}
+	} else {
+		fr := ForwardedResponse{
+			SchedulerID: j.id,
+			Name:        j.config.Name,
+			ExecutionID: rec.ID,
+			StartedAt:   rec.StartedAt,
+			DurationMs:  rec.Duration.Milliseconds(),
+			StatusCode:  rec.StatusCode,
+			Body:        output,
+		}
+		if json.Valid([]byte(output)) {
+			fr.Body = json.RawMessage(output)
+		}
+		var err error
+		if body, err = json.Marshal(fr); err != nil {
+			j.logAt(LogError, "응답 전달 오류: %v", err)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, f.method(), f.URL, bytes.NewReader(body))
+	if err != nil {
+		j.logAt(LogError, "응답 전달 오류: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", contentType)
+	if f.BodyOnly {
+		req.Header.Set("X-Scheduler-Id", j.id)
+		req.Header.Set("X-Scheduler-Execution-Id", rec.ID)
+		if rec.StatusCode != 0 {
+			req.Header.Set("X-Scheduler-Status-Code", strconv.Itoa(rec.StatusCode))
+		}
+	}
+	for name, value := range f.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := j.sched.clients.Client(TransportConfig{}).Do(req)
+	if err != nil {
+		j.logAt(LogError, "응답 전달 오류: %v", j.sched.redactor.Text(err.Error()))
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		j.logAt(LogError, "응답 전달 오류: 상태 코드 %d", resp.StatusCode)
+		return
+	}
+	j.logAt(LogDebug, "응답을 전달했습니다: %s (%d바이트)", j.sched.redactor.Text(f.URL), len(body))
+}
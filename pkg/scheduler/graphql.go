@@ -0,0 +1,98 @@
+// pkg/scheduler/graphql.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeGraphQL sends a GraphQL query or mutation to Config.APIURL.
+const JobTypeGraphQL = "graphql"
+
+// GraphQLConfig configures a JobTypeGraphQL job.
+type GraphQLConfig struct {
+	// Query is the query or mutation document.
+	Query string `json:"query"`
+	// Variables is the JSON object of operation variables.
+	Variables json.RawMessage `json:"variables,omitempty"`
+	// OperationName selects the operation when Query contains several.
+	OperationName string `json:"operationName,omitempty"`
+}
+
+// GraphQLExecutor posts a GraphQL request to the configured API URL. A 200 OK
+// response without a non-empty "errors" array is treated as success.
+type GraphQLExecutor struct {
+	Clients *ClientManager
+}
+
+// graphQLRequest is the standard GraphQL-over-HTTP request body.
+type graphQLRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	OperationName string          `json:"operationName,omitempty"`
+}
+
+// graphQLResponse holds the part of a GraphQL response used to judge success.
+type graphQLResponse struct {
+	Errors []json.RawMessage `json:"errors"`
+}
+
+// Execute sends the GraphQL request based on the scheduler's configuration.
+func (e *GraphQLExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	gc := config.GraphQL
+	if gc == nil || gc.Query == "" {
+		return Result{}, i18n.Errorf("GraphQL 쿼리가 설정되지 않았습니다")
+	}
+
+	body, err := json.Marshal(graphQLRequest{
+		Query:         gc.Query,
+		Variables:     gc.Variables,
+		OperationName: gc.OperationName,
+	})
+	if err != nil {
+		return Result{}, i18n.Errorf("GraphQL 변수 인코딩 오류: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", config.APIURL, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, i18n.Errorf("요청 생성 오류: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, application/json")
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+	setAcceptEncoding(req)
+	injectTrace(ctx, req)
+	if err := config.Signing.sign(req); err != nil {
+		return Result{}, i18n.Errorf("요청 서명 오류: %w", err)
+	}
+	req, tracer := traceRequest(req)
+
+	resp, err := config.Auth.do(withCookieJar(ctx, e.Clients.Client(config.transport())), req)
+	if err != nil {
+		return Result{Timing: tracer.finish(true)}, i18n.Errorf("API 호출 오류: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := readBody(ctx, resp, e.Clients.maxResponseSize)
+	if err != nil {
+		return Result{StatusCode: resp.StatusCode, Timing: tracer.finish(true)}, i18n.Errorf("응답 본문 읽기 오류: %w", err)
+	}
+
+	var gr graphQLResponse
+	success := resp.StatusCode == http.StatusOK &&
+		json.Unmarshal([]byte(respBody), &gr) == nil &&
+		len(gr.Errors) == 0
+
+	return Result{
+		Success:    success,
+		StatusCode: resp.StatusCode,
+		Output:     respBody,
+		Timing:     tracer.finish(false),
+	}, nil
+}
@@ -0,0 +1,256 @@
+// pkg/scheduler/grpcexec/grpcexec.go
+package grpcexec
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// defaultTimeout is the call deadline used when GRPCConfig.Timeout is empty.
+const defaultTimeout = 10 * time.Second
+
+// Executor invokes the unary gRPC method configured in Config.GRPC. The
+// method's message types are resolved from the configured descriptor set
+// file or, when none is given, through server reflection. A call that
+// returns status OK is treated as success.
+type Executor struct {
+	// FileRoot is the directory descriptor set files are read from, as
+	// returned by Scheduler.FileRoot. Empty rejects them.
+	FileRoot string
+}
+
+// Execute makes the gRPC call based on the scheduler's configuration.
+func (e *Executor) Execute(ctx context.Context, config scheduler.Config) (scheduler.Result, error) {
+	gc := config.GRPC
+	if gc == nil || gc.Target == "" || gc.Method == "" {
+		return scheduler.Result{}, i18n.Errorf("gRPC 대상 또는 메서드가 설정되지 않았습니다")
+	}
+
+	service, method, err := splitMethod(gc.Method)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	timeout := defaultTimeout
+	if gc.Timeout != "" {
+		timeout, err = time.ParseDuration(gc.Timeout)
+		if err != nil {
+			return scheduler.Result{}, i18n.Errorf("gRPC 타임아웃 파싱 오류: %w", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var creds credentials.TransportCredentials
+	if gc.Plaintext {
+		creds = insecure.NewCredentials()
+	} else {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.NewClient(gc.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("gRPC 연결 생성 오류: %w", err)
+	}
+	defer conn.Close()
+
+	var files *protoregistry.Files
+	if gc.DescriptorSet != "" {
+		files, err = loadDescriptorSet(e.FileRoot, gc.DescriptorSet)
+	} else {
+		files, err = resolveByReflection(ctx, conn, service)
+	}
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	md, err := findMethod(files, service, method)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	req := dynamicpb.NewMessage(md.Input())
+	body := gc.Request
+	if body == "" {
+		body = "{}"
+	}
+	if err := protojson.Unmarshal([]byte(body), req); err != nil {
+		return scheduler.Result{}, i18n.Errorf("gRPC 요청 메시지 파싱 오류: %w", err)
+	}
+	resp := dynamicpb.NewMessage(md.Output())
+
+	if len(gc.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(gc.Metadata))
+	}
+
+	err = conn.Invoke(ctx, "/"+service+"/"+method, req, resp)
+	st := status.Convert(err)
+	if err != nil {
+		return scheduler.Result{
+			StatusCode: int(st.Code()),
+			Output:     fmt.Sprintf("%s: %s", st.Code(), st.Message()),
+		}, nil
+	}
+
+	out, err := protojson.Marshal(resp)
+	if err != nil {
+		return scheduler.Result{}, i18n.Errorf("gRPC 응답 메시지 변환 오류: %w", err)
+	}
+	return scheduler.Result{
+		Success:    true,
+		StatusCode: int(st.Code()),
+		Output:     string(out),
+	}, nil
+}
+
+// splitMethod splits "package.Service/Method" (with an optional leading
+// slash) into its service and method names.
+func splitMethod(full string) (service, method string, err error) {
+	full = strings.TrimPrefix(full, "/")
+	i := strings.LastIndex(full, "/")
+	if i <= 0 || i == len(full)-1 {
+		return "", "", i18n.Errorf("gRPC 메서드 형식이 올바르지 않습니다 (package.Service/Method): %s", full)
+	}
+	return full[:i], full[i+1:], nil
+}
+
+// findMethod looks up a unary method descriptor in files.
+func findMethod(files *protoregistry.Files, service, method string) (protoreflect.MethodDescriptor, error) {
+	d, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, i18n.Errorf("gRPC 서비스를 찾을 수 없습니다: %s", service)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, i18n.Errorf("gRPC 서비스가 아닙니다: %s", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, i18n.Errorf("gRPC 메서드를 찾을 수 없습니다: %s/%s", service, method)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, i18n.Errorf("스트리밍 gRPC 메서드는 지원하지 않습니다: %s/%s", service, method)
+	}
+	return md, nil
+}
+
+// loadDescriptorSet reads a FileDescriptorSet produced by
+// "protoc --include_imports --descriptor_set_out" from name below root.
+func loadDescriptorSet(root, name string) (*protoregistry.Files, error) {
+	path, err := scheduler.ResolveFile(root, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("proto 디스크립터 파일 읽기 오류: %w", err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fds); err != nil {
+		return nil, i18n.Errorf("proto 디스크립터 파싱 오류: %w", err)
+	}
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return nil, i18n.Errorf("proto 디스크립터 파싱 오류: %w", err)
+	}
+	return files, nil
+}
+
+// resolveByReflection fetches the file defining service, and everything it
+// imports, from the server reflection service.
+func resolveByReflection(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, i18n.Errorf("gRPC 서버 리플렉션 오류: %w", err)
+	}
+	defer stream.CloseSend()
+
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	fetch := func(req *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return errors.New(errResp.GetErrorMessage())
+		}
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return err
+			}
+			protos[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	err = fetch(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, i18n.Errorf("gRPC 서버 리플렉션 오류: %w", err)
+	}
+
+	// Servers usually send transitive dependencies along with the file, but
+	// fetch any that are still missing by name.
+	for missing := missingDeps(protos); len(missing) > 0; missing = missingDeps(protos) {
+		for _, name := range missing {
+			err := fetch(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+			})
+			if err != nil {
+				return nil, i18n.Errorf("gRPC 서버 리플렉션 오류: %w", err)
+			}
+			if _, ok := protos[name]; !ok {
+				return nil, i18n.Errorf("gRPC 서버 리플렉션 오류: %s 파일을 받지 못했습니다", name)
+			}
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range protos {
+		fds.File = append(fds.File, fd)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, i18n.Errorf("proto 디스크립터 파싱 오류: %w", err)
+	}
+	return files, nil
+}
+
+// missingDeps returns the imports of protos that have not been fetched yet.
+func missingDeps(protos map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	for _, fd := range protos {
+		for _, dep := range fd.GetDependency() {
+			if _, ok := protos[dep]; !ok {
+				missing = append(missing, dep)
+			}
+		}
+	}
+	return missing
+}
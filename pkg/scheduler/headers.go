@@ -0,0 +1,72 @@
+// pkg/scheduler/headers.go
+package scheduler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// builtinHeaders are the default headers of a Scheduler created without
+// WithDefaultHeaders. They let the targets attribute requests to a
+// scheduler in their access logs.
+var builtinHeaders = map[string]string{
+	"User-Agent":     "go-api-scheduler (scheduler {schedulerId}; run {run})",
+	"X-Scheduler-Id": "{schedulerId}",
+}
+
+// WithDefaultHeaders sets the headers added to the requests of every
+// HTTP-based job, replacing the built-in User-Agent and X-Scheduler-Id
+// headers; an empty map sends none. The placeholders {schedulerId},
+// {name}, {run}, {attempt} and {executionId} are replaced in the values.
+// The X-Execution-Id header is always sent. Headers set by a scheduler
+// take precedence, regardless of case.
+func WithDefaultHeaders(h map[string]string) Option {
+	return func(s *Scheduler) {
+		s.settings.defaultHeaders = h
+	}
+}
+
+// idempotencyHeader carries the idempotency key of an execution, and
+// executionHeader the ID of its run.
+const (
+	idempotencyHeader = "Idempotency-Key"
+	executionHeader   = "X-Execution-Id"
+)
+
+// requestHeaders returns the headers of attempt of the run-th execution of
+// j: the default headers with their placeholders replaced, the execution
+// ID and the idempotency key, if any, overridden by own, the job's.
+func (j *job) requestHeaders(own map[string]string, run, attempt int, idempotencyKey string) map[string]string {
+	r := strings.NewReplacer(
+		"{schedulerId}", j.id,
+		"{name}", j.config.Name,
+		"{run}", strconv.Itoa(run),
+		"{attempt}", strconv.Itoa(attempt),
+		"{executionId}", j.execID,
+	)
+	defaults := j.sched.defaultHeaders()
+	headers := make(map[string]string, len(defaults)+2)
+	for name, value := range defaults {
+		headers[name] = r.Replace(value)
+	}
+	headers[executionHeader] = j.execID
+	if idempotencyKey != "" {
+		headers[idempotencyHeader] = idempotencyKey
+	}
+	return overrideHeaders(headers, own)
+}
+
+// overrideHeaders sets the headers of override in base, replacing those
+// with the same name regardless of case, and returns base.
+func overrideHeaders(base, override map[string]string) map[string]string {
+	for name, value := range override {
+		for d := range base {
+			if http.CanonicalHeaderKey(d) == http.CanonicalHeaderKey(name) {
+				delete(base, d)
+			}
+		}
+		base[name] = value
+	}
+	return base
+}
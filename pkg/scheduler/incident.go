@@ -0,0 +1,189 @@
+// pkg/scheduler/incident.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultIncidentURLs are the URLs of the incident channels without a URL.
+var defaultIncidentURLs = map[string]string{
+	ChannelPagerDuty: "https://events.pagerduty.com/v2/enqueue",
+	ChannelOpsgenie:  "https://api.opsgenie.com/v2/alerts",
+}
+
+// defaultIncidentTemplate is the title of the incidents of channels
+// without a Template.
+const defaultIncidentTemplate = `{{or .Name .SchedulerID}}{{if eq .Event "monitor"}} is down{{else}} failed {{.FailureStreak}} time(s) in a row{{end}}` +
+	`{{with .Payload.statusCode}} (HTTP {{.}}){{end}}{{with .Payload.error}}: {{.}}{{end}}`
+
+// Incident actions.
+const (
+	incidentTrigger = "trigger"
+	incidentResolve = "resolve"
+)
+
+// incident reports whether ch opens incidents rather than sending
+// messages.
+func (ch NotifyChannel) incident() bool {
+	_, ok := defaultIncidentURLs[ch.channelType()]
+	return ok
+}
+
+// routingKey returns the key of the incidents of schedulers in group.
+func (ch NotifyChannel) routingKey(group string) string {
+	if key, ok := ch.GroupRoutingKeys[group]; ok && group != "" {
+		return key
+	}
+	return ch.RoutingKey
+}
+
+// threshold returns the failure streak opening an incident.
+func (ch NotifyChannel) threshold() int {
+	if ch.FailureThreshold <= 0 {
+		return 1
+	}
+	return ch.FailureThreshold
+}
+
+// incidentAction returns what n does to the incident of its scheduler on
+// ch: open it when the failure streak reaches the threshold or a monitor
+// goes down, resolve it when a success ends such a streak or a monitor
+// recovers, and nothing otherwise.
+func incidentAction(ch NotifyChannel, n Notification) string {
+	switch n.Event {
+	case EventFailed:
+		if n.FailureStreak == ch.threshold() {
+			return incidentTrigger
+		}
+	case EventSucceeded:
+		if n.FailureStreak >= ch.threshold() {
+			return incidentResolve
+		}
+	case notifyMonitor:
+		if m, ok := n.Payload.(MonitorEvent); ok {
+			if m.State == MonitorDown {
+				return incidentTrigger
+			}
+			return incidentResolve
+		}
+	}
+	return ""
+}
+
+// incidentKey is the key deduplicating the incidents of a scheduler, so
+// the incident a streak opened is the one its recovery resolves.
+func incidentKey(n Notification) string {
+	return "go-api-scheduler/" + n.SchedulerID
+}
+
+// truncate returns s cut to at most max bytes, on a rune boundary.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+// PagerDutyNotifier opens and resolves PagerDuty incidents through the
+// Events API v2 for ChannelPagerDuty channels.
+type PagerDutyNotifier struct {
+	Clients *ClientManager
+}
+
+// Notify triggers or resolves the incident of the scheduler of n, keyed
+// by the scheduler ID, with the routing key of its group.
+func (p *PagerDutyNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	action := incidentAction(ch, n)
+	key := ch.routingKey(n.Group)
+	if action == "" || key == "" {
+		return nil
+	}
+	event := map[string]any{
+		"routing_key":  key,
+		"event_action": action,
+		"dedup_key":    incidentKey(n),
+	}
+	if action == incidentTrigger {
+		summary, err := n.Render(ch.Template, defaultIncidentTemplate)
+		if err != nil {
+			return err
+		}
+		payload := map[string]any{
+			"summary":        truncate(strings.TrimSpace(summary), 1024),
+			"source":         n.SchedulerID,
+			"severity":       "error",
+			"timestamp":      n.Time,
+			"custom_details": n.Payload,
+		}
+		if n.Group != "" {
+			payload["group"] = n.Group
+		}
+		event["payload"] = payload
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return postNotification(ctx, p.Clients, ch, "application/json", body)
+}
+
+// OpsgenieNotifier creates and closes Opsgenie alerts through the Alert
+// API for ChannelOpsgenie channels.
+type OpsgenieNotifier struct {
+	Clients *ClientManager
+}
+
+// Notify creates or closes the alert of the scheduler of n, aliased by
+// the scheduler ID, with the API key of its group.
+func (o *OpsgenieNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	action := incidentAction(ch, n)
+	key := ch.routingKey(n.Group)
+	if action == "" || key == "" {
+		return nil
+	}
+	headers := maps.Clone(ch.Headers)
+	if headers == nil {
+		headers = make(map[string]string, 1)
+	}
+	headers["Authorization"] = "GenieKey " + key
+	alias := incidentKey(n)
+	base := strings.TrimSuffix(ch.url(), "/")
+	ch.Headers = headers
+
+	var alert map[string]any
+	if action == incidentTrigger {
+		message, err := n.Render(ch.Template, defaultIncidentTemplate)
+		if err != nil {
+			return err
+		}
+		details := map[string]string{"schedulerId": n.SchedulerID}
+		if n.Group != "" {
+			details["group"] = n.Group
+		}
+		description, _ := json.MarshalIndent(n.Payload, "", "  ")
+		alert = map[string]any{
+			"message":     truncate(strings.TrimSpace(message), 130),
+			"alias":       alias,
+			"description": truncate(string(description), 15000),
+			"source":      "go-api-scheduler",
+			"details":     details,
+		}
+		ch.URL = base
+	} else {
+		alert = map[string]any{"source": "go-api-scheduler"}
+		ch.URL = base + "/" + url.PathEscape(alias) + "/close?identifierType=alias"
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	return postNotification(ctx, o.Clients, ch, "application/json", body)
+}
@@ -0,0 +1,480 @@
+// pkg/scheduler/job.go
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// job represents a single scheduler instance.
+type job struct {
+	id    string
+	sched *Scheduler
+	// ctx is cancelled when the scheduler is stopped, which also aborts any
+	// in-flight execution.
+	ctx    context.Context
+	cancel context.CancelFunc
+	config Config
+
+	// anchor is the first fire time, which the interval is counted from.
+	anchor   time.Time
+	interval time.Duration
+	// lastFire is when the most recent fire time was handled. It is zero
+	// until the first execution.
+	lastFire time.Time
+
+	// runs counts executions and persisted is when the job was last
+	// saved; they are only used by the run goroutine.
+	runs      int
+	persisted time.Time
+	// prevOutput is the previous successful response of a job with
+	// Config.Diff, or nil before the first one. It is only used by the run
+	// goroutine.
+	prevOutput *string
+	// last is the previous execution, which the OnlyIf and SkipIf
+	// conditions look at. It is only used by the run goroutine.
+	last *Execution
+	// monitor is the state of a JobTypeMonitor job.
+	monitor monitorState
+	// pruned is when archived responses past the retention were last
+	// deleted. It is only used by the run goroutine.
+	pruned time.Time
+	// sink is the archive sink of the latest attempt, if it streams its
+	// response. It is only used by the run goroutine.
+	sink *archiveSink
+	// session is the cookie jar of a job with Config.Session, created by
+	// its first execution. It is only used by the run goroutine.
+	session *session
+	// streak is the current run of identical failures, whose repeated
+	// messages are collapsed. It is only used by the run goroutine.
+	streak failureStreak
+	// execID is the Execution.ID of the run in progress. It is only used
+	// by the run goroutine.
+	execID string
+	// triggers receives the requests to execute now sent by other
+	// schedulers, and trigger is the one being executed. trigger is only
+	// used by the run goroutine.
+	triggers chan triggerRequest
+	trigger  *triggerRequest
+
+	// next, failures, crash, the heartbeat, stall, the SLA, the metrics, the
+	// last workflow run and the state are reported by List. They are
+	// protected by the scheduler's mu, as is failStreak, the count of
+	// executions that failed in a row.
+	next       time.Time
+	failures   int
+	failStreak int
+	crash      *Crash
+	heartbeat  time.Time
+	stall      *Stall
+	sla        slaState
+	metrics    map[string]MetricSample
+	workflow   *workflowRun
+	// state is one of the State constants, entered at stateSince.
+	state       string
+	stateSince  time.Time
+	transitions []Transition
+	// resumeState is the state a paused job resumes to, and finalState
+	// the state a stopping job ends in.
+	resumeState string
+	finalState  string
+}
+
+// logf emits an event about this job, with the ID of its run in progress.
+// It must only be called from the run goroutine.
+func (j *job) logf(format string, args ...any) {
+	ev := j.sched.logEvent(j.id, format, args...)
+	ev.ExecutionID = j.execID
+	j.sched.bus.publish(ev)
+}
+
+// run is a goroutine that handles the scheduling and API calls for a single scheduler.
+func (j *job) run() {
+	defer j.finish()
+	defer j.recoverCrash()
+	j.publish(EventStarted, nil)
+	defer j.publish(EventStopped, nil)
+	if j.config.Name != "" {
+		j.logf("스케줄러 시작 요청을 받았습니다: %s", j.config.Name)
+	} else {
+		j.logf("스케줄러 시작 요청을 받았습니다.")
+	}
+	j.logf("설정: 시작 시각 %s, 반복 %s, URL %s", j.config.StartTime, j.interval, j.config.APIURL)
+
+	if j.config.Diff != nil {
+		j.loadPreviousOutput()
+	}
+	if j.config.OnlyIf != "" || j.config.SkipIf != "" {
+		j.loadLastExecution()
+	}
+	if j.config.jobType() == JobTypeMonitor {
+		j.loadMonitorState()
+	}
+
+	fs := fireSchedule{anchor: j.anchor, interval: j.interval, calendar: j.sched.newCalendar(j.id, j.config.Calendar)}
+	var next time.Time
+	if j.lastFire.IsZero() {
+		j.logf("스케줄 시작까지 대기 중입니다... 남은 시간: %s", j.anchor.Sub(j.sched.clock.Now()).Round(time.Millisecond))
+		j.setNext(j.anchor)
+		j.advance(StateWaiting)
+
+		for {
+			req, ok := j.wait(j.anchor)
+			if !ok {
+				j.logf("스케줄러가 시작 전에 중지되었습니다.")
+				return
+			}
+			if req == nil {
+				break
+			}
+			j.runTriggered(*req)
+		}
+		next = fs.next(j.anchor)
+	} else {
+		next = j.resumeFrom(fs)
+	}
+
+	j.advance(StateRunning)
+	j.logf("스케줄러가 실행 중입니다.")
+
+	for {
+		j.setNext(next)
+		req, ok := j.wait(next)
+		if !ok {
+			j.logf("스케줄러가 중지되었습니다.")
+			return
+		}
+		// An execution may have stopped the scheduler while we were
+		// already due again.
+		if j.ctx.Err() != nil {
+			return
+		}
+		if req != nil {
+			j.runTriggered(*req)
+			continue
+		}
+
+		now := j.sched.clock.Now().Round(0)
+		j.fire(fs, next, now)
+		j.lastFire = now
+		next = fs.next(now)
+		j.setNext(next)
+		// Fast intervals are saved at most once a second.
+		if j.interval >= minInterval || now.Sub(j.persisted) >= minInterval {
+			j.sched.persistRunning(j)
+			j.persisted = now
+		}
+	}
+}
+
+// setNext records the fire time the job waits for, which is a heartbeat.
+func (j *job) setNext(t time.Time) {
+	j.sched.mu.Lock()
+	j.next = t
+	j.heartbeat = j.sched.clock.Now()
+	j.sched.mu.Unlock()
+}
+
+// resumeFrom returns the first fire time to wait for when the job was
+// restored from a store. Fire times missed while the process was down are
+// caught up within the configured catch-up window; older ones are skipped.
+func (j *job) resumeFrom(fs fireSchedule) time.Time {
+	now := j.sched.clock.Now().Round(0)
+	next := fs.next(j.lastFire)
+	// Prefer the saved fire time, which was computed with the calendar
+	// as it was then.
+	j.sched.mu.Lock()
+	if j.next.After(j.lastFire) {
+		next = j.next
+	}
+	j.sched.mu.Unlock()
+	j.logf("저장된 상태에서 복원되었습니다. 마지막 실행 시각: %s", j.lastFire.Format("2006-01-02 15:04:05"))
+	if !next.Before(now) {
+		return next
+	}
+
+	window, _ := j.config.catchUpWindow()
+	if window <= 0 {
+		j.logAt(LogWarn, "재시작 전 놓친 실행을 따라잡지 않습니다 (catchUpWindow 미설정).")
+		return fs.next(now)
+	}
+	if earliest := now.Add(-window); next.Before(earliest) {
+		next = fs.next(earliest.Add(-time.Nanosecond))
+		j.logAt(LogWarn, "따라잡기 범위(%s)보다 오래된 실행은 건너뜁니다.", window)
+	}
+	return next
+}
+
+// fire handles the fire time at scheduled, which is reached at now, and any
+// fire times missed since then according to the misfire policy.
+func (j *job) fire(fs fireSchedule, scheduled, now time.Time) {
+	// next only returns a day the calendar doesn't allow when it found
+	// none within maxCalendarDays.
+	if !fs.calendar.allows(scheduled) {
+		return
+	}
+	if j.suppressed(now) {
+		return
+	}
+	if locker := j.sched.locker; locker != nil {
+		claimed, err := locker.Claim(j.ctx, j.id, scheduled, j.interval)
+		if err != nil {
+			j.logAt(LogError, "실행 선점 오류: %v", err)
+			return
+		}
+		if !claimed {
+			// Another instance executes this fire time.
+			return
+		}
+	}
+
+	due := fs.due(scheduled, now)
+	if due == 1 && now.Sub(scheduled) <= misfireThreshold {
+		j.execute()
+		return
+	}
+
+	switch j.config.MisfirePolicy {
+	case MisfireSkip:
+		j.logAt(LogWarn, "예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(skip)에 따라 건너뜁니다.", due, scheduled.Format("15:04:05"))
+	case MisfireRunAll:
+		runs := due
+		if runs > maxCatchUpRuns {
+			runs = maxCatchUpRuns
+		}
+		j.logAt(LogWarn, "예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(run-all)에 따라 %d회 실행합니다.", due, scheduled.Format("15:04:05"), runs)
+		for i := 0; i < runs && j.ctx.Err() == nil; i++ {
+			j.execute()
+		}
+	default:
+		j.logAt(LogWarn, "예정된 실행 %d회를 놓쳤습니다 (최초 예정 시각 %s). 정책(run-once)에 따라 한 번 실행합니다.", due, scheduled.Format("15:04:05"))
+		j.execute()
+	}
+}
+
+// suppressed reports whether executions are skipped at now because j is
+// paused, in a blackout window or in maintenance mode, and logs why.
+func (j *job) suppressed(now time.Time) bool {
+	if j.paused() {
+		j.logAt(LogInfo, "일시 중지 상태라 실행을 건너뜁니다.")
+		return true
+	}
+	if w, ok := j.sched.blackout(j.config, now); ok {
+		j.logAt(LogInfo, "점검 시간대(%s)라 실행을 건너뜁니다.", w)
+		return true
+	}
+	if j.sched.inMaintenance() {
+		j.logAt(LogInfo, "점검 모드라 실행을 건너뜁니다.")
+		return true
+	}
+	return false
+}
+
+// execute runs the job once through the executor registered for its type.
+func (j *job) execute() {
+	if !j.conditionsAllow() {
+		return
+	}
+	jobType := j.config.jobType()
+	exec, ok := j.sched.executor(jobType)
+	if !ok {
+		j.logAt(LogError, "등록되지 않은 작업 유형입니다: %s", jobType)
+		return
+	}
+	j.execID = NewID()
+	defer func() { j.execID = "" }()
+	j.publish(EventFired, nil)
+
+	switch {
+	case jobType == JobTypeMonitor:
+		j.logAt(LogInfo, "상태 확인 시작: URL %s", j.config.APIURL)
+	case jobType == JobTypeHTTP:
+		j.logAt(LogInfo, "API 호출 시작: URL %s, 메서드 %s", j.config.APIURL, j.config.HTTPMethod)
+	case jobType == JobTypeGraphQL:
+		j.logAt(LogInfo, "GraphQL 요청 시작: URL %s", j.config.APIURL)
+	case jobType == JobTypeLoad && j.config.Load != nil:
+		j.logAt(LogInfo, "부하 테스트 시작: URL %s, 요청 %d회, 동시 실행 %d", j.config.APIURL, j.config.Load.Requests, j.config.Load.concurrency())
+	case jobType == JobTypeChain && j.config.Chain != nil:
+		j.logAt(LogInfo, "체인 실행 시작: %d단계", len(j.config.Chain.Steps))
+	case jobType == JobTypeWorkflow && j.config.Workflow != nil:
+		j.logAt(LogInfo, "워크플로 실행 시작: %d단계, 동시 실행 %d", len(j.config.Workflow.Steps), j.config.Workflow.concurrency())
+	case jobType == JobTypeFanout && j.config.Fanout != nil:
+		j.logAt(LogInfo, "다중 대상 호출 시작: 대상 %d개, 동시 실행 %d", len(j.config.Fanout.Targets), j.config.Fanout.concurrency())
+	case jobType == JobTypeCommand && j.config.Command != nil:
+		j.logAt(LogInfo, "명령 실행 시작: %s", j.config.Command.Path)
+	case jobType == JobTypeGRPC && j.config.GRPC != nil:
+		j.logAt(LogInfo, "gRPC 호출 시작: 대상 %s, 메서드 %s", j.config.GRPC.Target, j.config.GRPC.Method)
+	case jobType == JobTypeKafka && j.config.Publish != nil:
+		j.logAt(LogInfo, "Kafka 메시지 발행 시작: 토픽 %s", j.config.Publish.Topic)
+	case jobType == JobTypeAMQP && j.config.Publish != nil:
+		j.logAt(LogInfo, "AMQP 메시지 발행 시작: 익스체인지 %q, 라우팅 키 %q", j.config.Publish.Exchange, j.config.Publish.RoutingKey)
+	default:
+		j.logAt(LogInfo, "작업 실행 시작: 유형 %s", jobType)
+	}
+	if len(j.config.Headers) > 0 {
+		j.logAt(LogInfo, "요청 헤더: %s", j.sched.redactor.Headers(j.config.Headers))
+	}
+
+	defer j.discardSink()
+	j.runs++
+	run := j.runs
+	var idempotencyKey string
+	if j.config.IdempotencyKey {
+		idempotencyKey = NewID()
+		j.logAt(LogInfo, "멱등성 키: %s", idempotencyKey)
+	}
+	first := j.prevOutput == nil
+	attempts := j.config.Retry.attempts()
+	backoff, _ := j.config.Retry.backoff()
+	for attempt := 1; ; attempt++ {
+		rec, res, err := j.attempt(exec, run, attempt, idempotencyKey)
+		if rec == nil {
+			return
+		}
+		if err != nil && j.ctx.Err() != nil {
+			j.logAt(LogWarn, "스케줄러가 중지되어 진행 중이던 실행을 취소했습니다.")
+			return
+		}
+
+		// Unsuccessful executions are logged as errors; when they fail the
+		// same way as the previous one, only the error is logged again.
+		level, repeat := LogInfo, j.failureRepeat(rec)
+		if !rec.Success {
+			level = LogError
+		}
+		if err != nil {
+			j.logRepeat(level, repeat, "%s", rec.Error)
+		} else {
+			if res.StatusCode != 0 {
+				j.logRepeat(level, repeat, "실행 완료 - 상태 코드: %d", res.StatusCode)
+			} else {
+				j.logRepeat(level, repeat, "실행 완료")
+			}
+			switch {
+			case j.config.Diff != nil && rec.Success:
+				j.logChanges(rec, first)
+			case rec.Output != "" && repeat <= 1:
+				j.logAt(level, "응답 본문: %s", rec.Output)
+			}
+		}
+
+		// Successful executions only report their timing at debug level.
+		t := rec.Timing
+		if t == nil && j.logs(LogDebug) {
+			t, level = res.Timing, LogDebug
+		}
+		if t != nil && repeat <= 1 {
+			if t.FailedPhase != "" {
+				j.logAt(level, "연결 진단 - 호스트 %s, 실패 단계 %s, DNS %.1fms, 연결 %.1fms, TLS %.1fms, 전체 %.1fms", t.Host, t.FailedPhase, t.DNSMs, t.ConnectMs, t.TLSMs, t.TotalMs)
+			} else {
+				j.logAt(level, "연결 진단 - 호스트 %s, DNS %.1fms, 연결 %.1fms, TLS %.1fms, 첫 바이트 %.1fms, 전체 %.1fms", t.Host, t.DNSMs, t.ConnectMs, t.TLSMs, t.TTFBMs, t.TotalMs)
+			}
+		}
+		if !rec.Success && attempt < attempts && !retryable(err) {
+			j.logAt(LogWarn, "페이로드를 만들 수 없어 재시도하지 않습니다.")
+		} else if !rec.Success && attempt < attempts {
+			j.logAt(LogWarn, "실행 실패 - %s 후 재시도합니다 (%d/%d).", backoff, attempt+1, attempts)
+			if !j.sched.clock.WaitUntil(j.ctx, j.sched.clock.Now().Add(backoff)) || j.ctx.Err() != nil {
+				return
+			}
+			backoff *= 2
+			continue
+		}
+
+		j.last = rec
+		j.archive(*rec, res.Output)
+		j.forward(*rec, res.Output)
+		j.sched.mu.Lock()
+		streak := j.failStreak
+		if rec.Success {
+			j.failStreak = 0
+		} else {
+			j.failures++
+			j.failStreak++
+			streak = j.failStreak
+		}
+		j.sched.mu.Unlock()
+		j.finishRun(rec)
+		j.publishResult(rec, streak)
+		j.triggerNext(rec.Success)
+		if !rec.Success && j.checkStreak(streak, rec) {
+			return
+		}
+		if jobType == JobTypeMonitor {
+			j.observe(*rec)
+		}
+		if rec.Success && j.config.stopsOnSuccess() {
+			j.logf("실행 성공 - 스케줄러가 자동으로 중지됩니다.")
+			j.sched.stopJob(j)
+		}
+		return
+	}
+}
+
+// attempt makes one execution through exec and records it. It returns a
+// nil record when the job was stopped while waiting for the pool.
+func (j *job) attempt(exec Executor, run, attempt int, idempotencyKey string) (*Execution, Result, error) {
+	if !j.sched.pool.tryAcquire() {
+		j.logAt(LogWarn, "동시 실행 한도에 도달하여 실행 대기열에서 대기 중입니다.")
+		if err := j.sched.pool.acquire(j.ctx, j.config.Priority); err != nil {
+			j.logAt(LogWarn, "스케줄러가 중지되어 대기 중이던 실행을 취소했습니다.")
+			return nil, Result{}, err
+		}
+	}
+	j.beat()
+	ctx, span := j.startSpan(run, attempt)
+	ctx = j.newSink(ctx)
+	ctx = j.withApprovalGate(ctx)
+	started, measured := j.sched.clock.Now(), time.Now()
+	res, err := func() (Result, error) {
+		// The slot is released even if the executor panics.
+		defer j.sched.pool.release()
+		config, err := j.sched.applyProfile(j.config)
+		if err != nil {
+			return Result{}, err
+		}
+		if config, err = j.sched.applyVariables(config); err != nil {
+			return Result{}, err
+		}
+		config.Headers = j.requestHeaders(config.Headers, run, attempt, idempotencyKey)
+		return j.executeInSession(ctx, exec, config)
+	}()
+
+	rec := Execution{
+		ID:          j.execID,
+		SchedulerID: j.id,
+		StartedAt:   started,
+		Duration:    time.Since(measured),
+		Success:     err == nil && res.Success,
+		StatusCode:  res.StatusCode,
+		Output:      j.sched.redactor.Body(res.Output),
+	}
+	if j.trigger != nil {
+		rec.TriggeredBy = j.trigger.source
+	}
+	if err != nil {
+		rec.Error = j.sched.redactor.Text(err.Error())
+		if errors.Is(err, ErrPayloadRender) {
+			rec.ErrorCode = ErrorCodePayloadRender
+		}
+	}
+	if !rec.Success {
+		rec.Timing = res.Timing
+	}
+	if j.config.jobType() == JobTypeWorkflow {
+		j.recordWorkflow(rec, res.Output)
+	}
+	if rec.Success && j.config.Transform != nil {
+		err = j.transform(ctx, &rec, &res)
+	}
+	if rec.Success && j.config.Diff != nil {
+		j.diff(&rec)
+	}
+	if rec.Success && len(j.config.Metrics) > 0 {
+		j.extractMetrics(res.Output)
+	}
+	endSpan(span, rec)
+	j.publish(EventExecution, &rec)
+	return &rec, res, err
+}
@@ -0,0 +1,343 @@
+// pkg/scheduler/job_test.go
+package scheduler_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// respond returns a response with the given status code to r.
+func respond(r *http.Request, status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(http.StatusText(status))),
+		Request:    r,
+	}
+}
+
+// calls records the times of the requests sent through a fake transport,
+// as told by the clock of the scheduler.
+type calls struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (c *calls) add(t time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.times = append(c.times, t)
+	return len(c.times)
+}
+
+func (c *calls) list() []time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Time(nil), c.times...)
+}
+
+// events records the events of a scheduler by type.
+type events struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (e *events) HandleEvent(ev scheduler.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.counts[ev.Type]++
+}
+
+func (e *events) count(typ string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.counts[typ]
+}
+
+// start is 08:00 on the day of the tests, an hour before the start time of
+// their schedulers.
+var start = time.Date(2026, 1, 1, 8, 0, 0, 0, time.Local)
+
+// keepRunning is a StopOnSuccess of false, for schedulers that keep
+// executing after a success.
+var keepRunning = new(bool)
+
+// newTestScheduler returns a scheduler on a ManualClock at start whose
+// requests are answered by rt, with the further opts, and the events it
+// publishes.
+func newTestScheduler(t *testing.T, rt http.RoundTripper, opts ...scheduler.Option) (*scheduler.Scheduler, *scheduler.ManualClock, *events) {
+	t.Helper()
+	clock := scheduler.NewManualClock(start)
+	clients := scheduler.NewClientManager(0, 0)
+	clients.SetTransport(rt)
+	opts = append([]scheduler.Option{scheduler.WithClock(clock), scheduler.WithClientManager(clients)}, opts...)
+	sched := scheduler.New(opts...)
+	ev := &events{counts: map[string]int{}}
+	sched.AddSubscriber(ev)
+	return sched, clock, ev
+}
+
+// testContext returns a context bounding the waits of a test.
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// startJob starts config under id and waits for its start time, after
+// which it waits for its first fire time an interval later.
+func startJob(t *testing.T, ctx context.Context, sched *scheduler.Scheduler, clock *scheduler.ManualClock, id string, config scheduler.Config) {
+	t.Helper()
+	if err := sched.Start(id, config); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { sched.Stop(id) })
+	if err := clock.BlockUntil(ctx, 1); err != nil {
+		t.Fatalf("waiting for the start time: %v", err)
+	}
+	if err := clock.AdvanceAndWait(ctx, time.Hour, 1); err != nil {
+		t.Fatalf("reaching the start time: %v", err)
+	}
+}
+
+func TestMisfirePolicies(t *testing.T) {
+	tests := []struct {
+		policy string
+		// missed are the executions for the six fire times passed by
+		// the jump to 09:06:30.
+		missed int
+	}{
+		{"", 1},
+		{scheduler.MisfireRunOnce, 1},
+		{scheduler.MisfireRunAll, 6},
+		{scheduler.MisfireSkip, 0},
+	}
+	for _, tt := range tests {
+		t.Run("policy="+tt.policy, func(t *testing.T) {
+			ctx := testContext(t)
+			var c calls
+			var clock *scheduler.ManualClock
+			sched, clock, ev := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				c.add(clock.Now())
+				return respond(r, http.StatusOK), nil
+			}))
+			startJob(t, ctx, sched, clock, "misfire", scheduler.Config{
+				StartTime:     "09:00:00",
+				Interval:      "1m",
+				APIURL:        "http://api.test/ping",
+				HTTPMethod:    "GET",
+				MisfirePolicy: tt.policy,
+				StopOnSuccess: keepRunning,
+			})
+			if n := len(c.list()); n != 0 {
+				t.Fatalf("%d calls at the start time, want 0", n)
+			}
+
+			// The fire times from 09:01 to 09:06 pass while the clock
+			// jumps to 09:06:30.
+			if err := clock.AdvanceAndWait(ctx, 6*time.Minute+30*time.Second, 1); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(c.list()); n != tt.missed {
+				t.Errorf("%d calls for the missed fire times, want %d", n, tt.missed)
+			}
+			// The next fire time, 09:07, is on time again.
+			if err := clock.AdvanceAndWait(ctx, 30*time.Second, 1); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(c.list()); n != tt.missed+1 {
+				t.Errorf("%d calls after the next fire time, want %d", n, tt.missed+1)
+			}
+			if n := ev.count(scheduler.EventSucceeded); n != tt.missed+1 {
+				t.Errorf("%d succeeded events, want %d", n, tt.missed+1)
+			}
+		})
+	}
+}
+
+func TestStopDuringExecution(t *testing.T) {
+	ctx := testContext(t)
+	inFlight := make(chan struct{})
+	cancelled := make(chan struct{})
+	var c calls
+	var clock *scheduler.ManualClock
+	sched, clock, ev := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if c.add(clock.Now()) == 1 {
+			close(inFlight)
+		}
+		<-r.Context().Done()
+		close(cancelled)
+		return nil, r.Context().Err()
+	}))
+	stopped := make(chan struct{})
+	sched.AddSubscriber(scheduler.SubscriberFunc(func(e scheduler.Event) {
+		if e.Type == scheduler.EventStopped {
+			close(stopped)
+		}
+	}))
+	startJob(t, ctx, sched, clock, "stop", scheduler.Config{
+		StartTime:  "09:00:00",
+		Interval:   "1m",
+		APIURL:     "http://api.test/slow",
+		HTTPMethod: "GET",
+		Retry:      &scheduler.RetryPolicy{MaxAttempts: 3},
+	})
+
+	clock.Advance(time.Minute)
+	select {
+	case <-inFlight:
+	case <-ctx.Done():
+		t.Fatal("the execution didn't start")
+	}
+	ok, err := sched.Stop("stop")
+	if !ok || err != nil {
+		t.Fatalf("Stop = %v, %v; want true, nil", ok, err)
+	}
+	for _, ch := range []chan struct{}{cancelled, stopped} {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			t.Fatal("the execution wasn't cancelled")
+		}
+	}
+
+	status, err := sched.Status("stop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != scheduler.StateStopped {
+		t.Errorf("state %q, want %q", status.State, scheduler.StateStopped)
+	}
+	// A cancelled execution is neither a failure nor retried.
+	if status.Failures != 0 || ev.count(scheduler.EventFailed) != 0 {
+		t.Errorf("%d failures and %d failed events, want none", status.Failures, ev.count(scheduler.EventFailed))
+	}
+	clock.Advance(time.Hour)
+	if n := len(c.list()); n != 1 {
+		t.Errorf("%d calls, want 1", n)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	ctx := testContext(t)
+	var c calls
+	var clock *scheduler.ManualClock
+	sched, clock, ev := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if c.add(clock.Now()) < 3 {
+			return respond(r, http.StatusInternalServerError), nil
+		}
+		return respond(r, http.StatusOK), nil
+	}))
+	startJob(t, ctx, sched, clock, "retry", scheduler.Config{
+		StartTime:     "09:00:00",
+		Interval:      "1h",
+		APIURL:        "http://api.test/flaky",
+		HTTPMethod:    "GET",
+		Retry:         &scheduler.RetryPolicy{MaxAttempts: 3, Backoff: "1m"},
+		StopOnSuccess: keepRunning,
+	})
+
+	// The first attempt fails at 10:00 and the retries wait 1m, then 2m.
+	steps := []struct {
+		advance time.Duration
+		calls   int
+	}{
+		{time.Hour, 1},
+		{59 * time.Second, 1},
+		{time.Second, 2},
+		{time.Minute, 2},
+		{time.Minute, 3},
+	}
+	for i, step := range steps {
+		if err := clock.AdvanceAndWait(ctx, step.advance, 1); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if n := len(c.list()); n != step.calls {
+			t.Fatalf("step %d: %d calls at %s, want %d", i, n, clock.Now().Format("15:04:05"), step.calls)
+		}
+	}
+
+	want := []string{"10:00:00", "10:01:00", "10:03:00"}
+	for i, at := range c.list() {
+		if got := at.Format("15:04:05"); got != want[i] {
+			t.Errorf("attempt %d at %s, want %s", i+1, got, want[i])
+		}
+	}
+	if got := ev.count(scheduler.EventExecution); got != 3 {
+		t.Errorf("%d execution events, want 3", got)
+	}
+	if got, failed := ev.count(scheduler.EventSucceeded), ev.count(scheduler.EventFailed); got != 1 || failed != 0 {
+		t.Errorf("%d succeeded and %d failed events, want 1 and 0", got, failed)
+	}
+}
+
+func TestPayloadRenderNotRetried(t *testing.T) {
+	ctx := testContext(t)
+	var c calls
+	var clock *scheduler.ManualClock
+	sched, clock, ev := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		c.add(clock.Now())
+		return respond(r, http.StatusOK), nil
+	}))
+	var mu sync.Mutex
+	var records []scheduler.Execution
+	sched.AddSubscriber(scheduler.SubscriberFunc(func(e scheduler.Event) {
+		if e.Type == scheduler.EventExecution {
+			mu.Lock()
+			records = append(records, *e.Execution)
+			mu.Unlock()
+		}
+	}))
+	// The variable only turns the payload into invalid base64 at
+	// execution time.
+	if err := sched.SaveVariable(scheduler.Variable{Name: "DATA", Value: "not base64!"}); err != nil {
+		t.Fatal(err)
+	}
+	startJob(t, ctx, sched, clock, "render", scheduler.Config{
+		StartTime:   "09:00:00",
+		Interval:    "1h",
+		APIURL:      "http://api.test/upload",
+		HTTPMethod:  "POST",
+		PayloadType: scheduler.PayloadBinary,
+		ContentType: "application/octet-stream",
+		Payload:     `{{var "DATA"}}`,
+		Retry:       &scheduler.RetryPolicy{MaxAttempts: 3, Backoff: "1m"},
+	})
+
+	if err := clock.AdvanceAndWait(ctx, time.Hour, 1); err != nil {
+		t.Fatal(err)
+	}
+	// The retries would have run by now.
+	if err := clock.AdvanceAndWait(ctx, 5*time.Minute, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(c.list()); n != 0 {
+		t.Errorf("%d requests sent, want none", n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(records) != 1 {
+		t.Fatalf("%d executions, want 1", len(records))
+	}
+	if rec := records[0]; rec.Success || rec.ErrorCode != scheduler.ErrorCodePayloadRender {
+		t.Errorf("execution success %v with error code %q, want a failure with %q", rec.Success, rec.ErrorCode, scheduler.ErrorCodePayloadRender)
+	}
+	if n := ev.count(scheduler.EventFailed); n != 1 {
+		t.Errorf("%d failed events, want 1", n)
+	}
+}
@@ -0,0 +1,63 @@
+// pkg/scheduler/kafkaexec/kafkaexec.go
+package kafkaexec
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// Executor publishes the message configured in Config.Publish to a Kafka
+// topic. A message acknowledged by the brokers is treated as success.
+type Executor struct {
+	writer *kafka.Writer
+}
+
+// New creates an Executor that publishes through the given brokers.
+func New(brokers []string, clientID string) *Executor {
+	transport := &kafka.Transport{ClientID: clientID}
+	return &Executor{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: false,
+			Transport:              transport,
+		},
+	}
+}
+
+// Execute publishes a single message.
+func (e *Executor) Execute(ctx context.Context, config scheduler.Config) (scheduler.Result, error) {
+	pc := config.Publish
+	if pc == nil || pc.Topic == "" {
+		return scheduler.Result{}, i18n.Errorf("Kafka 토픽이 설정되지 않았습니다")
+	}
+
+	msg := kafka.Message{
+		Topic: pc.Topic,
+		Value: []byte(pc.Message),
+	}
+	if pc.Key != "" {
+		msg.Key = []byte(pc.Key)
+	}
+	for key, value := range pc.Headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	if err := e.writer.WriteMessages(ctx, msg); err != nil {
+		return scheduler.Result{}, i18n.Errorf("Kafka 메시지 발행 오류: %w", err)
+	}
+	return scheduler.Result{
+		Success: true,
+		Output:  i18n.Sprintf(i18n.Default(), "토픽 %s 에 메시지를 발행했습니다", pc.Topic),
+	}, nil
+}
+
+// Close flushes pending messages and closes the broker connections.
+func (e *Executor) Close() error {
+	return e.writer.Close()
+}
@@ -0,0 +1,114 @@
+// pkg/scheduler/limits.go
+package scheduler
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// ErrLimitExceeded is returned by Start and Update when the scheduler would
+// exceed one of the Limits of the Scheduler.
+var ErrLimitExceeded = errors.New("scheduler: limit exceeded")
+
+// DefaultRole is the role of owners without an entry in Limits.Roles,
+// including schedulers without an owner.
+const DefaultRole = "default"
+
+// Limits are quotas that protect an instance shared by several users. They
+// are enforced when a scheduler is started or its config changes, not when
+// schedulers are restored from the store. Zero values mean unlimited.
+type Limits struct {
+	// MaxSchedulers bounds the schedulers registered on the instance,
+	// stopped ones included.
+	MaxSchedulers int
+	// MaxPerOwner bounds the schedulers of one Config.Owner. Schedulers
+	// without an owner share one quota.
+	MaxPerOwner int
+	// MaxPerGroup bounds the schedulers of one Config.Group. Schedulers
+	// without a group are not limited.
+	MaxPerGroup int
+	// MaxPerHost bounds the running schedulers whose apiURL targets the
+	// same host.
+	MaxPerHost int
+	// MinInterval is the shortest repeat interval allowed for the
+	// schedulers of each role.
+	MinInterval map[string]time.Duration
+	// Roles maps owners to their role. Other owners have DefaultRole.
+	Roles map[string]string
+}
+
+// WithLimits enforces limits on the schedulers started or updated through
+// the Scheduler.
+func WithLimits(limits Limits) Option {
+	return func(s *Scheduler) {
+		s.limits = limits
+	}
+}
+
+// role returns the role of owner.
+func (l Limits) role(owner string) string {
+	if role, ok := l.Roles[owner]; ok {
+		return role
+	}
+	return DefaultRole
+}
+
+// checkLimitsLocked returns an error wrapping ErrLimitExceeded when
+// registering config under id would exceed the limits of s. A scheduler
+// already registered under id is replaced, so it doesn't count. The
+// scheduler's mu must be held.
+func (s *Scheduler) checkLimitsLocked(id string, config Config) error {
+	l := s.limits
+	role := l.role(config.Owner)
+	if min := l.MinInterval[role]; min > 0 {
+		if interval, err := config.interval(); err == nil && interval < min {
+			return i18n.Errorf("%w: %s 역할의 최소 반복 간격은 %s입니다: %s", ErrLimitExceeded, role, min, interval)
+		}
+	}
+
+	host := targetHost(config)
+	total, owned, grouped, sameHost := 0, 0, 0, 0
+	for other, j := range s.jobs {
+		if other == id {
+			continue
+		}
+		total++
+		if j.config.Owner == config.Owner {
+			owned++
+		}
+		if config.Group != "" && j.config.Group == config.Group {
+			grouped++
+		}
+		if host != "" && active(j.state) && targetHost(j.config) == host {
+			sameHost++
+		}
+	}
+	switch {
+	case l.MaxSchedulers > 0 && total >= l.MaxSchedulers:
+		return i18n.Errorf("%w: 인스턴스의 스케줄러가 최대 %d개입니다", ErrLimitExceeded, l.MaxSchedulers)
+	case l.MaxPerOwner > 0 && owned >= l.MaxPerOwner:
+		return i18n.Errorf("%w: 사용자 %q의 스케줄러가 최대 %d개입니다", ErrLimitExceeded, config.Owner, l.MaxPerOwner)
+	case l.MaxPerGroup > 0 && config.Group != "" && grouped >= l.MaxPerGroup:
+		return i18n.Errorf("%w: 그룹 %q의 스케줄러가 최대 %d개입니다", ErrLimitExceeded, config.Group, l.MaxPerGroup)
+	case l.MaxPerHost > 0 && host != "" && sameHost >= l.MaxPerHost:
+		return i18n.Errorf("%w: 호스트 %s를 호출하는 실행 중인 스케줄러가 최대 %d개입니다", ErrLimitExceeded, host, l.MaxPerHost)
+	}
+	return nil
+}
+
+// targetHost returns the host the apiURL of c targets, or "" when it has
+// none.
+func targetHost(c Config) string {
+	if c.APIURL == "" {
+		return ""
+	}
+	u, err := url.Parse(c.APIURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
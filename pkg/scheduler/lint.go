@@ -0,0 +1,177 @@
+// pkg/scheduler/lint.go
+package scheduler
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Codes of the warnings returned by Lint.
+const (
+	// LintUnresolvedVariable is a {{var "NAME"}} reference to a variable
+	// that isn't defined, or an {{env "NAME"}} reference to an environment
+	// variable that isn't set.
+	LintUnresolvedVariable = "unresolved-variable"
+	// LintUnresolvedHost is a host name that doesn't resolve.
+	LintUnresolvedHost = "unresolved-host"
+	// LintShortInterval is a repeat interval under lintMinInterval.
+	LintShortInterval = "short-interval"
+	// LintStopWithoutCriteria is a scheduler that stops on its first
+	// success, where any 200 response is a success.
+	LintStopWithoutCriteria = "stop-without-criteria"
+)
+
+// lintMinInterval is the shortest repeat interval Lint doesn't warn about.
+const lintMinInterval = 5 * time.Second
+
+// lintDNSTimeout bounds the host name lookups of Lint.
+const lintDNSTimeout = 3 * time.Second
+
+// LintOptions selects the optional checks of Lint.
+type LintOptions struct {
+	// ResolveHosts looks up the host names the scheduler calls.
+	ResolveHosts bool
+}
+
+// LintWarning is a likely mistake in a config that is nonetheless valid.
+type LintWarning struct {
+	// Code is one of the Lint constants.
+	Code string `json:"code"`
+	// Field is the config field the warning is about, if it is about one.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	msg     error
+}
+
+// Localize returns w with its message in lang.
+func (w LintWarning) Localize(lang string) LintWarning {
+	w.Message = i18n.Localize(w.msg, lang)
+	return w
+}
+
+// warning returns a LintWarning with the message of i18n.Errorf(format, args...).
+func warning(code, field, format string, args ...any) LintWarning {
+	msg := i18n.Errorf(format, args...)
+	return LintWarning{Code: code, Field: field, Message: msg.Error(), msg: msg}
+}
+
+// Lint checks config as Start would, without starting it, and looks for
+// likely mistakes beyond that: references to variables that aren't
+// defined, short intervals, stopping on the first success without
+// success criteria and, with opts.ResolveHosts, host names that don't
+// resolve. It returns the warnings, and the error Start would fail with.
+func (s *Scheduler) Lint(ctx context.Context, config Config, opts LintOptions) ([]LintWarning, error) {
+	config, err := s.applyTemplate(config)
+	if err != nil {
+		return nil, err
+	}
+	validErr := s.validate(config)
+
+	warnings := []LintWarning{}
+	resolved, sub := s.substitute(config)
+	seen := make(map[string]bool)
+	for _, ref := range sub.unresolved {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		warnings = append(warnings, warning(LintUnresolvedVariable, "", "변수를 확인할 수 없습니다: %s", ref))
+	}
+	if d, err := config.interval(); err == nil && d < lintMinInterval {
+		warnings = append(warnings, warning(LintShortInterval, "interval", "반복 간격 %s은 %s보다 짧습니다. 대상 API에 부담이 될 수 있습니다", d, lintMinInterval))
+	}
+	if config.stopsOnSuccess() && !config.hasSuccessCriteria() {
+		warnings = append(warnings, warning(LintStopWithoutCriteria, "stopOnSuccess",
+			"성공 조건 없이 첫 200 응답에서 스케줄러가 중지됩니다. 계속 반복하려면 stopOnSuccess를 false로 설정하세요"))
+	}
+	if opts.ResolveHosts {
+		if p, err := s.applyProfile(resolved); err == nil {
+			resolved = p
+		}
+		for _, host := range lintHosts(resolved) {
+			lookupCtx, cancel := context.WithTimeout(ctx, lintDNSTimeout)
+			_, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+			cancel()
+			if err != nil {
+				warnings = append(warnings, warning(LintUnresolvedHost, "", "호스트 이름을 확인할 수 없습니다: %s: %v", host, err))
+			}
+		}
+	}
+	return warnings, validErr
+}
+
+// hasSuccessCriteria reports whether c decides success on more than a 200
+// response. Job types that aren't plain HTTP requests have their own.
+func (c Config) hasSuccessCriteria() bool {
+	switch c.jobType() {
+	case JobTypeHTTP, JobTypeGraphQL, JobTypeLoad, JobTypeFanout:
+		return false
+	case JobTypeChain:
+		return c.Chain != nil && stepsHaveCriteria(c.Chain.Steps)
+	case JobTypeWorkflow:
+		if c.Workflow == nil {
+			return false
+		}
+		steps := make([]ChainStep, len(c.Workflow.Steps))
+		for i, step := range c.Workflow.Steps {
+			steps[i] = step.ChainStep
+		}
+		return stepsHaveCriteria(steps)
+	}
+	return true
+}
+
+// stepsHaveCriteria reports whether any of steps sets ExpectStatus or
+// SuccessIf.
+func stepsHaveCriteria(steps []ChainStep) bool {
+	for _, step := range steps {
+		if len(step.ExpectStatus) > 0 || step.SuccessIf != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// lintHosts returns the host names, not IP addresses, that c calls, sorted.
+func lintHosts(c Config) []string {
+	urls := []string{c.APIURL}
+	if c.Session != nil && c.Session.Login != nil {
+		urls = append(urls, c.Session.Login.APIURL)
+	}
+	if c.Chain != nil {
+		for _, step := range c.Chain.Steps {
+			urls = append(urls, step.APIURL)
+		}
+	}
+	if c.Workflow != nil {
+		for _, step := range c.Workflow.Steps {
+			urls = append(urls, step.APIURL)
+		}
+	}
+	if c.Fanout != nil {
+		urls = append(urls, c.Fanout.Targets...)
+	}
+
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" || strings.Contains(u.Hostname(), "{{") {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if net.ParseIP(host) != nil || seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -0,0 +1,200 @@
+// pkg/scheduler/load.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeLoad sends a burst of HTTP requests to Config.APIURL on every fire
+// time, as a lightweight periodic load or smoke test.
+const JobTypeLoad = "load"
+
+// Limits of a LoadConfig.
+const (
+	maxLoadRequests    = 100000
+	maxLoadConcurrency = 1000
+)
+
+// LoadConfig configures a JobTypeLoad job. The requests themselves are
+// built from APIURL, HTTPMethod, Payload and Headers as for JobTypeHTTP.
+type LoadConfig struct {
+	// Requests is the number of requests sent per execution.
+	Requests int `json:"requests"`
+	// Concurrency is how many requests are in flight at once. Zero means 1.
+	Concurrency int `json:"concurrency,omitempty"`
+	// MinSuccessRate is the fraction of requests (0 to 1) that must
+	// succeed for the execution to succeed. Zero means all of them.
+	MinSuccessRate float64 `json:"minSuccessRate,omitempty"`
+}
+
+// validate checks the load settings. A nil LoadConfig is valid.
+func (c *LoadConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Requests < 1 || c.Requests > maxLoadRequests {
+		return i18n.Errorf("%w: load.requests는 1에서 %d 사이여야 합니다", ErrInvalidConfig, maxLoadRequests)
+	}
+	if c.Concurrency < 0 || c.Concurrency > maxLoadConcurrency {
+		return i18n.Errorf("%w: load.concurrency는 0에서 %d 사이여야 합니다", ErrInvalidConfig, maxLoadConcurrency)
+	}
+	if c.MinSuccessRate < 0 || c.MinSuccessRate > 1 {
+		return i18n.Errorf("%w: load.minSuccessRate는 0에서 1 사이여야 합니다", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// concurrency returns the number of requests in flight at once.
+func (c *LoadConfig) concurrency() int {
+	if c.Concurrency <= 0 {
+		return 1
+	}
+	return c.Concurrency
+}
+
+// LoadReport aggregates the requests of a JobTypeLoad execution. It is the
+// JSON output of the execution.
+type LoadReport struct {
+	Requests    int     `json:"requests"`
+	Concurrency int     `json:"concurrency"`
+	Successes   int     `json:"successes"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"successRate"`
+	// Throughput is in requests per second.
+	Throughput float64 `json:"throughput"`
+
+	// Latencies are in milliseconds.
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	P50LatencyMs float64 `json:"p50LatencyMs"`
+	P90LatencyMs float64 `json:"p90LatencyMs"`
+	P99LatencyMs float64 `json:"p99LatencyMs"`
+	MaxLatencyMs float64 `json:"maxLatencyMs"`
+
+	// StatusCodes counts the responses by status code.
+	StatusCodes map[int]int `json:"statusCodes,omitempty"`
+	// Errors counts the requests that got no response, by error message.
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+// LoadExecutor sends the requests of a JobTypeLoad job through Executor,
+// which is the HTTP executor when it's registered by New, and reports a
+// LoadReport.
+type LoadExecutor struct {
+	Executor Executor
+}
+
+// Execute sends the configured number of requests with the configured
+// concurrency. It stops early when ctx is done.
+func (e *LoadExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	lc := config.Load
+	if lc == nil || lc.Requests <= 0 {
+		return Result{}, i18n.Errorf("부하 테스트 설정(load)이 없습니다")
+	}
+	workers := lc.concurrency()
+	if workers > lc.Requests {
+		workers = lc.Requests
+	}
+
+	type outcome struct {
+		latency time.Duration
+		res     Result
+		err     error
+	}
+	outcomes := make([]outcome, 0, lc.Requests)
+	var mu sync.Mutex
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	var p panics
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				// A request that panics is left out; the worker goes on
+				// taking requests so that sending them doesn't block.
+				func() {
+					defer p.catch()
+					started := time.Now()
+					res, err := e.Executor.Execute(ctx, config)
+					o := outcome{latency: time.Since(started), res: res, err: err}
+					mu.Lock()
+					outcomes = append(outcomes, o)
+					mu.Unlock()
+				}()
+			}
+		}()
+	}
+
+	started := time.Now()
+send:
+	for i := 0; i < lc.Requests; i++ {
+		select {
+		case jobs <- struct{}{}:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(started)
+	if p.err != nil {
+		return Result{}, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	report := LoadReport{Requests: len(outcomes), Concurrency: workers}
+	latencies := make([]time.Duration, 0, len(outcomes))
+	var total time.Duration
+	for _, o := range outcomes {
+		latencies = append(latencies, o.latency)
+		total += o.latency
+		if o.err != nil {
+			if report.Errors == nil {
+				report.Errors = make(map[string]int)
+			}
+			report.Errors[o.err.Error()]++
+		} else if o.res.StatusCode != 0 {
+			if report.StatusCodes == nil {
+				report.StatusCodes = make(map[int]int)
+			}
+			report.StatusCodes[o.res.StatusCode]++
+		}
+		if o.err == nil && o.res.Success {
+			report.Successes++
+		}
+	}
+	report.Failures = report.Requests - report.Successes
+	if report.Requests > 0 {
+		report.SuccessRate = float64(report.Successes) / float64(report.Requests)
+		report.AvgLatencyMs = ms(total / time.Duration(report.Requests))
+		sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+		report.P50LatencyMs = ms(percentile(latencies, 0.50))
+		report.P90LatencyMs = ms(percentile(latencies, 0.90))
+		report.P99LatencyMs = ms(percentile(latencies, 0.99))
+		report.MaxLatencyMs = ms(latencies[len(latencies)-1])
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(report.Requests) / elapsed.Seconds()
+	}
+
+	out, err := json.Marshal(report)
+	if err != nil {
+		return Result{}, err
+	}
+	minRate := lc.MinSuccessRate
+	if minRate == 0 {
+		minRate = 1
+	}
+	return Result{
+		Success: report.Requests > 0 && report.SuccessRate >= minRate,
+		Output:  string(out),
+	}, nil
+}
@@ -0,0 +1,90 @@
+// pkg/scheduler/loglevel.go
+package scheduler
+
+import (
+	"strconv"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Log levels for Config.LogLevel, from the most to the least verbose.
+const (
+	// LogDebug adds connection diagnostics of successful executions.
+	LogDebug = "debug"
+	// LogInfo, the default, logs the progress of every execution.
+	LogInfo = "info"
+	// LogWarn only logs skipped, missed, retried and queued executions,
+	// besides errors and the scheduler starting and stopping.
+	LogWarn = "warn"
+	// LogError only logs failures, besides the scheduler starting and
+	// stopping.
+	LogError = "error"
+)
+
+// logLevels orders the log levels.
+var logLevels = map[string]int{LogDebug: 0, LogInfo: 1, LogWarn: 2, LogError: 3}
+
+// validateLogLevel checks Config.LogLevel.
+func validateLogLevel(level string) error {
+	if _, ok := logLevels[level]; !ok && level != "" {
+		return i18n.Errorf("%w: logLevel은 debug, info, warn, error 중 하나여야 합니다: %q", ErrInvalidConfig, level)
+	}
+	return nil
+}
+
+// logs reports whether messages of level are logged for j, by its own
+// log level or else the scheduler's.
+func (j *job) logs(level string) bool {
+	min, ok := logLevels[j.config.LogLevel]
+	if !ok {
+		min, ok = logLevels[j.sched.logLevel()]
+	}
+	if !ok {
+		min = logLevels[LogInfo]
+	}
+	return logLevels[level] >= min
+}
+
+// failureStreak is a run of executions failing with the same error.
+type failureStreak struct {
+	err   string
+	count int
+}
+
+// failureRepeat adds rec, if it failed, to the failure streak of j and
+// returns how often its failure occurred in a row. Successful executions
+// end the streak and return 0.
+func (j *job) failureRepeat(rec *Execution) int {
+	if rec.Success {
+		j.streak = failureStreak{}
+		return 0
+	}
+	err := rec.Error
+	if err == "" {
+		err = strconv.Itoa(rec.StatusCode)
+	}
+	if err != j.streak.err {
+		j.streak = failureStreak{err: err}
+	}
+	j.streak.count++
+	return j.streak.count
+}
+
+// logRepeat is logAt for a message repeated repeat times in a row.
+func (j *job) logRepeat(level string, repeat int, format string, args ...any) {
+	if !j.logs(level) {
+		return
+	}
+	ev := j.sched.logEvent(j.id, format, args...)
+	ev.ExecutionID = j.execID
+	ev.Repeat = repeat
+	j.sched.bus.publish(ev)
+}
+
+// logAt emits an event about this job if its log level lets messages of
+// level through.
+func (j *job) logAt(level, format string, args ...any) {
+	if j.logs(level) {
+		j.logf(format, args...)
+	}
+}
@@ -0,0 +1,64 @@
+// pkg/scheduler/maintenance.go
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Maintenance is the maintenance mode of a Scheduler. While it is enabled
+// every fire time is skipped, as in a blackout window for all schedulers,
+// and SLAs aren't checked. The schedulers keep their state and execute
+// again from their first fire time after it is lifted.
+type Maintenance struct {
+	Enabled bool `json:"enabled"`
+	// Reason is shown in the log and to API clients.
+	Reason string `json:"reason,omitempty"`
+	// Since is when maintenance mode was enabled.
+	Since time.Time `json:"since,omitempty"`
+	// By is who enabled it, when known.
+	By string `json:"by,omitempty"`
+}
+
+// maintenance holds the maintenance mode of a Scheduler.
+type maintenance struct {
+	mu    sync.RWMutex
+	state Maintenance
+}
+
+// SetMaintenance enables or lifts maintenance mode, logs the change and
+// returns the new mode. Executions already running are not cancelled.
+// Enabling it again replaces the reason but keeps Since.
+func (s *Scheduler) SetMaintenance(enabled bool, reason, by string) Maintenance {
+	s.maint.mu.Lock()
+	prev := s.maint.state
+	next := Maintenance{}
+	if enabled {
+		next = Maintenance{Enabled: true, Reason: reason, Since: s.clock.Now(), By: by}
+		if prev.Enabled {
+			next.Since = prev.Since
+		}
+	}
+	s.maint.state = next
+	s.maint.mu.Unlock()
+
+	switch {
+	case enabled && !prev.Enabled:
+		s.emit("", "점검 모드를 시작합니다. 예정된 실행을 건너뜁니다: %s", reason)
+	case !enabled && prev.Enabled:
+		s.emit("", "점검 모드를 해제합니다. 다음 예정 시각부터 실행합니다.")
+	}
+	return next
+}
+
+// Maintenance returns the maintenance mode of s.
+func (s *Scheduler) Maintenance() Maintenance {
+	s.maint.mu.RLock()
+	defer s.maint.mu.RUnlock()
+	return s.maint.state
+}
+
+// inMaintenance reports whether maintenance mode is enabled.
+func (s *Scheduler) inMaintenance() bool {
+	return s.Maintenance().Enabled
+}
@@ -0,0 +1,132 @@
+// pkg/scheduler/metrics.go
+package scheduler
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// maxMetrics bounds the metrics extracted by one scheduler.
+const maxMetrics = 20
+
+// metricName matches a valid Prometheus metric name.
+var metricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// MetricExtraction reads a number from each successful JSON response and
+// exports it as a gauge, so that a scheduler can poll an API for a value
+// such as a queue depth and expose it to Prometheus.
+type MetricExtraction struct {
+	// Name is the name of the gauge, such as "orders_queue_depth". It is
+	// exported with the scheduler's ID as the id label, so schedulers may
+	// share a name.
+	Name string `json:"name"`
+	// Path is the JSON path of the value, such as "$.queues[0].depth".
+	// Numbers, numeric strings and booleans (1 or 0) are accepted.
+	Path string `json:"path"`
+	// Help is the help text of the gauge.
+	Help string `json:"help,omitempty"`
+}
+
+// MetricSample is the latest value of a MetricExtraction.
+type MetricSample struct {
+	Name  string  `json:"name"`
+	Help  string  `json:"help,omitempty"`
+	Value float64 `json:"value"`
+	// UpdatedAt is when the value was read.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// validateMetrics checks the metric extractions of a config.
+func validateMetrics(metrics []MetricExtraction) error {
+	if len(metrics) > maxMetrics {
+		return i18n.Errorf("%w: metrics는 %d개 이하여야 합니다", ErrInvalidConfig, maxMetrics)
+	}
+	seen := map[string]bool{}
+	for _, m := range metrics {
+		if !metricName.MatchString(m.Name) || strings.HasPrefix(m.Name, "api_scheduler_") {
+			return i18n.Errorf("%w: metrics의 이름이 올바르지 않습니다: %q", ErrInvalidConfig, m.Name)
+		}
+		if seen[m.Name] {
+			return i18n.Errorf("%w: metrics의 이름이 중복됩니다: %s", ErrInvalidConfig, m.Name)
+		}
+		seen[m.Name] = true
+		if _, err := splitJSONPath(m.Path); err != nil {
+			return i18n.Errorf("%w: metrics.%s 경로 오류: %v", ErrInvalidConfig, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// extractMetrics reads the metrics of j from the successful response
+// output. A value that is missing or not a number drops its gauge, so a
+// stale value isn't exported as current.
+func (j *job) extractMetrics(output string) {
+	now := j.sched.clock.Now()
+	samples := make(map[string]MetricSample, len(j.config.Metrics))
+	var body any
+	if err := json.Unmarshal([]byte(output), &body); err != nil {
+		j.logAt(LogWarn, "응답이 JSON이 아니라 메트릭을 추출하지 못했습니다.")
+		j.setMetrics(nil)
+		return
+	}
+	for _, m := range j.config.Metrics {
+		steps, _ := splitJSONPath(m.Path)
+		v, ok := lookupJSONPath(body, steps)
+		if !ok {
+			j.logAt(LogWarn, "메트릭 %s의 경로 %s에 값이 없습니다.", m.Name, m.Path)
+			continue
+		}
+		value, ok := metricValue(v)
+		if !ok {
+			j.logAt(LogWarn, "메트릭 %s의 값이 숫자가 아닙니다: %s", m.Name, jsonValue(v))
+			continue
+		}
+		samples[m.Name] = MetricSample{Name: m.Name, Help: m.Help, Value: value, UpdatedAt: now}
+	}
+
+	j.setMetrics(samples)
+}
+
+// setMetrics replaces the metric values of j.
+func (j *job) setMetrics(samples map[string]MetricSample) {
+	j.sched.mu.Lock()
+	j.metrics = samples
+	j.sched.mu.Unlock()
+}
+
+// metricValue converts a JSON value to the value of a gauge.
+func metricValue(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// metricsLocked returns the latest metric values of j, ordered by name.
+// The scheduler's mu must be held.
+func (j *job) metricsLocked() []MetricSample {
+	if len(j.metrics) == 0 {
+		return nil
+	}
+	list := make([]MetricSample, 0, len(j.metrics))
+	for _, m := range j.metrics {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list
+}
@@ -0,0 +1,258 @@
+// pkg/scheduler/monitor.go
+package scheduler
+
+import (
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeMonitor checks Config.APIURL like JobTypeHTTP, but keeps running
+// after successful checks and tracks whether the target is up or down.
+const JobTypeMonitor = "monitor"
+
+// Monitor states. A monitor is in neither state before its first check.
+const (
+	MonitorUp   = "up"
+	MonitorDown = "down"
+)
+
+// monitorHistory bounds the executions read to restore the state of a
+// monitor when it starts.
+const monitorHistory = 1000
+
+// uptimeWindows are the windows reported by Uptime, by label.
+var uptimeWindows = []struct {
+	label  string
+	length time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// MonitorConfig configures a JobTypeMonitor job. A nil MonitorConfig uses
+// the defaults.
+type MonitorConfig struct {
+	// FailureThreshold is how many consecutive failed checks mark an up
+	// target down. Zero means 1.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// RecoveryThreshold is how many consecutive successful checks mark a
+	// down target up. Zero means 1.
+	RecoveryThreshold int `json:"recoveryThreshold,omitempty"`
+}
+
+// validate checks the monitor settings. A nil MonitorConfig is valid.
+func (c *MonitorConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.FailureThreshold < 0 || c.RecoveryThreshold < 0 {
+		return i18n.Errorf("%w: monitor 임계값은 0 이상이어야 합니다", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// thresholds returns the failure and recovery thresholds.
+func (c *MonitorConfig) thresholds() (failure, recovery int) {
+	failure, recovery = 1, 1
+	if c != nil && c.FailureThreshold > 0 {
+		failure = c.FailureThreshold
+	}
+	if c != nil && c.RecoveryThreshold > 0 {
+		recovery = c.RecoveryThreshold
+	}
+	return failure, recovery
+}
+
+// MonitorStatus is the state of a JobTypeMonitor scheduler.
+type MonitorStatus struct {
+	// State is MonitorUp, MonitorDown or empty before the first check.
+	State string `json:"state,omitempty"`
+	// Since is when the target entered State.
+	Since *time.Time `json:"since,omitempty"`
+	// LastCheck is when the latest check started.
+	LastCheck *time.Time `json:"lastCheck,omitempty"`
+}
+
+// monitorState tracks the state of a monitor. It is protected by the
+// scheduler's mu.
+type monitorState struct {
+	status MonitorStatus
+	// streak counts the consecutive checks that disagree with the state.
+	streak int
+}
+
+// MonitorEvent is sent to the Notify webhook of a monitor when its state
+// changes.
+type MonitorEvent struct {
+	SchedulerID string `json:"schedulerId"`
+	Name        string `json:"name,omitempty"`
+	// State is the new state and PreviousState the old one, which is
+	// empty for the first check.
+	State         string    `json:"state"`
+	PreviousState string    `json:"previousState,omitempty"`
+	Since         time.Time `json:"since"`
+	// Execution is the check that changed the state.
+	Execution Execution `json:"execution"`
+}
+
+// UptimeWindow is the share of successful checks of a monitor in a window
+// before now.
+type UptimeWindow struct {
+	// Window is "24h", "7d" or "30d".
+	Window string `json:"window"`
+	Checks int    `json:"checks"`
+	Up     int    `json:"up"`
+	// UptimePercent is 0 to 100, or 100 without any checks.
+	UptimePercent float64 `json:"uptimePercent"`
+}
+
+// Uptime is the state and uptime of a monitor.
+type Uptime struct {
+	SchedulerID string         `json:"schedulerId"`
+	Status      MonitorStatus  `json:"status"`
+	Windows     []UptimeWindow `json:"windows"`
+}
+
+// Uptime returns the state of the monitor registered under id and its
+// uptime over the last day, week and 30 days. The uptime needs a store
+// that keeps execution history.
+func (s *Scheduler) Uptime(id string) (Uptime, error) {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	var status *MonitorStatus
+	if ok {
+		status = j.monitorStatus()
+	}
+	s.mu.Unlock()
+	if !ok {
+		return Uptime{}, ErrNotFound
+	}
+	if status == nil {
+		return Uptime{}, i18n.Errorf("%w: 모니터(monitor) 유형의 스케줄러가 아닙니다", ErrInvalidConfig)
+	}
+
+	es, ok := s.store.(ExecutionStore)
+	if !ok {
+		return Uptime{}, ErrNoHistory
+	}
+	history, err := es.ListExecutions(id, 0)
+	if err != nil {
+		return Uptime{}, err
+	}
+	now := s.clock.Now()
+	up := Uptime{SchedulerID: id, Status: *status}
+	for _, window := range uptimeWindows {
+		w := UptimeWindow{Window: window.label, UptimePercent: 100}
+		from := now.Add(-window.length)
+		for _, e := range history {
+			if e.StartedAt.Before(from) {
+				continue
+			}
+			w.Checks++
+			if e.Success {
+				w.Up++
+			}
+		}
+		if w.Checks > 0 {
+			w.UptimePercent = 100 * float64(w.Up) / float64(w.Checks)
+		}
+		up.Windows = append(up.Windows, w)
+	}
+	return up, nil
+}
+
+// monitorStatus returns the state of j for Status, or nil for jobs that
+// aren't monitors. The scheduler's mu must be held.
+func (j *job) monitorStatus() *MonitorStatus {
+	if j.config.jobType() != JobTypeMonitor {
+		return nil
+	}
+	st := j.monitor.status
+	return &st
+}
+
+// loadMonitorState restores the state of a monitor from the execution
+// history, so that a restart doesn't count as a state change.
+func (j *job) loadMonitorState() {
+	es, ok := j.sched.store.(ExecutionStore)
+	if !ok {
+		return
+	}
+	history, err := es.ListExecutions(j.id, monitorHistory)
+	if err != nil || len(history) == 0 {
+		return
+	}
+	latest := history[0]
+	since := latest.StartedAt
+	for _, e := range history[1:] {
+		if e.Success != latest.Success {
+			break
+		}
+		since = e.StartedAt
+	}
+	st := MonitorStatus{State: MonitorDown, Since: &since, LastCheck: &latest.StartedAt}
+	if latest.Success {
+		st.State = MonitorUp
+	}
+	j.sched.mu.Lock()
+	j.monitor.status = st
+	j.sched.mu.Unlock()
+}
+
+// observe updates the state of a monitor with a finished check, and logs
+// and notifies a state change.
+func (j *job) observe(rec Execution) {
+	failure, recovery := j.config.Monitor.thresholds()
+	state := MonitorDown
+	if rec.Success {
+		state = MonitorUp
+	}
+
+	j.sched.mu.Lock()
+	m := &j.monitor
+	at := rec.StartedAt
+	m.status.LastCheck = &at
+	prev := m.status.State
+	changed := false
+	switch {
+	case prev == state:
+		m.streak = 0
+	case prev == "":
+		changed = true
+	default:
+		m.streak++
+		threshold := failure
+		if state == MonitorUp {
+			threshold = recovery
+		}
+		changed = m.streak >= threshold
+	}
+	if changed {
+		m.status.State = state
+		m.status.Since = &at
+		m.streak = 0
+	}
+	j.sched.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if state == MonitorUp {
+		j.logf("모니터 상태 변경: 정상(up)")
+	} else {
+		j.logf("모니터 상태 변경: 장애(down)")
+	}
+	if prev == "" && state == MonitorUp {
+		return
+	}
+	j.sched.notifyEvent(j.sched.notifyConfig(j.config), j.notification(notifyMonitor, MonitorEvent{
+		SchedulerID:   rec.SchedulerID,
+		Name:          j.config.Name,
+		State:         state,
+		PreviousState: prev,
+		Since:         at,
+		Execution:     rec,
+	}))
+}
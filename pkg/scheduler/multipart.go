@@ -0,0 +1,129 @@
+// pkg/scheduler/multipart.go
+package scheduler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// FilePart is a file sent in a multipart/form-data request. The file is
+// read from Path on every execution, or given inline as base64 Content.
+type FilePart struct {
+	// Field is the form field name of the part.
+	Field string `json:"field"`
+	// Path must be inside the directory set by WithFileRoot, which
+	// relative paths are resolved against.
+	Path string `json:"path,omitempty"`
+	// Content is the base64-encoded file content, used instead of Path.
+	Content string `json:"content,omitempty"`
+	// Filename is sent as the file name. Empty means the base name of
+	// Path, or Field for inline content.
+	Filename string `json:"filename,omitempty"`
+	// ContentType is the type of the part. Empty means
+	// "application/octet-stream".
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// validateFiles checks the file parts of a multipart request.
+func validateFiles(files []FilePart) error {
+	for i, f := range files {
+		if f.Field == "" {
+			return i18n.Errorf("%w: files[%d]에 field가 없습니다", ErrInvalidConfig, i)
+		}
+		if (f.Path == "") == (f.Content == "") {
+			return i18n.Errorf("%w: files[%d]에는 path와 content 중 하나만 지정해야 합니다", ErrInvalidConfig, i)
+		}
+		if f.Content != "" {
+			if _, err := base64.StdEncoding.DecodeString(f.Content); err != nil {
+				return i18n.Errorf("%w: files[%d].content는 base64여야 합니다: %v", ErrInvalidConfig, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// multipartBody encodes the form fields and files as a multipart/form-data
+// body and returns it with its content type, reading files under root.
+func multipartBody(fields url.Values, files []FilePart, root string) (*bytes.Buffer, string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range fields[name] {
+			if err := w.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	for _, f := range files {
+		content, filename, err := f.read(root)
+		if err != nil {
+			return nil, "", err
+		}
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="`+escapeQuotes(f.Field)+`"; filename="`+escapeQuotes(filename)+`"`)
+		h.Set("Content-Type", contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+// read returns the content and file name of the part, reading Path under
+// root.
+func (f FilePart) read(root string) ([]byte, string, error) {
+	filename := f.Filename
+	if f.Path != "" {
+		if filename == "" {
+			filename = filepath.Base(f.Path)
+		}
+		path, err := resolveFile(root, f.Path)
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", i18n.Errorf("업로드할 파일을 읽을 수 없습니다: %w", err)
+		}
+		return content, filename, nil
+	}
+	if filename == "" {
+		filename = f.Field
+	}
+	content, err := base64.StdEncoding.DecodeString(f.Content)
+	if err != nil {
+		return nil, "", i18n.Errorf("files.content 디코딩 오류: %w", err)
+	}
+	return content, filename, nil
+}
+
+// escapeQuotes escapes a Content-Disposition parameter value, as
+// mime/multipart does for CreateFormFile.
+var escapeQuotes = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
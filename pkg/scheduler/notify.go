@@ -0,0 +1,382 @@
+// pkg/scheduler/notify.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// notifyTimeout bounds the delivery of a notification.
+const notifyTimeout = 10 * time.Second
+
+// notifyMonitor is the Notification.Event of a monitor state change.
+const notifyMonitor = "monitor"
+
+// The NotifyChannel types of the built-in notifiers.
+const (
+	ChannelWebhook   = "webhook"
+	ChannelSlack     = "slack"
+	ChannelPagerDuty = "pagerduty"
+	ChannelOpsgenie  = "opsgenie"
+	ChannelTelegram  = "telegram"
+	ChannelDiscord   = "discord"
+)
+
+// NotifyConfig sends the Execution record of finished executions, and the
+// other events of a scheduler, to a webhook as JSON and to the channels.
+type NotifyConfig struct {
+	// WebhookURL receives the events as JSON. It may be empty when
+	// Channels is set.
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// Channels are further destinations, each with its own message
+	// template.
+	Channels []NotifyChannel `json:"channels,omitempty"`
+	// OnSuccess and OnFailure select which executions are sent. With both
+	// false only failures are sent.
+	OnSuccess bool `json:"onSuccess,omitempty"`
+	OnFailure bool `json:"onFailure,omitempty"`
+}
+
+// NotifyChannel is a destination of notifications, delivered by the
+// Notifier registered for its Type.
+type NotifyChannel struct {
+	// Type is ChannelWebhook (default), ChannelSlack or the type of a
+	// Notifier registered with RegisterNotifier.
+	Type string `json:"type,omitempty"`
+	// URL may be empty for PagerDuty and Opsgenie, which default to their
+	// public APIs, and is unused for Telegram, whose bot is set up on the
+	// server.
+	URL string `json:"url,omitempty"`
+	// ChatID is the chat, group or channel a Telegram channel posts to,
+	// e.g. "-1001234567890" or "@ops_alerts".
+	ChatID string `json:"chatId,omitempty"`
+	// Template is a Go text/template executed with the NotificationData
+	// of each notification, which shapes the message. Empty means the
+	// channel's default: the event as JSON for a webhook, a one-line
+	// summary for Slack and the incident title for PagerDuty and
+	// Opsgenie.
+	Template string `json:"template,omitempty"`
+	// Headers are added to the requests, e.g. for credentials.
+	Headers map[string]string `json:"headers,omitempty"`
+	// RoutingKey is the PagerDuty integration key or the Opsgenie API key
+	// of incident channels. GroupRoutingKeys overrides it for the
+	// schedulers of a Config.Group, so a server-wide notify can route
+	// each group to its own service. Schedulers without a key open no
+	// incidents.
+	RoutingKey       string            `json:"routingKey,omitempty"`
+	GroupRoutingKeys map[string]string `json:"groupRoutingKeys,omitempty"`
+	// FailureThreshold is how many executions of a scheduler must fail in
+	// a row before an incident channel opens an incident. Zero means 1.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// validate checks the settings. Nil settings are valid.
+func (n *NotifyConfig) validate() error {
+	if n == nil {
+		return nil
+	}
+	if n.WebhookURL == "" && len(n.Channels) == 0 {
+		return i18n.Errorf("%w: notify.webhookURL 또는 notify.channels가 필요합니다", ErrInvalidConfig)
+	}
+	for i, ch := range n.Channels {
+		if ch.channelType() == ChannelTelegram {
+			if ch.ChatID == "" {
+				return i18n.Errorf("%w: notify.channels[%d]에 chatId가 필요합니다", ErrInvalidConfig, i)
+			}
+		} else if u, err := url.Parse(ch.url()); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return i18n.Errorf("%w: notify.channels[%d].url은 http 또는 https URL이어야 합니다: %q", ErrInvalidConfig, i, ch.URL)
+		}
+		if ch.incident() {
+			if ch.RoutingKey == "" && len(ch.GroupRoutingKeys) == 0 {
+				return i18n.Errorf("%w: notify.channels[%d]에 routingKey 또는 groupRoutingKeys가 필요합니다", ErrInvalidConfig, i)
+			}
+			if ch.FailureThreshold < 0 {
+				return i18n.Errorf("%w: notify.channels[%d].failureThreshold는 0 이상이어야 합니다", ErrInvalidConfig, i)
+			}
+		}
+		if ch.Template != "" {
+			if _, err := parseNotifyTemplate(ch.Template); err != nil {
+				return i18n.Errorf("%w: notify.channels[%d].template 오류: %v", ErrInvalidConfig, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// channels returns the channels of n, with WebhookURL first.
+func (n *NotifyConfig) channels() []NotifyChannel {
+	if n.WebhookURL == "" {
+		return n.Channels
+	}
+	return append([]NotifyChannel{{Type: ChannelWebhook, URL: n.WebhookURL}}, n.Channels...)
+}
+
+// channelType returns the type of ch.
+func (ch NotifyChannel) channelType() string {
+	if ch.Type == "" {
+		return ChannelWebhook
+	}
+	return ch.Type
+}
+
+// url returns the URL of ch, or the default URL of its type.
+func (ch NotifyChannel) url() string {
+	if ch.URL == "" {
+		return defaultIncidentURLs[ch.channelType()]
+	}
+	return ch.URL
+}
+
+// wants reports whether an execution with the given outcome is sent.
+func (n *NotifyConfig) wants(success bool) bool {
+	if n == nil {
+		return false
+	}
+	if success {
+		return n.OnSuccess
+	}
+	return n.OnFailure || !n.OnSuccess
+}
+
+// Notification is an event about a scheduler to deliver to a channel.
+type Notification struct {
+	// Event is the type of the event: EventSucceeded or EventFailed for
+	// executions, EventCrashed, EventRestarted, EventStalled,
+	// EventUnstalled, EventSLAViolated, EventApprovalRequested, "monitor"
+	// for a monitor state change, or "report" for a summary report.
+	Event       string
+	SchedulerID string
+	Name        string
+	Group       string
+	Time        time.Time
+	// FailureStreak is set for executions: the executions failed in a row
+	// up to a failed one, or the failures a successful one ended.
+	FailureStreak int
+	// Payload is the event as webhooks receive it: an Execution,
+	// CrashEvent, RestartEvent, StallEvent, SLAEvent, ApprovalEvent,
+	// MonitorEvent or Report.
+	Payload any
+}
+
+// NotificationData is the data of a NotifyChannel.Template.
+type NotificationData struct {
+	Event         string
+	SchedulerID   string
+	Name          string
+	Group         string
+	Time          time.Time
+	FailureStreak int
+	// Payload is the JSON form of Notification.Payload, such as
+	// {{.Payload.statusCode}} or {{.Payload.error}} for an execution.
+	Payload map[string]any
+}
+
+// Render executes tmpl, or def when tmpl is empty, with the
+// NotificationData of n.
+func (n Notification) Render(tmpl, def string) (string, error) {
+	if tmpl == "" {
+		tmpl = def
+	}
+	t, err := parseNotifyTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+	data := NotificationData{
+		Event:         n.Event,
+		SchedulerID:   n.SchedulerID,
+		Name:          n.Name,
+		Group:         n.Group,
+		Time:          n.Time,
+		FailureStreak: n.FailureStreak,
+	}
+	if b, err := json.Marshal(n.Payload); err == nil {
+		json.Unmarshal(b, &data.Payload)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// parseNotifyTemplate parses a NotifyChannel.Template.
+func parseNotifyTemplate(tmpl string) (*template.Template, error) {
+	return template.New("notify").Funcs(templateFuncs).Option("missingkey=zero").Parse(tmpl)
+}
+
+// Notifier delivers notifications to the channels of one type. Register
+// one with RegisterNotifier to add a type of channel.
+type Notifier interface {
+	Notify(ctx context.Context, ch NotifyChannel, n Notification) error
+}
+
+// RegisterNotifier registers the notifier of the channels of the given
+// type, replacing any notifier previously registered for it.
+func (s *Scheduler) RegisterNotifier(channelType string, n Notifier) {
+	s.execMu.Lock()
+	defer s.execMu.Unlock()
+	s.notifiers[channelType] = n
+}
+
+// notifier returns the notifier registered for the given channel type.
+func (s *Scheduler) notifier(channelType string) (Notifier, bool) {
+	s.execMu.RLock()
+	defer s.execMu.RUnlock()
+	n, ok := s.notifiers[channelType]
+	return n, ok
+}
+
+// checkNotifiers checks that a notifier is registered for every channel
+// of n.
+func (s *Scheduler) checkNotifiers(n *NotifyConfig) error {
+	if n == nil {
+		return nil
+	}
+	for _, ch := range n.Channels {
+		if _, ok := s.notifier(ch.channelType()); !ok {
+			return i18n.Errorf("%w: 알 수 없는 알림 채널 유형입니다: %q", ErrInvalidConfig, ch.Type)
+		}
+	}
+	return nil
+}
+
+// notification returns a Notification of the given event about j.
+func (j *job) notification(event string, v any) Notification {
+	return Notification{
+		Event:       event,
+		SchedulerID: j.id,
+		Name:        j.config.Name,
+		Group:       j.config.Group,
+		Time:        j.sched.clock.Now(),
+		Payload:     v,
+	}
+}
+
+// notify sends msg, the final execution of a fire time, to the channels
+// configured in n that want it. Incident channels get every execution, to
+// resolve their incidents on success.
+func (s *Scheduler) notify(n *NotifyConfig, msg Notification, success bool) {
+	if n == nil {
+		return
+	}
+	wants := n.wants(success)
+	for _, ch := range n.channels() {
+		if wants || ch.incident() {
+			s.deliver(ch, msg)
+		}
+	}
+}
+
+// notifyEvent sends msg to the channels configured in n regardless of
+// OnSuccess and OnFailure.
+func (s *Scheduler) notifyEvent(n *NotifyConfig, msg Notification) {
+	if n == nil {
+		return
+	}
+	for _, ch := range n.channels() {
+		s.deliver(ch, msg)
+	}
+}
+
+// deliver sends msg to ch through the notifier of its type. Failures are
+// logged and not retried.
+func (s *Scheduler) deliver(ch NotifyChannel, msg Notification) {
+	notifier, ok := s.notifier(ch.channelType())
+	if !ok {
+		s.emit(msg.SchedulerID, "알림 전송 오류: %v", i18n.Errorf("알 수 없는 알림 채널 유형입니다: %q", ch.Type))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	if err := notifier.Notify(ctx, ch, msg); err != nil {
+		s.emit(msg.SchedulerID, "알림 전송 오류: %v", s.redactor.Text(err.Error()))
+	}
+}
+
+// WebhookNotifier posts notifications to the URL of ChannelWebhook
+// channels: the payload as JSON, or the rendered template.
+type WebhookNotifier struct {
+	Clients *ClientManager
+}
+
+// Notify posts n to ch.URL. A rendered template that is valid JSON is sent
+// as application/json, anything else as text.
+func (w *WebhookNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	var body []byte
+	contentType := "application/json"
+	if ch.Template == "" {
+		var err error
+		if body, err = json.Marshal(n.Payload); err != nil {
+			return err
+		}
+	} else {
+		text, err := n.Render(ch.Template, "")
+		if err != nil {
+			return err
+		}
+		body = []byte(text)
+		if !json.Valid(body) {
+			contentType = "text/plain; charset=utf-8"
+		}
+	}
+	return postNotification(ctx, w.Clients, ch, contentType, body)
+}
+
+// defaultSlackTemplate is the message of a Slack channel without a
+// Template.
+const defaultSlackTemplate = `{{if eq .Event "report"}}:bar_chart: {{.Payload.summary}}{{else}}` +
+	`{{if eq .Event "failed" "crashed" "stalled" "sla-violated"}}:rotating_light:{{else if eq .Event "approval-requested"}}:raised_hand:{{else}}:white_check_mark:{{end}} *{{or .Name .SchedulerID}}* {{.Event}}` +
+	`{{with .Payload.statusCode}} (HTTP {{.}}){{end}}{{with .Payload.error}}: {{.}}{{end}}{{with .Payload.state}} {{.}}{{end}}{{end}}`
+
+// SlackNotifier posts notifications to the Slack incoming webhook URL of
+// ChannelSlack channels.
+type SlackNotifier struct {
+	Clients *ClientManager
+}
+
+// Notify posts n to ch.URL. The rendered template is sent as the text of
+// the message, or as the message itself when it is a JSON object, e.g.
+// with Block Kit blocks.
+func (sn *SlackNotifier) Notify(ctx context.Context, ch NotifyChannel, n Notification) error {
+	text, err := n.Render(ch.Template, defaultSlackTemplate)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(chatMessage(text, "text", 0))
+	if err != nil {
+		return err
+	}
+	return postNotification(ctx, sn.Clients, ch, "application/json", body)
+}
+
+// postNotification posts body to the URL of ch with its headers.
+func postNotification(ctx context.Context, clients *ClientManager, ch NotifyChannel, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ch.url(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range ch.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := clients.Client(TransportConfig{}).Do(req)
+	if err != nil {
+		// The URL is left out, since webhook URLs often embed a secret.
+		if ue, ok := err.(*url.Error); ok {
+			return ue.Err
+		}
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return i18n.Errorf("상태 코드 %d", resp.StatusCode)
+	}
+	return nil
+}
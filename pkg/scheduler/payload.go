@@ -0,0 +1,452 @@
+// pkg/scheduler/payload.go
+package scheduler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// maxPayloadSize bounds a payload read from a PayloadSource.
+const maxPayloadSize = 10 << 20
+
+// ErrPayloadRender is returned by HTTPExecutor for a payload that isn't
+// valid JSON, instead of sending the request without it. Executions
+// failing with it are marked with ErrorCodePayloadRender and not retried,
+// since the same payload would fail again.
+var ErrPayloadRender = errors.New("scheduler: payload render error")
+
+// ErrorCodePayloadRender is the Execution.ErrorCode of executions that
+// failed with ErrPayloadRender.
+const ErrorCodePayloadRender = "payload-render"
+
+// PayloadSource reads the payload of an HTTP request at execution time
+// instead of taking it from Config.Payload, for bodies that are large or
+// generated by another system.
+type PayloadSource struct {
+	// File is a local file read on every execution. It must be inside the
+	// directory set by WithFileRoot, which relative paths are resolved
+	// against.
+	File string `json:"file,omitempty"`
+	// URL is fetched with a GET request on every execution, through the
+	// job's Transport. Responses other than 200 OK fail the execution.
+	URL string `json:"url,omitempty"`
+}
+
+// validate checks the payload source. A nil PayloadSource is valid.
+func (p *PayloadSource) validate() error {
+	if p == nil {
+		return nil
+	}
+	if (p.File == "") == (p.URL == "") {
+		return i18n.Errorf("%w: payloadFrom에는 file과 url 중 하나만 지정해야 합니다", ErrInvalidConfig)
+	}
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return i18n.Errorf("%w: payloadFrom.url은 http 또는 https URL이어야 합니다: %q", ErrInvalidConfig, p.URL)
+		}
+	}
+	return nil
+}
+
+// load reads the payload, fetching URLs with client and reading files
+// under root.
+func (p *PayloadSource) load(ctx context.Context, client *http.Client, root string) (string, error) {
+	if p.File != "" {
+		path, err := resolveFile(root, p.File)
+		if err != nil {
+			return "", err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return "", i18n.Errorf("페이로드 파일을 읽을 수 없습니다: %w", err)
+		}
+		defer f.Close()
+		return readPayload(f)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.URL, nil)
+	if err != nil {
+		return "", i18n.Errorf("요청 생성 오류: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", i18n.Errorf("페이로드를 가져올 수 없습니다: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", i18n.Errorf("페이로드를 가져올 수 없습니다 - 상태 코드: %d", resp.StatusCode)
+	}
+	return readPayload(resp.Body)
+}
+
+// WithFileRoot lets schedulers read local files under dir, with
+// Config.PayloadFrom and Config.Files. Without it, or with an empty dir, they can't read
+// any, so that configs sent through the API can't read the files of the
+// server.
+func WithFileRoot(dir string) Option {
+	return func(s *Scheduler) {
+		if dir != "" {
+			if abs, err := filepath.Abs(dir); err == nil {
+				dir = abs
+			}
+		}
+		s.fileRoot = dir
+	}
+}
+
+// checkFiles rejects the local files of c that are outside the directory
+// set by WithFileRoot, and CA files that can't be loaded.
+func (s *Scheduler) checkFiles(c Config) error {
+	if c.PayloadFrom != nil && c.PayloadFrom.File != "" {
+		if _, err := resolveFile(s.fileRoot, c.PayloadFrom.File); err != nil {
+			return i18n.Errorf("%w: payloadFrom.file: %v", ErrInvalidConfig, err)
+		}
+	}
+	for i, f := range c.Files {
+		if f.Path == "" {
+			continue
+		}
+		if _, err := resolveFile(s.fileRoot, f.Path); err != nil {
+			return i18n.Errorf("%w: files[%d].path: %v", ErrInvalidConfig, i, err)
+		}
+	}
+	if c.GRPC != nil && c.GRPC.DescriptorSet != "" {
+		if _, err := resolveFile(s.fileRoot, c.GRPC.DescriptorSet); err != nil {
+			return i18n.Errorf("%w: grpc.descriptorSet: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.Calendar != nil && c.Calendar.Holidays != "" && !isURL(c.Calendar.Holidays) {
+		if _, err := resolveFile(s.fileRoot, c.Calendar.Holidays); err != nil {
+			return i18n.Errorf("%w: calendar.holidays: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.Transport != nil && c.Transport.CAFile != "" {
+		if _, err := c.Transport.tlsConfig(s.fileRoot); err != nil {
+			return i18n.Errorf("%w: transport TLS 설정 오류: %v", ErrInvalidConfig, err)
+		}
+	}
+	return nil
+}
+
+// FileRoot returns the directory set by WithFileRoot, for executors
+// registered by the embedder that read local files.
+func (s *Scheduler) FileRoot() string {
+	return s.fileRoot
+}
+
+// ResolveFile returns the path of the file name below root, as the
+// built-in executors resolve local files below FileRoot. Names outside
+// root, including through symbolic links, are rejected, as are all names
+// when root is empty.
+func ResolveFile(root, name string) (string, error) {
+	return resolveFile(root, name)
+}
+
+// resolveFile returns the path of the file name under root, resolving
+// relative names against it. Names outside root, including through
+// symbolic links, are rejected, as are all names when root is empty.
+func resolveFile(root, name string) (string, error) {
+	if root == "" {
+		return "", i18n.Errorf("서버에 파일 디렉터리(-file-root)가 설정되지 않아 파일을 읽을 수 없습니다: %s", name)
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	if !within(root, path) {
+		return "", i18n.Errorf("파일이 허용된 디렉터리 밖에 있습니다: %s", name)
+	}
+	if real, err := evalExisting(path); err == nil {
+		realRoot, err := filepath.EvalSymlinks(root)
+		if err != nil || !within(realRoot, real) {
+			return "", i18n.Errorf("파일이 허용된 디렉터리 밖에 있습니다: %s", name)
+		}
+	}
+	return path, nil
+}
+
+// evalExisting is filepath.EvalSymlinks for paths that may not exist yet,
+// such as directories about to be created: the symbolic links of the
+// longest existing prefix are evaluated and the rest is appended.
+func evalExisting(path string) (string, error) {
+	var rest []string
+	for {
+		real, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(append([]string{real}, rest...)...), nil
+		}
+		parent := filepath.Dir(path)
+		if !os.IsNotExist(err) || parent == path {
+			return "", err
+		}
+		rest = append([]string{filepath.Base(path)}, rest...)
+		path = parent
+	}
+}
+
+// within reports whether path is root or below it.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// readPayload reads r up to maxPayloadSize.
+func readPayload(r io.Reader) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxPayloadSize+1))
+	if err != nil {
+		return "", i18n.Errorf("페이로드 읽기 오류: %w", err)
+	}
+	if len(b) > maxPayloadSize {
+		return "", i18n.Errorf("페이로드가 %dMB를 넘습니다", maxPayloadSize>>20)
+	}
+	return string(b), nil
+}
+
+// Payload types; see Config.PayloadType.
+const (
+	// PayloadForm sends the fields of a JSON object as the query of GET
+	// requests and the form body of POST requests. It is the default.
+	PayloadForm = "form"
+	// PayloadJSON sends the payload, any JSON value, as an
+	// application/json body.
+	PayloadJSON = "json"
+	// PayloadText sends the payload as it is, with Config.ContentType.
+	PayloadText = "text"
+	// PayloadBinary sends the base64-decoded payload, with
+	// Config.ContentType.
+	PayloadBinary = "binary"
+)
+
+// payloadType returns the configured payload type, defaulting to
+// PayloadForm.
+func (c Config) payloadType() string {
+	if c.PayloadType == "" {
+		return PayloadForm
+	}
+	return c.PayloadType
+}
+
+// contentType returns the Content-Type of a body that isn't a form.
+func (c Config) contentType() string {
+	if c.ContentType == "" && c.payloadType() == PayloadJSON {
+		return "application/json"
+	}
+	return c.ContentType
+}
+
+// validatePayload checks the payload of the job types sending it through
+// HTTPExecutor. Variable references are taken as values, since they are
+// only replaced at execution time.
+func (c Config) validatePayload() error {
+	switch c.jobType() {
+	case JobTypeHTTP, JobTypeMonitor, JobTypeLoad, JobTypeFanout:
+	default:
+		return nil
+	}
+	typ := c.payloadType()
+	switch typ {
+	case PayloadForm:
+		if c.ContentType != "" {
+			return i18n.Errorf("%w: contentType은 payloadType json, text, binary에만 쓸 수 있습니다", ErrInvalidConfig)
+		}
+	case PayloadJSON, PayloadText, PayloadBinary:
+		if strings.ToUpper(c.HTTPMethod) != "POST" {
+			return i18n.Errorf("%w: payloadType %s는 POST 요청에만 쓸 수 있습니다", ErrInvalidConfig, typ)
+		}
+		if len(c.Files) > 0 {
+			return i18n.Errorf("%w: files는 payloadType form에만 쓸 수 있습니다", ErrInvalidConfig)
+		}
+		if c.ContentType == "" && typ != PayloadJSON {
+			return i18n.Errorf("%w: payloadType %s에는 contentType이 필요합니다", ErrInvalidConfig, typ)
+		}
+		if c.ContentType != "" {
+			if _, _, err := mime.ParseMediaType(c.ContentType); err != nil {
+				return i18n.Errorf("%w: contentType이 올바르지 않습니다: %q", ErrInvalidConfig, c.ContentType)
+			}
+		}
+	default:
+		return i18n.Errorf("%w: 알 수 없는 payloadType입니다: %q", ErrInvalidConfig, c.PayloadType)
+	}
+	if c.RawQuery {
+		if strings.ToUpper(c.HTTPMethod) == "POST" || len(c.Files) > 0 {
+			return i18n.Errorf("%w: rawQuery는 GET 요청에만 쓸 수 있습니다", ErrInvalidConfig)
+		}
+		if err := checkRawQuery(variableRef.ReplaceAllString(c.Payload, "0")); err != nil {
+			return i18n.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+		return nil
+	}
+
+	var err error
+	switch typ {
+	case PayloadForm:
+		return validateFormPayload("payload", c.Payload)
+	case PayloadJSON:
+		_, err = jsonBody(variableRef.ReplaceAllString(c.Payload, "0"))
+	case PayloadBinary:
+		if !variableRef.MatchString(c.Payload) {
+			_, err = binaryBody(c.Payload)
+		}
+	}
+	if err != nil {
+		return i18n.Errorf("%w: payload: %v", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// validateFormPayload checks payload, the setting named field, as a form
+// payload.
+func validateFormPayload(field, payload string) error {
+	if _, err := formFields(variableRef.ReplaceAllString(payload, "0")); err != nil {
+		return i18n.Errorf("%w: %s: %v", ErrInvalidConfig, field, err)
+	}
+	return nil
+}
+
+// requestBody returns the body of a request with config, whose payload
+// type isn't PayloadForm, from the payload text. It fails with
+// ErrPayloadRender when the text doesn't fit the type. Binary payloads
+// read through Config.PayloadFrom are sent as they are.
+func requestBody(config Config, text string) ([]byte, error) {
+	var body []byte
+	var err error
+	switch {
+	case config.payloadType() == PayloadJSON:
+		body, err = jsonBody(text)
+	case config.payloadType() == PayloadBinary && config.PayloadFrom == nil:
+		body, err = binaryBody(text)
+	default:
+		body = []byte(text)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("%w: %v", ErrPayloadRender, err)
+	}
+	return body, nil
+}
+
+// parsePayload parses a form payload into fields, failing with
+// ErrPayloadRender when it isn't one.
+func parsePayload(text string) (url.Values, error) {
+	fields, err := formFields(text)
+	if err != nil {
+		return nil, i18n.Errorf("%w: %v", ErrPayloadRender, err)
+	}
+	return fields, nil
+}
+
+// formFields parses a form payload: a JSON object whose values are
+// strings, numbers, booleans or arrays of them. Arrays become repeated
+// fields and numbers and booleans their text; nulls are left out. An
+// empty payload has no fields.
+func formFields(text string) (url.Values, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	v, err := decodeJSON(text)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := v.(map[string]any)
+	if !ok {
+		return nil, i18n.Errorf("form 페이로드는 JSON 객체여야 합니다. 다른 JSON 값은 payloadType json으로 보내세요")
+	}
+	fields := url.Values{}
+	for key, value := range payload {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			text, ok := fieldText(v)
+			if !ok {
+				return nil, i18n.Errorf("%s 필드의 값은 문자열, 숫자, 불리언 또는 그 배열이어야 합니다. 중첩된 값은 payloadType json으로 보내세요", key)
+			}
+			fields.Add(key, text)
+		}
+	}
+	return fields, nil
+}
+
+// fieldText returns the text of a JSON scalar as a form field.
+func fieldText(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// jsonBody checks that text is a single JSON value. Empty text is sent as
+// an empty body.
+func jsonBody(text string) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	if _, err := decodeJSON(text); err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+// binaryBody decodes base64 text.
+func binaryBody(text string) ([]byte, error) {
+	body, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, i18n.Errorf("binary 페이로드는 base64여야 합니다: %v", err)
+	}
+	return body, nil
+}
+
+// decodeJSON decodes text, keeping numbers as they are written.
+func decodeJSON(text string) (any, error) {
+	var syntaxErr *json.SyntaxError
+	if err := json.Unmarshal([]byte(text), new(json.RawMessage)); errors.As(err, &syntaxErr) {
+		return nil, i18n.Errorf("페이로드가 올바른 JSON이 아닙니다: %v (위치 %d)", err, syntaxErr.Offset)
+	}
+	var v any
+	d := json.NewDecoder(strings.NewReader(text))
+	d.UseNumber()
+	return v, d.Decode(&v)
+}
+
+// checkRawQuery reports characters that can't be sent in the query of a
+// URL as they are.
+func checkRawQuery(query string) error {
+	if i := strings.IndexFunc(query, func(r rune) bool { return r == '#' || r <= ' ' || r == 0x7f }); i >= 0 {
+		return i18n.Errorf("rawQuery 페이로드에는 공백, 제어 문자, '#'을 쓸 수 없습니다 (위치 %d)", i)
+	}
+	return nil
+}
+
+// appendQuery returns u with query appended to the query it already has.
+func appendQuery(u *url.URL, query string) *url.URL {
+	query = strings.TrimPrefix(query, "?")
+	switch {
+	case query == "":
+	case u.RawQuery == "":
+		u.RawQuery = query
+	default:
+		u.RawQuery += "&" + query
+	}
+	return u
+}
@@ -0,0 +1,173 @@
+// pkg/scheduler/pool.go
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// PoolStats is a snapshot of the execution pool.
+type PoolStats struct {
+	// MaxConcurrent is the execution limit. Zero means unlimited.
+	MaxConcurrent int `json:"maxConcurrent"`
+	// Active is the number of executions currently running.
+	Active int64 `json:"active"`
+	// Queued is the number of executions waiting for a free slot.
+	Queued int64 `json:"queued"`
+	// Completed is the number of executions finished since start.
+	Completed int64 `json:"completed"`
+}
+
+// pool bounds the number of executions that run at the same time across
+// all schedulers. Executions waiting for a slot get it by priority, then in
+// the order they started waiting.
+type pool struct {
+	// max is the execution limit. Zero means unlimited.
+	max int
+
+	// mu protects running and waiting.
+	mu      sync.Mutex
+	running int
+	waiting waitQueue
+	// seq orders the waiters of the same priority.
+	seq uint64
+
+	active    atomic.Int64
+	queued    atomic.Int64
+	completed atomic.Int64
+}
+
+// waiter is an execution waiting for a slot. ready is closed when the slot
+// of a finished execution is handed over to it.
+type waiter struct {
+	priority int
+	seq      uint64
+	ready    chan struct{}
+	// granted is set with ready closed, under the pool's mu.
+	granted bool
+	// index is the position in the waitQueue.
+	index int
+}
+
+// waitQueue is a heap of waiters, highest priority and earliest first.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, k int) bool {
+	if q[i].priority != q[k].priority {
+		return q[i].priority > q[k].priority
+	}
+	return q[i].seq < q[k].seq
+}
+
+func (q waitQueue) Swap(i, k int) {
+	q[i], q[k] = q[k], q[i]
+	q[i].index = i
+	q[k].index = k
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
+
+// newPool creates a pool that allows max concurrent executions. A max of
+// zero or less means unlimited.
+func newPool(max int) *pool {
+	if max < 0 {
+		max = 0
+	}
+	return &pool{max: max}
+}
+
+// tryAcquire takes a slot if one is free and no execution is waiting for
+// it, without waiting.
+func (p *pool) tryAcquire() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.max > 0 && (p.running >= p.max || p.waiting.Len() > 0) {
+		return false
+	}
+	p.running++
+	p.active.Add(1)
+	return true
+}
+
+// acquire waits for a free slot or for ctx to be done. Waiting executions
+// of a higher priority get a slot first.
+func (p *pool) acquire(ctx context.Context, priority int) error {
+	p.mu.Lock()
+	if p.max == 0 || (p.running < p.max && p.waiting.Len() == 0) {
+		p.running++
+		p.mu.Unlock()
+		p.active.Add(1)
+		return nil
+	}
+	p.seq++
+	w := &waiter{priority: priority, seq: p.seq, ready: make(chan struct{})}
+	heap.Push(&p.waiting, w)
+	p.mu.Unlock()
+
+	p.queued.Add(1)
+	defer p.queued.Add(-1)
+	select {
+	case <-w.ready:
+		p.active.Add(1)
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		granted := w.granted
+		if !granted {
+			heap.Remove(&p.waiting, w.index)
+		}
+		p.mu.Unlock()
+		if granted {
+			// The slot was handed over as ctx was done; pass it on.
+			p.handOff()
+		}
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire or tryAcquire.
+func (p *pool) release() {
+	p.active.Add(-1)
+	p.completed.Add(1)
+	p.handOff()
+}
+
+// handOff gives a slot that is no longer used to the first waiter, or
+// frees it.
+func (p *pool) handOff() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.waiting.Len() == 0 {
+		p.running--
+		return
+	}
+	w := heap.Pop(&p.waiting).(*waiter)
+	w.granted = true
+	close(w.ready)
+}
+
+// stats returns a snapshot of the pool counters.
+func (p *pool) stats() PoolStats {
+	return PoolStats{
+		MaxConcurrent: p.max,
+		Active:        p.active.Load(),
+		Queued:        p.queued.Load(),
+		Completed:     p.completed.Load(),
+	}
+}
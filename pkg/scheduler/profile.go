@@ -0,0 +1,145 @@
+// pkg/scheduler/profile.go
+package scheduler
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Profile is an environment, such as dev, staging or prod, that schedulers
+// call. Schedulers with a relative apiURL run against the active profile,
+// or the one named by their Config.Profile, so the same set of schedulers
+// can be pointed at another environment by switching profiles.
+type Profile struct {
+	// BaseURL is the absolute URL relative apiURLs are appended to, e.g.
+	// "https://staging.example.com/api".
+	BaseURL string `json:"baseURL"`
+	// Headers are added to the requests, e.g. an API key of the
+	// environment. The scheduler's own headers take precedence.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Auth authenticates the requests of schedulers without their own
+	// Config.Auth.
+	Auth *AuthConfig `json:"auth,omitempty"`
+}
+
+// ProfileInfo describes a profile without its credentials.
+type ProfileInfo struct {
+	Name    string `json:"name"`
+	BaseURL string `json:"baseURL"`
+	Active  bool   `json:"active"`
+}
+
+// validate checks the profile called name.
+func (p Profile) validate(name string) error {
+	u, err := url.Parse(p.BaseURL)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return i18n.Errorf("%w: 프로필 %q의 baseURL은 절대 URL이어야 합니다", ErrInvalidConfig, name)
+	}
+	return p.Auth.validate()
+}
+
+// resolve returns the path ref, such as "/v1/orders?limit=10", appended to
+// the base URL of p.
+func (p Profile) resolve(ref string) string {
+	return strings.TrimSuffix(p.BaseURL, "/") + "/" + strings.TrimPrefix(ref, "/")
+}
+
+// validateProfiles checks profiles and that active, if set, is one of
+// them.
+func validateProfiles(profiles map[string]Profile, active string) error {
+	for name, p := range profiles {
+		if err := p.validate(name); err != nil {
+			return err
+		}
+	}
+	if _, ok := profiles[active]; active != "" && !ok {
+		return i18n.Errorf("%w: 활성 프로필이 정의되어 있지 않습니다: %q", ErrInvalidConfig, active)
+	}
+	return nil
+}
+
+// Profiles returns the profiles set with Reconfigure, ordered by name.
+func (s *Scheduler) Profiles() []ProfileInfo {
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	list := make([]ProfileInfo, 0, len(s.settings.profiles))
+	for name, p := range s.settings.profiles {
+		list = append(list, ProfileInfo{Name: name, BaseURL: p.BaseURL, Active: name == s.settings.activeProfile})
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list
+}
+
+// profile returns the name and settings of the profile the requests of a
+// scheduler with config c go to: its own, or the active one when its
+// apiURL is relative. ok is false when c uses no profile.
+func (s *Scheduler) profile(c Config) (name string, p Profile, ok bool, err error) {
+	name = c.Profile
+	if name == "" {
+		if !relativeURL(c.APIURL) && (c.Session == nil || c.Session.Login == nil || !relativeURL(c.Session.Login.APIURL)) {
+			return "", Profile{}, false, nil
+		}
+		s.settings.mu.RLock()
+		name = s.settings.activeProfile
+		s.settings.mu.RUnlock()
+		if name == "" {
+			return "", Profile{}, false, i18n.Errorf("%w: 상대 apiURL에는 프로필이 필요합니다", ErrInvalidConfig)
+		}
+	}
+	s.settings.mu.RLock()
+	p, ok = s.settings.profiles[name]
+	s.settings.mu.RUnlock()
+	if !ok {
+		return name, Profile{}, false, i18n.Errorf("%w: 프로필을 찾을 수 없습니다: %q", ErrInvalidConfig, name)
+	}
+	return name, p, true, nil
+}
+
+// checkProfile checks that the profile config needs is defined.
+func (s *Scheduler) checkProfile(config Config) error {
+	_, _, _, err := s.profile(config)
+	return err
+}
+
+// applyProfile returns config with its relative URLs appended to the base
+// URL of its profile, whose headers are added below the scheduler's and whose auth
+// is used when the scheduler has none.
+func (s *Scheduler) applyProfile(config Config) (Config, error) {
+	_, p, ok, err := s.profile(config)
+	if err != nil || !ok {
+		return config, err
+	}
+	if relativeURL(config.APIURL) {
+		config.APIURL = p.resolve(config.APIURL)
+	}
+	if config.Session != nil && config.Session.Login != nil && relativeURL(config.Session.Login.APIURL) {
+		login := *config.Session.Login
+		login.APIURL = p.resolve(login.APIURL)
+		config.Session = &SessionConfig{Login: &login}
+	}
+	if len(p.Headers) > 0 {
+		headers := make(map[string]string, len(p.Headers)+len(config.Headers))
+		for name, value := range p.Headers {
+			headers[name] = value
+		}
+		config.Headers = overrideHeaders(headers, config.Headers)
+	}
+	if config.Auth == nil {
+		config.Auth = p.Auth
+	}
+	return config, nil
+}
+
+// relativeURL reports whether u is a URL without a scheme, such as
+// "/v1/orders", to be resolved against a profile. A URL starting with a
+// variable reference, such as {{var "ORDERS_API"}}/v1/orders, isn't.
+func relativeURL(u string) bool {
+	if u == "" || strings.HasPrefix(u, "{{") {
+		return false
+	}
+	parsed, err := url.Parse(u)
+	return err == nil && !parsed.IsAbs()
+}
@@ -0,0 +1,515 @@
+// pkg/scheduler/redact.go
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// redactedValue replaces redacted values.
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedHeaders are always redacted.
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// Redactor masks secrets before request and response details are written
+// to events or the execution store.
+type Redactor struct {
+	headers  map[string]bool
+	fields   []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a Redactor masking the values of the given header
+// names (in addition to Authorization, Cookie and the like), of JSON
+// fields, and every match of the given regular expressions. A field is
+// either a name, matched at any depth, or a dotted path from the root such
+// as "user.password".
+func NewRedactor(headers, fields, patterns []string) (*Redactor, error) {
+	r := &Redactor{headers: make(map[string]bool), fields: fields}
+	for _, h := range append(defaultRedactedHeaders, headers...) {
+		r.headers[http.CanonicalHeaderKey(h)] = true
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, i18n.Errorf("잘못된 마스킹 정규식 %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Text masks the matches of the patterns in s.
+func (r *Redactor) Text(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, redactedValue)
+	}
+	return s
+}
+
+// Body masks the configured JSON fields of a JSON body and then the
+// matches of the patterns. Bodies that aren't JSON only get the patterns
+// applied.
+func (r *Redactor) Body(s string) string {
+	if len(r.fields) > 0 {
+		dec := json.NewDecoder(strings.NewReader(s))
+		dec.UseNumber()
+		var v any
+		if dec.Decode(&v) == nil && r.redactFields(v, "") {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if enc.Encode(v) == nil {
+				s = strings.TrimSuffix(buf.String(), "\n")
+			}
+		}
+	}
+	return r.Text(s)
+}
+
+// Headers formats headers as "Name: value" pairs with redacted values masked.
+func (r *Redactor) Headers(h map[string]string) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := h[name]
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		parts = append(parts, name+": "+r.Text(value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// redactFields masks the configured fields in v, whose path from the root
+// is path. It reports whether anything was masked.
+func (r *Redactor) redactFields(v any, path string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if r.matchesField(key, childPath) {
+				v[key] = redactedValue
+				changed = true
+				continue
+			}
+			if r.redactFields(child, childPath) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if r.redactFields(child, path) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// matchesField reports whether the field named key at path is redacted.
+func (r *Redactor) matchesField(key, path string) bool {
+	for _, f := range r.fields {
+		if f == path || (!strings.Contains(f, ".") && strings.EqualFold(f, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Config returns a copy of c with its secrets masked: redacted headers and
+// gRPC metadata, the values of command environment variables named in the
+// fields, the configured JSON fields of payloads and messages, proxy URLs
+// with a password, and pattern matches in URLs.
+func (r *Redactor) Config(c Config) Config {
+	c.APIURL = r.Text(c.APIURL)
+	c.Payload = r.Body(c.Payload)
+	c.Headers = r.headerMap(c.Headers)
+	if c.PayloadFrom != nil {
+		p := *c.PayloadFrom
+		p.URL = r.Text(p.URL)
+		c.PayloadFrom = &p
+	}
+	if c.Command != nil {
+		cmd := *c.Command
+		cmd.Env = r.fieldMap(cmd.Env)
+		c.Command = &cmd
+	}
+	if c.GRPC != nil {
+		g := *c.GRPC
+		g.Request = r.Body(g.Request)
+		g.Metadata = r.headerMap(g.Metadata)
+		c.GRPC = &g
+	}
+	if c.Publish != nil {
+		p := *c.Publish
+		p.Message = r.Body(p.Message)
+		p.Headers = r.headerMap(p.Headers)
+		c.Publish = &p
+	}
+	if c.GraphQL != nil && len(c.GraphQL.Variables) > 0 {
+		g := *c.GraphQL
+		g.Variables = json.RawMessage(r.Body(string(g.Variables)))
+		c.GraphQL = &g
+	}
+	if c.Chain != nil {
+		ch := *c.Chain
+		ch.Vars = r.fieldMap(ch.Vars)
+		ch.Steps = make([]ChainStep, len(c.Chain.Steps))
+		for i, step := range c.Chain.Steps {
+			step.APIURL = r.Text(step.APIURL)
+			step.Payload = r.Body(step.Payload)
+			step.Headers = r.headerMap(step.Headers)
+			ch.Steps[i] = step
+		}
+		c.Chain = &ch
+	}
+	if c.Workflow != nil {
+		wf := *c.Workflow
+		wf.Vars = r.fieldMap(wf.Vars)
+		wf.Steps = make([]WorkflowStep, len(c.Workflow.Steps))
+		for i, step := range c.Workflow.Steps {
+			step.APIURL = r.Text(step.APIURL)
+			step.Payload = r.Body(step.Payload)
+			step.Headers = r.headerMap(step.Headers)
+			wf.Steps[i] = step
+		}
+		c.Workflow = &wf
+	}
+	if c.Fanout != nil {
+		f := *c.Fanout
+		f.Targets = make([]string, len(c.Fanout.Targets))
+		for i, t := range c.Fanout.Targets {
+			f.Targets[i] = r.Text(t)
+		}
+		c.Fanout = &f
+	}
+	if c.Transport != nil && c.Transport.Proxy != "" {
+		t := *c.Transport
+		if u, err := url.Parse(t.Proxy); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				t.Proxy = redactedValue
+			}
+		}
+		t.Proxy = r.Text(t.Proxy)
+		c.Transport = &t
+	}
+	if c.Session != nil && c.Session.Login != nil {
+		l := *c.Session.Login
+		l.APIURL = r.Text(l.APIURL)
+		l.Payload = r.Body(l.Payload)
+		l.Headers = r.headerMap(l.Headers)
+		c.Session = &SessionConfig{Login: &l}
+	}
+	if c.Archive != nil && c.Archive.S3 != nil {
+		ar := *c.Archive
+		s3 := *ar.S3
+		if s3.SecretAccessKey != "" {
+			s3.SecretAccessKey = redactedValue
+		}
+		if s3.SessionToken != "" {
+			s3.SessionToken = redactedValue
+		}
+		ar.S3 = &s3
+		c.Archive = &ar
+	}
+	if c.Auth != nil && c.Auth.Password != "" {
+		a := *c.Auth
+		a.Password = redactedValue
+		c.Auth = &a
+	}
+	if c.Signing != nil {
+		sg := *c.Signing
+		if sg.HMAC != nil {
+			h := *sg.HMAC
+			h.Secret = redactedValue
+			sg.HMAC = &h
+		}
+		if sg.AWS != nil {
+			a := *sg.AWS
+			if a.SecretAccessKey != "" {
+				a.SecretAccessKey = redactedValue
+			}
+			if a.SessionToken != "" {
+				a.SessionToken = redactedValue
+			}
+			sg.AWS = &a
+		}
+		c.Signing = &sg
+	}
+	if c.Calendar != nil {
+		cal := *c.Calendar
+		cal.Holidays = r.Text(cal.Holidays)
+		c.Calendar = &cal
+	}
+	if c.Notify != nil {
+		n := *c.Notify
+		n.WebhookURL = r.Text(n.WebhookURL)
+		n.Channels = make([]NotifyChannel, len(c.Notify.Channels))
+		for i, ch := range c.Notify.Channels {
+			ch.URL = r.Text(ch.URL)
+			ch.Headers = r.headerMap(ch.Headers)
+			if ch.RoutingKey != "" {
+				ch.RoutingKey = redactedValue
+			}
+			if ch.GroupRoutingKeys != nil {
+				keys := make(map[string]string, len(ch.GroupRoutingKeys))
+				for group := range ch.GroupRoutingKeys {
+					keys[group] = redactedValue
+				}
+				ch.GroupRoutingKeys = keys
+			}
+			n.Channels[i] = ch
+		}
+		c.Notify = &n
+	}
+	if c.Forward != nil {
+		f := *c.Forward
+		f.URL = r.Text(f.URL)
+		f.Headers = r.headerMap(f.Headers)
+		c.Forward = &f
+	}
+	return c
+}
+
+// headerMap returns a copy of h with redacted header values masked.
+func (r *Redactor) headerMap(h map[string]string) map[string]string {
+	if h == nil {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for name, value := range h {
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		out[name] = r.Text(value)
+	}
+	return out
+}
+
+// fieldMap returns a copy of m with the values of redacted fields masked.
+func (r *Redactor) fieldMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for key, value := range m {
+		if r.matchesField(key, key) {
+			value = redactedValue
+		}
+		out[key] = r.Text(value)
+	}
+	return out
+}
+
+// unredact returns c with the values masked by Config replaced by the
+// values in current, so a masked config can be edited and sent back
+// without retyping its secrets. Values masked inside a URL can't be
+// restored and must be sent in full.
+func unredact(c, current Config) Config {
+	c.Payload = unredactBody(c.Payload, current.Payload)
+	c.Headers = unredactMap(c.Headers, current.Headers)
+	if c.Command != nil && current.Command != nil {
+		cmd := *c.Command
+		cmd.Env = unredactMap(cmd.Env, current.Command.Env)
+		c.Command = &cmd
+	}
+	if c.GRPC != nil && current.GRPC != nil {
+		g := *c.GRPC
+		g.Request = unredactBody(g.Request, current.GRPC.Request)
+		g.Metadata = unredactMap(g.Metadata, current.GRPC.Metadata)
+		c.GRPC = &g
+	}
+	if c.Publish != nil && current.Publish != nil {
+		p := *c.Publish
+		p.Message = unredactBody(p.Message, current.Publish.Message)
+		p.Headers = unredactMap(p.Headers, current.Publish.Headers)
+		c.Publish = &p
+	}
+	if c.GraphQL != nil && current.GraphQL != nil && len(c.GraphQL.Variables) > 0 {
+		g := *c.GraphQL
+		g.Variables = json.RawMessage(unredactBody(string(g.Variables), string(current.GraphQL.Variables)))
+		c.GraphQL = &g
+	}
+	if c.Transport != nil && current.Transport != nil && c.Transport.Proxy == redactedValue {
+		t := *c.Transport
+		t.Proxy = current.Transport.Proxy
+		c.Transport = &t
+	}
+	if c.Session != nil && c.Session.Login != nil && current.Session != nil && current.Session.Login != nil {
+		l := *c.Session.Login
+		l.Payload = unredactBody(l.Payload, current.Session.Login.Payload)
+		l.Headers = unredactMap(l.Headers, current.Session.Login.Headers)
+		c.Session = &SessionConfig{Login: &l}
+	}
+	if c.Archive != nil && c.Archive.S3 != nil && current.Archive != nil && current.Archive.S3 != nil {
+		ar := *c.Archive
+		s3 := *ar.S3
+		if s3.SecretAccessKey == redactedValue {
+			s3.SecretAccessKey = current.Archive.S3.SecretAccessKey
+		}
+		if s3.SessionToken == redactedValue {
+			s3.SessionToken = current.Archive.S3.SessionToken
+		}
+		ar.S3 = &s3
+		c.Archive = &ar
+	}
+	if c.Auth != nil && current.Auth != nil && c.Auth.Password == redactedValue {
+		a := *c.Auth
+		a.Password = current.Auth.Password
+		c.Auth = &a
+	}
+	if c.Signing != nil && current.Signing != nil {
+		sg := *c.Signing
+		if sg.HMAC != nil && current.Signing.HMAC != nil && sg.HMAC.Secret == redactedValue {
+			h := *sg.HMAC
+			h.Secret = current.Signing.HMAC.Secret
+			sg.HMAC = &h
+		}
+		if sg.AWS != nil && current.Signing.AWS != nil {
+			a := *sg.AWS
+			if a.SecretAccessKey == redactedValue {
+				a.SecretAccessKey = current.Signing.AWS.SecretAccessKey
+			}
+			if a.SessionToken == redactedValue {
+				a.SessionToken = current.Signing.AWS.SessionToken
+			}
+			sg.AWS = &a
+		}
+		c.Signing = &sg
+	}
+	if c.Chain != nil && current.Chain != nil {
+		ch := *c.Chain
+		ch.Vars = unredactMap(ch.Vars, current.Chain.Vars)
+		ch.Steps = make([]ChainStep, len(c.Chain.Steps))
+		for i, step := range c.Chain.Steps {
+			if i < len(current.Chain.Steps) {
+				step.Payload = unredactBody(step.Payload, current.Chain.Steps[i].Payload)
+				step.Headers = unredactMap(step.Headers, current.Chain.Steps[i].Headers)
+			}
+			ch.Steps[i] = step
+		}
+		c.Chain = &ch
+	}
+	if c.Workflow != nil && current.Workflow != nil {
+		wf := *c.Workflow
+		wf.Vars = unredactMap(wf.Vars, current.Workflow.Vars)
+		wf.Steps = make([]WorkflowStep, len(c.Workflow.Steps))
+		for i, step := range c.Workflow.Steps {
+			if i < len(current.Workflow.Steps) {
+				step.Payload = unredactBody(step.Payload, current.Workflow.Steps[i].Payload)
+				step.Headers = unredactMap(step.Headers, current.Workflow.Steps[i].Headers)
+			}
+			wf.Steps[i] = step
+		}
+		c.Workflow = &wf
+	}
+	if c.Notify != nil && current.Notify != nil {
+		n := *c.Notify
+		n.Channels = make([]NotifyChannel, len(c.Notify.Channels))
+		for i, ch := range c.Notify.Channels {
+			if i < len(current.Notify.Channels) {
+				cur := current.Notify.Channels[i]
+				ch.Headers = unredactMap(ch.Headers, cur.Headers)
+				ch.GroupRoutingKeys = unredactMap(ch.GroupRoutingKeys, cur.GroupRoutingKeys)
+				if ch.RoutingKey == redactedValue {
+					ch.RoutingKey = cur.RoutingKey
+				}
+			}
+			n.Channels[i] = ch
+		}
+		c.Notify = &n
+	}
+	if c.Forward != nil && current.Forward != nil {
+		f := *c.Forward
+		f.Headers = unredactMap(f.Headers, current.Forward.Headers)
+		c.Forward = &f
+	}
+	return c
+}
+
+// unredactMap returns a copy of m with masked values taken from current.
+func unredactMap(m, current map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for key, value := range m {
+		if old, ok := current[key]; ok && value == redactedValue {
+			value = old
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// unredactBody returns the JSON body s with masked field values taken from
+// the same paths in current. Bodies that aren't JSON are returned as they
+// are.
+func unredactBody(s, current string) string {
+	if !strings.Contains(s, redactedValue) {
+		return s
+	}
+	var v, old any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if dec.Decode(&v) != nil {
+		return s
+	}
+	dec = json.NewDecoder(strings.NewReader(current))
+	dec.UseNumber()
+	if dec.Decode(&old) != nil {
+		return s
+	}
+	v = unredactValue(v, old)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if enc.Encode(v) != nil {
+		return s
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// unredactValue replaces the masked values in v with the values at the
+// same place in old.
+func unredactValue(v, old any) any {
+	switch v := v.(type) {
+	case string:
+		if v == redactedValue && old != nil {
+			return old
+		}
+	case map[string]any:
+		if o, ok := old.(map[string]any); ok {
+			for key, child := range v {
+				v[key] = unredactValue(child, o[key])
+			}
+		}
+	case []any:
+		if o, ok := old.([]any); ok {
+			for i, child := range v {
+				if i < len(o) {
+					v[i] = unredactValue(child, o[i])
+				}
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,369 @@
+// pkg/scheduler/redisstore/redisstore.go
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+	"go-api-scheduler/pkg/storage"
+)
+
+// Retention of the capped lists kept in Redis.
+const (
+	maxExecutionsPerScheduler = 1000
+	maxAuditEntries           = 10000
+)
+
+// opTimeout bounds every Redis operation made through the scheduler.Store
+// methods, which take no context.
+const opTimeout = 5 * time.Second
+
+// Store keeps schedulers, executions, audit entries, config versions,
+// templates and variables in Redis so several instances behind a load
+// balancer share them. It implements scheduler.Store,
+// scheduler.ExecutionStore, scheduler.ExecutionRangeStore,
+// scheduler.PurgeStore, scheduler.AuditStore, scheduler.VersionStore,
+// scheduler.TemplateStore, scheduler.VariableStore and scheduler.Locker.
+type Store struct {
+	client *redis.Client
+	prefix string
+}
+
+// DefaultPrefix is the key prefix used when none is configured.
+const DefaultPrefix = "api-scheduler"
+
+func init() {
+	storage.Register("redis", func(o storage.Options) (storage.Store, error) {
+		if o.URL == "" {
+			return nil, i18n.Errorf("redis 저장소에는 storage.url 설정이 필요합니다")
+		}
+		prefix := o.KeyPrefix
+		if prefix == "" {
+			prefix = DefaultPrefix
+		}
+		s, err := Open(o.URL, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// Open connects to the Redis server at url (e.g. "redis://localhost:6379/0").
+// All keys are created under prefix.
+func Open(url, prefix string) (*Store, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, i18n.Errorf("Redis URL 파싱 오류: %w", err)
+	}
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, i18n.Errorf("Redis 연결 오류: %w", err)
+	}
+	return &Store{client: client, prefix: prefix}, nil
+}
+
+// Client returns the underlying Redis client, e.g. to share it with the log tail.
+func (s *Store) Client() *redis.Client {
+	return s.client
+}
+
+// Close closes the connection pool.
+func (s *Store) Close() error {
+	return s.client.Close()
+}
+
+// key builds a key under the store prefix.
+func (s *Store) key(parts ...string) string {
+	k := s.prefix
+	for _, p := range parts {
+		k += ":" + p
+	}
+	return k
+}
+
+// updateScript replaces a hash field only if it still exists.
+var updateScript = redis.NewScript(`
+if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
+	return redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+end
+return 0
+`)
+
+// Save creates or replaces the record with rec.ID. Records that have
+// already fired are only updated, so a running instance does not bring
+// back a scheduler another instance has just deleted.
+func (s *Store) Save(rec scheduler.Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	if rec.LastFire.IsZero() {
+		return s.client.HSet(ctx, s.key("schedulers"), rec.ID, data).Err()
+	}
+	return updateScript.Run(ctx, s.client, []string{s.key("schedulers")}, rec.ID, data).Err()
+}
+
+// Delete removes the record with the given ID, if any.
+func (s *Store) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.HDel(ctx, s.key("schedulers"), id).Err()
+}
+
+// Load returns every stored record, ordered by ID.
+func (s *Store) Load() ([]scheduler.Record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	all, err := s.client.HGetAll(ctx, s.key("schedulers")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]scheduler.Record, 0, len(all))
+	for id, data := range all {
+		var rec scheduler.Record
+		if err := json.Unmarshal([]byte(data), &rec); err != nil {
+			return nil, i18n.Errorf("스케줄러 %s 레코드 파싱 오류: %w", id, err)
+		}
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].ID < records[b].ID })
+	return records, nil
+}
+
+// SaveExecution prepends an execution to the scheduler's capped history.
+func (s *Store) SaveExecution(e scheduler.Execution) error {
+	return s.pushCapped(s.key("executions", e.SchedulerID), e, maxExecutionsPerScheduler)
+}
+
+// ListExecutions returns the latest executions of a scheduler, newest first.
+func (s *Store) ListExecutions(schedulerID string, limit int) ([]scheduler.Execution, error) {
+	var list []scheduler.Execution
+	err := s.readList(s.key("executions", schedulerID), limit, func(data []byte) error {
+		var e scheduler.Execution
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		list = append(list, e)
+		return nil
+	})
+	return list, err
+}
+
+// ListExecutionsBetween returns the executions of every scheduler that
+// started in [from, to], oldest first. It reads the history of every
+// scheduler, so it's meant for occasional use such as the timeline.
+func (s *Store) ListExecutionsBetween(from, to time.Time) ([]scheduler.Execution, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	var keys []string
+	iter := s.client.Scan(ctx, 0, s.key("executions", "*"), 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	var list []scheduler.Execution
+	for _, key := range keys {
+		err := s.readList(key, 0, func(data []byte) error {
+			var e scheduler.Execution
+			if err := json.Unmarshal(data, &e); err != nil {
+				return err
+			}
+			if !e.StartedAt.Before(from) && !e.StartedAt.After(to) {
+				list = append(list, e)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(list, func(a, b int) bool { return list[a].StartedAt.Before(list[b].StartedAt) })
+	return list, nil
+}
+
+// Purge deletes the execution history and config versions of a scheduler.
+func (s *Store) Purge(schedulerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.Del(ctx, s.key("executions", schedulerID), s.key("versions", schedulerID)).Err()
+}
+
+// SaveAudit prepends an entry to the capped audit trail.
+func (s *Store) SaveAudit(e scheduler.AuditEntry) error {
+	return s.pushCapped(s.key("audit"), e, maxAuditEntries)
+}
+
+// ListAudit returns the latest audit entries, newest first.
+func (s *Store) ListAudit(limit int) ([]scheduler.AuditEntry, error) {
+	var list []scheduler.AuditEntry
+	err := s.readList(s.key("audit"), limit, func(data []byte) error {
+		var e scheduler.AuditEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		list = append(list, e)
+		return nil
+	})
+	return list, err
+}
+
+// SaveVersion appends a version to the config history of a scheduler.
+func (s *Store) SaveVersion(schedulerID string, v scheduler.Version) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.RPush(ctx, s.key("versions", schedulerID), data).Err()
+}
+
+// ListVersions returns the versions of a scheduler, oldest first.
+func (s *Store) ListVersions(schedulerID string) ([]scheduler.Version, error) {
+	var list []scheduler.Version
+	err := s.readList(s.key("versions", schedulerID), 0, func(data []byte) error {
+		var v scheduler.Version
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		list = append(list, v)
+		return nil
+	})
+	return list, err
+}
+
+// SaveTemplate creates or replaces the template with t.Name.
+func (s *Store) SaveTemplate(t scheduler.Template) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.HSet(ctx, s.key("templates"), t.Name, data).Err()
+}
+
+// DeleteTemplate removes the template with the given name, if any.
+func (s *Store) DeleteTemplate(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.HDel(ctx, s.key("templates"), name).Err()
+}
+
+// LoadTemplates returns every stored template, ordered by name.
+func (s *Store) LoadTemplates() ([]scheduler.Template, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	all, err := s.client.HGetAll(ctx, s.key("templates")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]scheduler.Template, 0, len(all))
+	for name, data := range all {
+		var t scheduler.Template
+		if err := json.Unmarshal([]byte(data), &t); err != nil {
+			return nil, i18n.Errorf("템플릿 %s 파싱 오류: %w", name, err)
+		}
+		list = append(list, t)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list, nil
+}
+
+// SaveVariable creates or replaces the variable with v.Name.
+func (s *Store) SaveVariable(v scheduler.Variable) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.HSet(ctx, s.key("variables"), v.Name, data).Err()
+}
+
+// DeleteVariable removes the variable with the given name, if any.
+func (s *Store) DeleteVariable(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return s.client.HDel(ctx, s.key("variables"), name).Err()
+}
+
+// LoadVariables returns every stored variable, ordered by name.
+func (s *Store) LoadVariables() ([]scheduler.Variable, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	all, err := s.client.HGetAll(ctx, s.key("variables")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]scheduler.Variable, 0, len(all))
+	for name, data := range all {
+		var v scheduler.Variable
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			return nil, i18n.Errorf("변수 %s 파싱 오류: %w", name, err)
+		}
+		list = append(list, v)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list, nil
+}
+
+// Claim takes the fire time with SET NX so only one instance executes it.
+func (s *Store) Claim(ctx context.Context, schedulerID string, fireTime time.Time, ttl time.Duration) (bool, error) {
+	key := s.key("claims", schedulerID, strconv.FormatInt(fireTime.UnixNano(), 10))
+	return s.client.SetNX(ctx, key, "1", ttl).Result()
+}
+
+// pushCapped prepends v as JSON to the list at key and trims it to max entries.
+func (s *Store) pushCapped(key string, v any, max int64) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	pipe := s.client.TxPipeline()
+	pipe.LPush(ctx, key, data)
+	pipe.LTrim(ctx, key, 0, max-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// readList calls fn for the first limit entries of the list at key. A
+// limit of zero or less reads the whole list.
+func (s *Store) readList(key string, limit int, fn func([]byte) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	items, err := s.client.LRange(ctx, key, 0, int64(limit)-1).Result()
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn([]byte(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
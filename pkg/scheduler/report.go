@@ -0,0 +1,343 @@
+// pkg/scheduler/report.go
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Report periods.
+const (
+	ReportDaily  = "daily"
+	ReportWeekly = "weekly"
+)
+
+// notifyReport is the Notification.Event of a summary report.
+const notifyReport = "report"
+
+// defaultReportTop is how many schedulers the lists of a report hold when
+// ReportConfig.Top is zero.
+const defaultReportTop = 5
+
+// ReportConfig configures the summary reports generated by RunReports.
+type ReportConfig struct {
+	// Period is ReportDaily or ReportWeekly.
+	Period string `json:"period"`
+	// At is the local time the reports are generated, as HH:mm. Empty
+	// means midnight.
+	At string `json:"at,omitempty"`
+	// Weekday is the day of weekly reports, e.g. "monday". Empty means
+	// Monday.
+	Weekday string `json:"weekday,omitempty"`
+	// Top bounds the failing and slowest schedulers and the upcoming runs
+	// listed. Zero means 5.
+	Top int `json:"top,omitempty"`
+	// Notify receives the reports. Nil means the server-wide notify
+	// settings.
+	Notify *NotifyConfig `json:"notify,omitempty"`
+}
+
+// Validate checks the settings.
+func (c ReportConfig) Validate() error {
+	if c.Period != ReportDaily && c.Period != ReportWeekly {
+		return i18n.Errorf("%w: reports.period는 daily 또는 weekly여야 합니다: %q", ErrInvalidConfig, c.Period)
+	}
+	if _, ok := c.at(); !ok {
+		return i18n.Errorf("%w: reports.at은 HH:mm 형식이어야 합니다: %q", ErrInvalidConfig, c.At)
+	}
+	if _, ok := c.weekday(); !ok {
+		return i18n.Errorf("%w: 알 수 없는 요일입니다: %q", ErrInvalidConfig, c.Weekday)
+	}
+	if c.Top < 0 {
+		return i18n.Errorf("%w: reports.top은 0 이상이어야 합니다", ErrInvalidConfig)
+	}
+	return c.Notify.validate()
+}
+
+// at returns the time of day of the reports since midnight.
+func (c ReportConfig) at() (time.Duration, bool) {
+	if c.At == "" {
+		return 0, true
+	}
+	t, err := time.Parse("15:04", c.At)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+// weekday returns the day of weekly reports.
+func (c ReportConfig) weekday() (time.Weekday, bool) {
+	if c.Weekday == "" {
+		return time.Monday, true
+	}
+	days, ok := parseWeekdays(c.Weekday)
+	if !ok || len(days) != 1 {
+		return 0, false
+	}
+	return days[0], true
+}
+
+// top returns the length of the lists of a report.
+func (c ReportConfig) top() int {
+	if c.Top == 0 {
+		return defaultReportTop
+	}
+	return c.Top
+}
+
+// days returns the length of the period in days.
+func (c ReportConfig) days() int {
+	if c.Period == ReportWeekly {
+		return 7
+	}
+	return 1
+}
+
+// next returns the first report time after t.
+func (c ReportConfig) next(t time.Time) time.Time {
+	at, _ := c.at()
+	t = t.In(time.Local)
+	next := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local).Add(at)
+	day, _ := c.weekday()
+	for !next.After(t) || c.Period == ReportWeekly && next.Weekday() != day {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Report summarizes the executions of every scheduler in a period and the
+// runs coming up in the next one.
+type Report struct {
+	// Period is ReportDaily or ReportWeekly, or empty for a report made on
+	// demand.
+	Period      string    `json:"period,omitempty"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// History reports whether executions are included, which needs a
+	// store that keeps execution history.
+	History bool `json:"history"`
+	// Schedulers counts the active schedulers.
+	Schedulers  int     `json:"schedulers"`
+	Runs        int     `json:"runs"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"successRate"`
+	// Failing lists the schedulers with the most failed executions, and
+	// Slowest those with the highest average latency.
+	Failing []ReportScheduler `json:"failing"`
+	Slowest []ReportScheduler `json:"slowest"`
+	// Upcoming lists the first runs after To, and UpcomingRuns counts the
+	// runs in the period after To.
+	Upcoming     []TimelineRun `json:"upcoming"`
+	UpcomingRuns int           `json:"upcomingRuns"`
+	// Summary is the report as text, in the default language.
+	Summary string `json:"summary"`
+}
+
+// ReportScheduler is the executions of a scheduler in a Report.
+type ReportScheduler struct {
+	SchedulerID  string  `json:"schedulerId"`
+	Name         string  `json:"name,omitempty"`
+	Group        string  `json:"group,omitempty"`
+	Runs         int     `json:"runs"`
+	Failures     int     `json:"failures"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	MaxLatencyMs float64 `json:"maxLatencyMs"`
+	// LastError is the error or status code of the latest failure.
+	LastError string `json:"lastError,omitempty"`
+
+	total  time.Duration
+	lastAt time.Time
+}
+
+// label returns the name of r, or its ID.
+func (r ReportScheduler) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.SchedulerID
+}
+
+// GenerateReport summarizes the executions that started in [from, to]
+// and the runs in the same length of time after to. Lists hold at most
+// top schedulers and runs.
+func (s *Scheduler) GenerateReport(period string, from, to time.Time, top int) (Report, error) {
+	entries := s.timelineEntries("")
+	executions, history, err := s.executionsBetween(from, to, entries)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Period:      period,
+		From:        from,
+		To:          to,
+		GeneratedAt: s.clock.Now(),
+		History:     history,
+		Schedulers:  len(entries),
+		Failing:     []ReportScheduler{},
+		Slowest:     []ReportScheduler{},
+		Upcoming:    []TimelineRun{},
+	}
+
+	configs := make(map[string]Config, len(entries))
+	for _, e := range entries {
+		configs[e.id] = e.config
+	}
+	byID := make(map[string]*ReportScheduler)
+	for _, x := range executions {
+		rs, ok := byID[x.SchedulerID]
+		if !ok {
+			c := configs[x.SchedulerID]
+			rs = &ReportScheduler{SchedulerID: x.SchedulerID, Name: c.Name, Group: c.Group}
+			byID[x.SchedulerID] = rs
+		}
+		r.Runs++
+		rs.Runs++
+		rs.total += x.Duration
+		rs.MaxLatencyMs = max(rs.MaxLatencyMs, ms(x.Duration))
+		if x.Success {
+			continue
+		}
+		r.Failures++
+		rs.Failures++
+		if x.StartedAt.After(rs.lastAt) {
+			rs.lastAt = x.StartedAt
+			rs.LastError = x.Error
+			if rs.LastError == "" {
+				rs.LastError = i18n.Sprintf(i18n.Default(), "상태 코드 %d", x.StatusCode)
+			}
+		}
+	}
+	if r.Runs > 0 {
+		r.SuccessRate = float64(r.Runs-r.Failures) / float64(r.Runs)
+	}
+
+	all := make([]ReportScheduler, 0, len(byID))
+	for _, rs := range byID {
+		rs.AvgLatencyMs = ms(rs.total / time.Duration(rs.Runs))
+		all = append(all, *rs)
+	}
+	sort.Slice(all, func(a, b int) bool { return all[a].SchedulerID < all[b].SchedulerID })
+	for _, rs := range all {
+		if rs.Failures > 0 {
+			r.Failing = append(r.Failing, rs)
+		}
+	}
+	sort.SliceStable(r.Failing, func(a, b int) bool { return r.Failing[a].Failures > r.Failing[b].Failures })
+	r.Slowest = append(r.Slowest, all...)
+	sort.SliceStable(r.Slowest, func(a, b int) bool { return r.Slowest[a].AvgLatencyMs > r.Slowest[b].AvgLatencyMs })
+	r.Failing = r.Failing[:min(top, len(r.Failing))]
+	r.Slowest = r.Slowest[:min(top, len(r.Slowest))]
+
+	var upcoming []TimelineRun
+	for _, e := range entries {
+		upcoming = append(upcoming, s.upcoming(e, to, to.Add(to.Sub(from)))...)
+	}
+	sortRuns(upcoming)
+	r.UpcomingRuns = len(upcoming)
+	r.Upcoming = append(r.Upcoming, upcoming[:min(top, len(upcoming))]...)
+
+	r.Summary = r.summary()
+	return r, nil
+}
+
+// summary returns the report as text.
+func (r Report) summary() string {
+	lang := i18n.Default()
+	from, to := r.From.Local().Format("2006-01-02 15:04"), r.To.Local().Format("2006-01-02 15:04")
+	var lines []string
+	switch r.Period {
+	case ReportDaily:
+		lines = append(lines, i18n.Sprintf(lang, "일간 요약 보고서: %s ~ %s", from, to))
+	case ReportWeekly:
+		lines = append(lines, i18n.Sprintf(lang, "주간 요약 보고서: %s ~ %s", from, to))
+	default:
+		lines = append(lines, i18n.Sprintf(lang, "요약 보고서: %s ~ %s", from, to))
+	}
+	if !r.History {
+		lines = append(lines, i18n.Sprintf(lang, "실행 기록을 보관하지 않아 실행 통계가 없습니다."))
+	} else {
+		lines = append(lines, i18n.Sprintf(lang, "스케줄러 %d개, 실행 %d회, 실패 %d회, 성공률 %.1f%%", r.Schedulers, r.Runs, r.Failures, r.SuccessRate*100))
+	}
+	if len(r.Failing) > 0 {
+		items := make([]string, len(r.Failing))
+		for i, rs := range r.Failing {
+			items[i] = i18n.Sprintf(lang, "%s (%d회)", rs.label(), rs.Failures)
+		}
+		lines = append(lines, i18n.Sprintf(lang, "실패가 많은 스케줄러: %s", strings.Join(items, ", ")))
+	}
+	if len(r.Slowest) > 0 {
+		items := make([]string, len(r.Slowest))
+		for i, rs := range r.Slowest {
+			items[i] = i18n.Sprintf(lang, "%s (평균 %.0fms)", rs.label(), rs.AvgLatencyMs)
+		}
+		lines = append(lines, i18n.Sprintf(lang, "가장 느린 스케줄러: %s", strings.Join(items, ", ")))
+	}
+	if len(r.Upcoming) > 0 {
+		next := r.Upcoming[0]
+		name := next.Name
+		if name == "" {
+			name = next.SchedulerID
+		}
+		lines = append(lines, i18n.Sprintf(lang, "예정된 실행 %d회, 다음 실행: %s %s", r.UpcomingRuns, name, next.Start.Local().Format("2006-01-02 15:04")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LatestReport returns the report last generated by RunReports or, before
+// the first one, a report on the period up to now.
+func (s *Scheduler) LatestReport() (Report, error) {
+	s.reportMu.Lock()
+	latest, c := s.report, s.reportConfig
+	s.reportMu.Unlock()
+	if latest != nil {
+		return *latest, nil
+	}
+	if c.Period == "" {
+		c.Period = ReportDaily
+	}
+	now := s.clock.Now()
+	return s.GenerateReport("", now.AddDate(0, 0, -c.days()), now, c.top())
+}
+
+// RunReports generates a report at the times set by c until ctx is done,
+// keeps it for LatestReport and sends it to the notification channels.
+// The channels get the Report as the payload of a "report" notification.
+func (s *Scheduler) RunReports(ctx context.Context, c ReportConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkNotifiers(c.Notify); err != nil {
+		return err
+	}
+	s.reportMu.Lock()
+	s.reportConfig = c
+	s.reportMu.Unlock()
+
+	for {
+		at := c.next(s.clock.Now())
+		if !s.clock.WaitUntil(ctx, at) {
+			return ctx.Err()
+		}
+		r, err := s.GenerateReport(c.Period, at.AddDate(0, 0, -c.days()), at, c.top())
+		if err != nil {
+			s.emit("", "요약 보고서 생성 오류: %v", err)
+			continue
+		}
+		s.reportMu.Lock()
+		s.report = &r
+		s.reportMu.Unlock()
+		s.emit("", "요약 보고서를 생성했습니다: 실행 %d회, 실패 %d회", r.Runs, r.Failures)
+
+		notify := c.Notify
+		if notify == nil {
+			notify = s.notifyConfig(Config{})
+		}
+		s.notifyEvent(notify, Notification{Event: notifyReport, Time: r.GeneratedAt, Payload: r})
+	}
+}
@@ -0,0 +1,62 @@
+// pkg/scheduler/retry.go
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// defaultRetryBackoff is the wait before the first retry when
+// RetryPolicy.Backoff is empty.
+const defaultRetryBackoff = time.Second
+
+// RetryPolicy re-executes a failed execution. The wait between attempts
+// starts at Backoff and doubles after every attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int `json:"maxAttempts"`
+	// Backoff is a Go duration string. Empty means 1s.
+	Backoff string `json:"backoff,omitempty"`
+}
+
+// validate checks the policy. A nil policy is valid.
+func (p *RetryPolicy) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 1 {
+		return i18n.Errorf("%w: retry.maxAttempts는 1 이상이어야 합니다", ErrInvalidConfig)
+	}
+	if _, err := p.backoff(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// attempts returns the total number of attempts, which is 1 without a policy.
+func (p *RetryPolicy) attempts() int {
+	if p == nil {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryable reports whether an execution that failed with err may be
+// retried. Payloads that can't be rendered fail the same way every time.
+func retryable(err error) bool {
+	return !errors.Is(err, ErrPayloadRender)
+}
+
+// backoff returns the wait before the first retry.
+func (p *RetryPolicy) backoff() (time.Duration, error) {
+	if p == nil || p.Backoff == "" {
+		return defaultRetryBackoff, nil
+	}
+	d, err := time.ParseDuration(p.Backoff)
+	if err != nil || d < 0 {
+		return 0, i18n.Errorf("%w: retry.backoff 파싱 오류: %q", ErrInvalidConfig, p.Backoff)
+	}
+	return d, nil
+}
@@ -0,0 +1,1230 @@
+// Package scheduler implements the scheduling engine used by the API
+// scheduler server. It has no dependency on the web server or the global
+// logger, so it can be embedded into other Go programs.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+var (
+	// ErrAlreadyExists is returned by Start when a scheduler with the same ID is already registered.
+	ErrAlreadyExists = errors.New("scheduler: already exists")
+	// ErrNotFound is returned when no scheduler is registered under the given ID.
+	ErrNotFound = errors.New("scheduler: not found")
+	// ErrUnknownJobType is returned by Start when no executor is registered for the job type.
+	ErrUnknownJobType = errors.New("scheduler: unknown job type")
+	// ErrInvalidConfig is returned by Start when the configuration is invalid.
+	ErrInvalidConfig = errors.New("scheduler: invalid config")
+)
+
+// maxStoredOutput bounds the output kept in a stored Execution.
+const maxStoredOutput = 64 << 10
+
+// maxNameLength bounds Config.Name.
+const maxNameLength = 200
+
+const (
+	// minInterval is the shortest repeat interval.
+	minInterval = time.Second
+	// minFastInterval is the shortest repeat interval of a scheduler with
+	// AllowFastInterval.
+	minFastInterval = 10 * time.Millisecond
+)
+
+// Config holds the user's scheduler configuration.
+type Config struct {
+	// SchemaVersion is the version of the format the config was written
+	// in; see the SchemaVersion constant. Zero is taken as the current
+	// version for configs built in Go.
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+	// Name and Description are free text shown to people instead of the ID.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Group and Labels organize schedulers for filtering.
+	Group  string            `json:"group,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	// Owner is the user who created the scheduler. It selects the quota
+	// and role the scheduler counts against; see Limits.
+	Owner string `json:"owner,omitempty"`
+	// Priority orders the executions waiting for a slot when
+	// maxConcurrentExecutions is reached: higher priorities run first,
+	// equal ones in the order they fired. Zero is the default; bulk jobs
+	// can use negative priorities.
+	Priority int `json:"priority,omitempty"`
+
+	// Type selects the executor that runs the job. Empty means JobTypeHTTP.
+	Type        string `json:"type,omitempty"`
+	StartTime   string `json:"startTime"`
+	RepeatValue int    `json:"repeatValue"`
+	RepeatUnit  string `json:"repeatUnit"`
+	// Interval is the repeat interval as a Go duration string such as
+	// "1h30m". When set, it's used instead of RepeatValue and RepeatUnit.
+	Interval string `json:"interval,omitempty"`
+	// AllowFastInterval allows intervals shorter than a second, down to
+	// 10ms, e.g. for load generation.
+	AllowFastInterval bool `json:"allowFastInterval,omitempty"`
+
+	// APIURL may be a path such as "/v1/orders", which is appended to the
+	// base URL of the profile.
+	APIURL     string `json:"apiURL"`
+	HTTPMethod string `json:"httpMethod"`
+	Payload    string `json:"payload"`
+	// PayloadType is how the payload is sent: PayloadForm (default),
+	// PayloadJSON, PayloadText or PayloadBinary. Types other than
+	// PayloadForm need a POST request.
+	PayloadType string `json:"payloadType,omitempty"`
+	// ContentType is the Content-Type of PayloadJSON, PayloadText and
+	// PayloadBinary bodies. Empty means application/json for PayloadJSON;
+	// the others need it.
+	ContentType string `json:"contentType,omitempty"`
+	// RawQuery appends the payload of GET requests to the query of APIURL
+	// as it is, such as "a=1&a=2", instead of reading fields from JSON.
+	RawQuery bool `json:"rawQuery,omitempty"`
+	// Profile names the environment profile the scheduler runs against,
+	// whatever the active one is. Empty means the active profile, which
+	// only schedulers with a relative APIURL use.
+	Profile string `json:"profile,omitempty"`
+	// PayloadFrom reads the payload of HTTP requests from a file or URL on
+	// every execution, replacing Payload.
+	PayloadFrom *PayloadSource `json:"payloadFrom,omitempty"`
+	// MisfirePolicy is one of MisfireRunOnce (default), MisfireRunAll or MisfireSkip.
+	MisfirePolicy string `json:"misfirePolicy,omitempty"`
+	// CatchUpWindow is a Go duration string bounding how far back runs
+	// missed while the process was down are executed after a restore.
+	// Empty means missed runs are not caught up.
+	CatchUpWindow string `json:"catchUpWindow,omitempty"`
+	// Calendar restricts the days on which the job runs.
+	Calendar *CalendarConfig `json:"calendar,omitempty"`
+	// Blackouts are windows in which fire times are skipped, in addition
+	// to the scheduler-wide ones.
+	Blackouts []BlackoutWindow `json:"blackouts,omitempty"`
+
+	// Command configures JobTypeCommand jobs.
+	Command *CommandConfig `json:"command,omitempty"`
+	// GRPC configures JobTypeGRPC jobs.
+	GRPC *GRPCConfig `json:"grpc,omitempty"`
+	// Publish configures JobTypeKafka and JobTypeAMQP jobs.
+	Publish *PublishConfig `json:"publish,omitempty"`
+	// GraphQL configures JobTypeGraphQL jobs.
+	GraphQL *GraphQLConfig `json:"graphql,omitempty"`
+	// Load configures JobTypeLoad jobs.
+	Load *LoadConfig `json:"load,omitempty"`
+	// Chain configures JobTypeChain jobs.
+	Chain *ChainConfig `json:"chain,omitempty"`
+	// Workflow configures JobTypeWorkflow jobs.
+	Workflow *WorkflowConfig `json:"workflow,omitempty"`
+	// Fanout configures JobTypeFanout jobs.
+	Fanout *FanoutConfig `json:"fanout,omitempty"`
+	// Monitor configures JobTypeMonitor jobs.
+	Monitor *MonitorConfig `json:"monitor,omitempty"`
+
+	// Transport configures the HTTP transport used by HTTP-based job types.
+	Transport *TransportConfig `json:"transport,omitempty"`
+	// Headers are added to the requests of HTTP-based job types.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Files make HTTP requests multipart/form-data POSTs, with the payload
+	// fields as form fields followed by the files.
+	Files []FilePart `json:"files,omitempty"`
+	// Auth authenticates the requests of HTTP-based job types.
+	Auth *AuthConfig `json:"auth,omitempty"`
+	// Signing signs the requests of HTTP-based job types.
+	Signing *SigningConfig `json:"signing,omitempty"`
+	// Session keeps cookies across the executions of HTTP-based job types.
+	Session *SessionConfig `json:"session,omitempty"`
+
+	// Retry re-executes a failed execution before the next fire time.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// IdempotencyKey sends a new UUID in an Idempotency-Key header on
+	// every execution, the same for all of its retries, so that APIs
+	// supporting the header don't apply a retried request twice.
+	IdempotencyKey bool `json:"idempotencyKey,omitempty"`
+	// LogLevel is the verbosity of the scheduler's log: LogDebug, LogInfo
+	// (default), LogWarn or LogError. The scheduler starting and stopping
+	// is always logged.
+	LogLevel string `json:"logLevel,omitempty"`
+	// RestartPolicy is RestartNever (default), RestartOnCrash, which
+	// starts the scheduler again when its goroutine crashed on a panic, or
+	// RestartOnFailureStreak, which also does when RestartThreshold
+	// executions failed in a row.
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	// RestartThreshold is the failure streak for RestartOnFailureStreak.
+	// Zero means 5.
+	RestartThreshold int `json:"restartThreshold,omitempty"`
+	// RestartCooldown is a Go duration string, how long a failed scheduler
+	// waits before it is started again. Empty means 10s.
+	RestartCooldown string `json:"restartCooldown,omitempty"`
+	// Notify sends the result of executions to a webhook.
+	Notify *NotifyConfig `json:"notify,omitempty"`
+	// SLA is the deadline and success rate the scheduler must meet.
+	SLA *SLAConfig `json:"sla,omitempty"`
+	// OnlyIf and SkipIf are conditions on the previous execution, such as
+	// "last.failed" or "last.body.status != 'ok'", checked before each
+	// execution: it's skipped unless OnlyIf holds or when SkipIf holds.
+	// The first execution always runs.
+	OnlyIf string `json:"onlyIf,omitempty"`
+	SkipIf string `json:"skipIf,omitempty"`
+	// Archive keeps the response bodies of executions in a directory or
+	// bucket.
+	Archive *ArchiveConfig `json:"archive,omitempty"`
+	// Diff compares each successful response with the previous one. Only
+	// changed responses are logged and notified.
+	Diff *DiffConfig `json:"diff,omitempty"`
+	// Transform reshapes each successful response before it is recorded,
+	// archived, forwarded or notified.
+	Transform *TransformConfig `json:"transform,omitempty"`
+	// OnSuccessTrigger and OnFailureTrigger are the IDs of the schedulers
+	// executed right away, besides their own fire times, when a run of
+	// this scheduler finally succeeds or fails.
+	OnSuccessTrigger []string `json:"onSuccessTrigger,omitempty"`
+	OnFailureTrigger []string `json:"onFailureTrigger,omitempty"`
+	// Forward sends the response of each successful execution to a
+	// downstream URL.
+	Forward *ForwardConfig `json:"forward,omitempty"`
+	// Metrics export numbers read from each successful JSON response as
+	// Prometheus gauges.
+	Metrics []MetricExtraction `json:"metrics,omitempty"`
+	// StopOnSuccess stops the scheduler after a successful execution.
+	// Empty means true, except for schedulers with Diff, Forward or
+	// Metrics and monitors, which keep polling.
+	StopOnSuccess *bool `json:"stopOnSuccess,omitempty"`
+
+	// Template names the Template whose settings fill the fields left
+	// empty here. It is resolved when the scheduler starts.
+	Template string `json:"template,omitempty"`
+}
+
+// jobType returns the configured job type, defaulting to JobTypeHTTP.
+func (c Config) jobType() string {
+	if c.Type == "" {
+		return JobTypeHTTP
+	}
+	return c.Type
+}
+
+// interval returns the repeat interval.
+func (c Config) interval() (time.Duration, error) {
+	var d time.Duration
+	if c.Interval != "" {
+		var err error
+		if d, err = time.ParseDuration(c.Interval); err != nil {
+			return 0, i18n.Errorf("%w: interval 파싱 오류: %v", ErrInvalidConfig, err)
+		}
+	} else {
+		var unit time.Duration
+		switch c.RepeatUnit {
+		case "h":
+			unit = time.Hour
+		case "m":
+			unit = time.Minute
+		case "s":
+			unit = time.Second
+		case "ms":
+			unit = time.Millisecond
+		default:
+			return 0, i18n.Errorf("%w: 유효하지 않은 반복 단위입니다: %q", ErrInvalidConfig, c.RepeatUnit)
+		}
+		if c.RepeatValue <= 0 {
+			return 0, i18n.Errorf("%w: 반복 값은 1 이상이어야 합니다", ErrInvalidConfig)
+		}
+		d = time.Duration(c.RepeatValue) * unit
+	}
+
+	switch {
+	case d < minFastInterval:
+		return 0, i18n.Errorf("%w: 반복 간격은 %s 이상이어야 합니다: %s", ErrInvalidConfig, minFastInterval, d)
+	case d < minInterval && !c.AllowFastInterval:
+		return 0, i18n.Errorf("%w: 1초보다 짧은 반복 간격에는 allowFastInterval이 필요합니다: %s", ErrInvalidConfig, d)
+	}
+	return d, nil
+}
+
+// stopsOnSuccess reports whether a successful execution stops the scheduler.
+func (c Config) stopsOnSuccess() bool {
+	if c.StopOnSuccess != nil {
+		return *c.StopOnSuccess
+	}
+	return c.Diff == nil && c.Forward == nil && len(c.Metrics) == 0 && c.jobType() != JobTypeMonitor
+}
+
+// firstFire returns the next occurrence of StartTime (HH:mm:ss, local time)
+// at or after now.
+func (c Config) firstFire(now time.Time) (time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", now.Format("2006-01-02")+" "+c.StartTime, time.Local)
+	if err != nil {
+		return time.Time{}, i18n.Errorf("%w: 시작 시간 파싱 오류: %v", ErrInvalidConfig, err)
+	}
+	if t.Before(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// catchUpWindow returns the parsed CatchUpWindow, or zero when unset.
+func (c Config) catchUpWindow() (time.Duration, error) {
+	if c.CatchUpWindow == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.CatchUpWindow)
+	if err != nil {
+		return 0, i18n.Errorf("%w: catchUpWindow 파싱 오류: %v", ErrInvalidConfig, err)
+	}
+	return d, nil
+}
+
+// validate checks the parts of the configuration shared by all job types.
+func (c Config) validate() error {
+	if _, err := c.interval(); err != nil {
+		return err
+	}
+	return c.validatePartial()
+}
+
+// validatePartial checks the optional settings, which is all a Template
+// needs to pass.
+func (c Config) validatePartial() error {
+	if err := c.validateSchema(); err != nil {
+		return err
+	}
+	if len(c.Name) > maxNameLength {
+		return i18n.Errorf("%w: 이름은 %d자를 넘을 수 없습니다", ErrInvalidConfig, maxNameLength)
+	}
+	if !validMisfirePolicy(c.MisfirePolicy) {
+		return i18n.Errorf("%w: 알 수 없는 misfire 정책입니다: %q", ErrInvalidConfig, c.MisfirePolicy)
+	}
+	if _, err := c.catchUpWindow(); err != nil {
+		return err
+	}
+	if err := c.Calendar.validate(); err != nil {
+		return err
+	}
+	for _, w := range c.Blackouts {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.Transport.validate(); err != nil {
+		return err
+	}
+	if err := c.validatePayload(); err != nil {
+		return err
+	}
+	if err := c.PayloadFrom.validate(); err != nil {
+		return err
+	}
+	if err := validateFiles(c.Files); err != nil {
+		return err
+	}
+	if err := c.Auth.validate(); err != nil {
+		return err
+	}
+	if err := c.Signing.validate(); err != nil {
+		return err
+	}
+	if err := c.Session.validate(); err != nil {
+		return err
+	}
+	if c.Auth != nil && c.Signing != nil && c.Signing.AWS != nil {
+		return i18n.Errorf("%w: auth와 signing.aws는 함께 쓸 수 없습니다", ErrInvalidConfig)
+	}
+	if err := c.Load.validate(); err != nil {
+		return err
+	}
+	if err := c.Chain.validate(); err != nil {
+		return err
+	}
+	if err := c.Workflow.validate(); err != nil {
+		return err
+	}
+	if err := c.Fanout.validate(); err != nil {
+		return err
+	}
+	if err := c.Monitor.validate(); err != nil {
+		return err
+	}
+	if err := c.Retry.validate(); err != nil {
+		return err
+	}
+	if err := c.Notify.validate(); err != nil {
+		return err
+	}
+	if err := c.SLA.validate(); err != nil {
+		return err
+	}
+	if err := validateLogLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if err := c.validateRestart(); err != nil {
+		return err
+	}
+	if err := c.Archive.validate(); err != nil {
+		return err
+	}
+	if err := c.Diff.validate(); err != nil {
+		return err
+	}
+	if err := c.validateTriggers(); err != nil {
+		return err
+	}
+	if err := c.Transform.validate(); err != nil {
+		return err
+	}
+	if err := c.Forward.validate(); err != nil {
+		return err
+	}
+	if err := validateMetrics(c.Metrics); err != nil {
+		return err
+	}
+	for name, expr := range map[string]string{"onlyIf": c.OnlyIf, "skipIf": c.SkipIf} {
+		if expr == "" {
+			continue
+		}
+		if _, err := parseCondition(expr); err != nil {
+			return i18n.Errorf("%w: %s 식 오류: %v", ErrInvalidConfig, name, err)
+		}
+	}
+	return nil
+}
+
+// transport returns the configured transport settings, or the defaults.
+func (c Config) transport() TransportConfig {
+	if c.Transport == nil {
+		return TransportConfig{}
+	}
+	return *c.Transport
+}
+
+// Record is the persisted state of a scheduler.
+type Record struct {
+	ID     string `json:"id"`
+	Config Config `json:"config"`
+	// Anchor is the first fire time, which the interval is counted from.
+	Anchor time.Time `json:"anchor"`
+	// LastFire is when the most recent fire time was handled.
+	LastFire time.Time `json:"lastFire,omitempty"`
+	// NextFire is the fire time the scheduler waited for when it was saved.
+	NextFire time.Time `json:"nextFire,omitempty"`
+	// State is StateStopped or StateCompleted for a scheduler that was
+	// stopped, which is restored without running. It is empty otherwise.
+	State string `json:"state,omitempty"`
+}
+
+// Store persists scheduler records so schedulers survive a restart.
+type Store interface {
+	// Save creates or replaces the record with rec.ID.
+	Save(rec Record) error
+	// Delete removes the record with the given ID, if any.
+	Delete(id string) error
+	// Load returns every stored record.
+	Load() ([]Record, error)
+}
+
+// Execution is the record of a single job execution.
+type Execution struct {
+	// ID identifies the run the execution belongs to. Retries of a run
+	// share it; it is also sent in the X-Execution-Id request header and
+	// set on the run's log events.
+	ID          string        `json:"id,omitempty"`
+	SchedulerID string        `json:"schedulerId"`
+	StartedAt   time.Time     `json:"startedAt"`
+	Duration    time.Duration `json:"duration"`
+	Success     bool          `json:"success"`
+	StatusCode  int           `json:"statusCode,omitempty"`
+	Output      string        `json:"output,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	// ErrorCode classifies Error for the failures that are told apart,
+	// such as ErrorCodePayloadRender.
+	ErrorCode string `json:"errorCode,omitempty"`
+	// Changes lists how the response differs from the previous one, for
+	// schedulers with Config.Diff.
+	Changes []ResponseChange `json:"changes,omitempty"`
+	// Timing is the connection timing of a failed HTTP request.
+	Timing *RequestTiming `json:"timing,omitempty"`
+	// TriggeredBy is the ID of the scheduler whose OnSuccessTrigger or
+	// OnFailureTrigger started the run, empty for runs at a fire time.
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+}
+
+// AuditEntry records a management action taken on a scheduler.
+type AuditEntry struct {
+	Time        time.Time `json:"time"`
+	SchedulerID string    `json:"schedulerId"`
+	// Action is one of "start", "stop", "delete", "pause", "resume",
+	// "restart", "restore", "clone", "update" or "rollback". Detail holds
+	// the source ID of a clone, the restored version of a rollback and the
+	// panic that led to a restart.
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ExecutionStore is implemented by stores that also keep execution history.
+type ExecutionStore interface {
+	SaveExecution(e Execution) error
+	// ListExecutions returns the latest executions of a scheduler, newest
+	// first. A limit of zero or less returns all of them.
+	ListExecutions(schedulerID string, limit int) ([]Execution, error)
+}
+
+// ExecutionRangeStore is implemented by execution stores that can list the
+// executions of every scheduler, including removed ones, by time.
+type ExecutionRangeStore interface {
+	// ListExecutionsBetween returns the executions that started in
+	// [from, to], oldest first.
+	ListExecutionsBetween(from, to time.Time) ([]Execution, error)
+}
+
+// PurgeStore is implemented by stores that can delete the execution
+// history and configuration versions of a scheduler, which Delete does.
+type PurgeStore interface {
+	Purge(schedulerID string) error
+}
+
+// AuditStore is implemented by stores that also keep an audit trail.
+type AuditStore interface {
+	SaveAudit(e AuditEntry) error
+	// ListAudit returns the latest audit entries, newest first. A limit of
+	// zero or less returns all of them.
+	ListAudit(limit int) ([]AuditEntry, error)
+}
+
+// Locker coordinates executions between instances that share a store, so
+// that each fire time is executed by only one of them.
+type Locker interface {
+	// Claim tries to take the fire time of a scheduler for this instance.
+	// It reports false when another instance already claimed it. Claims
+	// expire after ttl.
+	Claim(ctx context.Context, schedulerID string, fireTime time.Time, ttl time.Duration) (bool, error)
+}
+
+// Status describes a registered scheduler.
+type Status struct {
+	ID string `json:"id"`
+	// State is one of the State constants, entered at StateSince.
+	State      string    `json:"state"`
+	StateSince time.Time `json:"stateSince"`
+	// Transitions are the latest state changes, oldest first.
+	Transitions []Transition `json:"transitions"`
+	// Running reports whether the scheduler can still execute: it is
+	// pending, waiting, running or paused.
+	Running bool `json:"running"`
+	// NextRun is the fire time the scheduler waits for.
+	NextRun time.Time `json:"nextRun"`
+	// Failures counts the failed executions since the scheduler started.
+	Failures int `json:"failures"`
+	// Monitor is the state of JobTypeMonitor schedulers.
+	Monitor *MonitorStatus `json:"monitor,omitempty"`
+	// Crash is set when the scheduler failed on a panic or a failure
+	// streak.
+	Crash *Crash `json:"crash,omitempty"`
+	// Heartbeat is the last sign of life of the run loop, and Stall is
+	// set while CheckStalls finds it stuck.
+	Heartbeat time.Time `json:"heartbeat,omitempty"`
+	Stall     *Stall    `json:"stall,omitempty"`
+	// SLA is how a scheduler with Config.SLA has met it.
+	SLA *SLAStatus `json:"sla,omitempty"`
+	// Metrics are the latest values of Config.Metrics. A value missing
+	// from the last successful response is left out.
+	Metrics []MetricSample `json:"metrics,omitempty"`
+	Config  Config         `json:"config"`
+}
+
+// Scheduler manages a set of scheduler jobs.
+type Scheduler struct {
+	// mu protects concurrent access to the jobs map.
+	mu   sync.Mutex
+	jobs map[string]*job
+
+	// bus delivers events to the subscribers.
+	bus bus
+
+	// execMu protects the executors and notifiers maps.
+	execMu    sync.RWMutex
+	executors map[string]Executor
+	notifiers map[string]Notifier
+
+	// pool bounds concurrent executions across all jobs.
+	pool *pool
+	// clients provides shared HTTP clients to the HTTP-based executors.
+	clients *ClientManager
+	// fileRoot is the directory local payload files are read from.
+	fileRoot string
+	// archiveRoot is the directory local archives are kept below.
+	archiveRoot string
+	// awsEnvCredentials lets SigV4 read the AWS_* environment variables.
+	awsEnvCredentials bool
+	// store persists scheduler records. It is nil when persistence is off.
+	store Store
+	// locker claims fire times when several instances share the store.
+	locker Locker
+	// redactor masks secrets in events and stored executions.
+	redactor *Redactor
+	// settings are the server-wide settings changed by Reconfigure.
+	settings settings
+	// maint is the maintenance mode set by SetMaintenance.
+	maint maintenance
+	// clock tells the time of fire times, executions and events.
+	clock Clock
+	// limits are the quotas enforced by Start and Update.
+	limits Limits
+	// syncMu keeps Sync from reconciling while Start adds and saves a job.
+	syncMu sync.Mutex
+
+	// verMu protects the versions map, which keeps the config history
+	// when the store does not.
+	verMu    sync.Mutex
+	versions map[string][]Version
+
+	// tmplMu protects the templates map.
+	tmplMu    sync.RWMutex
+	templates map[string]Template
+
+	// varMu protects the variables map.
+	varMu     sync.RWMutex
+	variables map[string]Variable
+
+	// holidays caches the holiday calendars of the jobs' calendars.
+	holidays holidayCache
+
+	// apprMu protects the approvals map, which holds the workflow steps
+	// waiting for approval by execution ID and step name.
+	apprMu    sync.Mutex
+	approvals map[string]*pendingApproval
+
+	// reportMu protects the report last generated by RunReports and its
+	// settings.
+	reportMu     sync.Mutex
+	report       *Report
+	reportConfig ReportConfig
+}
+
+// Option configures a Scheduler created by New.
+type Option func(*Scheduler)
+
+// WithMaxConcurrent limits how many executions may run at the same time
+// across all schedulers. Executions beyond the limit wait in a queue. Zero
+// or less means unlimited, which is the default.
+func WithMaxConcurrent(n int) Option {
+	return func(s *Scheduler) {
+		s.pool = newPool(n)
+	}
+}
+
+// WithClientManager sets the ClientManager used by the built-in HTTP and
+// GraphQL executors. By default New creates one with default settings.
+func WithClientManager(m *ClientManager) Option {
+	return func(s *Scheduler) {
+		s.clients = m
+	}
+}
+
+// WithStore persists schedulers to st so they can be restored with Restore
+// after a restart.
+func WithStore(st Store) Option {
+	return func(s *Scheduler) {
+		s.store = st
+	}
+}
+
+// WithLocker makes every fire time be claimed through l before it is
+// executed, so that instances sharing a store don't execute it twice.
+func WithLocker(l Locker) Option {
+	return func(s *Scheduler) {
+		s.locker = l
+	}
+}
+
+// WithRedactor masks secrets in events and stored executions with r. By
+// default only the values of well-known credential headers are masked.
+func WithRedactor(r *Redactor) Option {
+	return func(s *Scheduler) {
+		s.redactor = r
+	}
+}
+
+// New creates an empty Scheduler with the HTTP, GraphQL, load and no-op
+// executors registered.
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		jobs:      make(map[string]*job),
+		executors: make(map[string]Executor),
+		notifiers: make(map[string]Notifier),
+		templates: make(map[string]Template),
+		variables: make(map[string]Variable),
+		versions:  make(map[string][]Version),
+		approvals: make(map[string]*pendingApproval),
+		pool:      newPool(0),
+		clients:   NewClientManager(0, 0),
+		settings:  settings{defaultHeaders: builtinHeaders},
+		clock:     systemClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.clients.SetFileRoot(s.fileRoot)
+	if s.redactor == nil {
+		s.redactor, _ = NewRedactor(nil, nil, nil)
+	}
+	if es, ok := s.store.(ExecutionStore); ok {
+		s.AddSubscriber(historySubscriber{s: s, store: es})
+	}
+	s.AddSubscriber(notifySubscriber{s: s})
+	s.RegisterExecutor(JobTypeHTTP, &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot})
+	s.RegisterExecutor(JobTypeGraphQL, &GraphQLExecutor{Clients: s.clients})
+	s.RegisterExecutor(JobTypeLoad, &LoadExecutor{Executor: &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot}})
+	s.RegisterExecutor(JobTypeChain, &ChainExecutor{Executor: &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot}})
+	s.RegisterExecutor(JobTypeWorkflow, &WorkflowExecutor{Executor: &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot}})
+	s.RegisterNotifier(ChannelWebhook, &WebhookNotifier{Clients: s.clients})
+	s.RegisterNotifier(ChannelSlack, &SlackNotifier{Clients: s.clients})
+	s.RegisterNotifier(ChannelDiscord, &DiscordNotifier{Clients: s.clients})
+	s.RegisterNotifier(ChannelPagerDuty, &PagerDutyNotifier{Clients: s.clients})
+	s.RegisterNotifier(ChannelOpsgenie, &OpsgenieNotifier{Clients: s.clients})
+	s.RegisterExecutor(JobTypeFanout, &FanoutExecutor{Executor: &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot}})
+	s.RegisterExecutor(JobTypeMonitor, &HTTPExecutor{Clients: s.clients, FileRoot: s.fileRoot})
+	s.RegisterExecutor(JobTypeNoop, NoopExecutor{})
+	return s
+}
+
+// RegisterExecutor registers the executor used for jobs of the given type,
+// replacing any executor previously registered for it.
+func (s *Scheduler) RegisterExecutor(jobType string, e Executor) {
+	s.execMu.Lock()
+	defer s.execMu.Unlock()
+	s.executors[jobType] = e
+}
+
+// executor returns the executor registered for the given job type.
+func (s *Scheduler) executor(jobType string) (Executor, bool) {
+	s.execMu.RLock()
+	defer s.execMu.RUnlock()
+	e, ok := s.executors[jobType]
+	return e, ok
+}
+
+// Start starts a new scheduler instance under the given ID. Use NewID for
+// an ID that is unique. It fails with ErrLimitExceeded when the scheduler
+// would exceed the limits set with WithLimits.
+func (s *Scheduler) Start(id string, config Config) error {
+	return s.start(id, config, false)
+}
+
+// start starts a scheduler under id. Only with restart may it replace a
+// stopped, completed or failed scheduler registered under the same ID.
+func (s *Scheduler) start(id string, config Config, restart bool) error {
+	if id == "" {
+		return i18n.Errorf("%w: 스케줄러 ID가 필요합니다", ErrInvalidConfig)
+	}
+	config, err := s.applyTemplate(config)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(config); err != nil {
+		return err
+	}
+	anchor, err := config.firstFire(s.clock.Now())
+	if err != nil {
+		return err
+	}
+	interval, _ := config.interval()
+
+	// Sync must not see the job before it is saved, or it would stop it.
+	s.syncMu.Lock()
+	j, err := s.add(id, config, anchor, interval, time.Time{}, true, restart)
+	if err != nil {
+		s.syncMu.Unlock()
+		return err
+	}
+	s.persist(j)
+	s.syncMu.Unlock()
+	s.audit(id, "start", "")
+	s.saveVersion(id, "start", config)
+
+	go j.run()
+	return nil
+}
+
+// Clone starts a new scheduler under newID with the configuration of the
+// scheduler registered under id. Fields set in overrides replace the copied
+// ones, except Owner: the clone belongs to overrides.Owner.
+func (s *Scheduler) Clone(id, newID string, overrides Config) error {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	var config Config
+	if ok {
+		config = j.config
+	}
+	s.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	config = mergeConfig(config, overrides)
+	config.Owner = overrides.Owner
+	if err := s.Start(newID, config); err != nil {
+		return err
+	}
+	s.audit(newID, "clone", id)
+	return nil
+}
+
+// Restore starts every scheduler saved in the store. Runs missed while the
+// process was down are caught up according to each scheduler's
+// CatchUpWindow and MisfirePolicy. Templates and variables saved in the
+// store are loaded first.
+func (s *Scheduler) Restore() error {
+	if s.store == nil {
+		return nil
+	}
+	if err := s.loadTemplates(); err != nil {
+		return err
+	}
+	if err := s.loadVariables(); err != nil {
+		return err
+	}
+	records, err := s.store.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if err := s.resume(rec); err == nil {
+			s.audit(rec.ID, "restore", "")
+		}
+	}
+	return nil
+}
+
+// resume starts a job from a stored record.
+func (s *Scheduler) resume(rec Record) error {
+	if err := s.validate(rec.Config); err != nil {
+		s.emit(rec.ID, "저장된 스케줄러를 복원할 수 없습니다: %v", err)
+		return err
+	}
+	interval, _ := rec.Config.interval()
+	lastFire := rec.LastFire
+	if lastFire.IsZero() && !rec.Anchor.After(s.clock.Now()) {
+		// The start time passed while the process was down.
+		lastFire = rec.Anchor
+	}
+
+	j, err := s.add(rec.ID, rec.Config, rec.Anchor, interval, lastFire, false, true)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if !rec.NextFire.IsZero() {
+		j.next = rec.NextFire
+	}
+	stopped := rec.State == StateStopped || rec.State == StateCompleted
+	if stopped {
+		j.recordState(j.state, rec.State)
+	}
+	s.mu.Unlock()
+	if !stopped {
+		go j.run()
+	}
+	return nil
+}
+
+// Sync reconciles the schedulers with the store: schedulers saved or
+// started again by other instances are started, and schedulers they
+// stopped or deleted are stopped. It is meant to be called periodically
+// when several instances share a store.
+func (s *Scheduler) Sync() error {
+	if s.store == nil {
+		return nil
+	}
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	records, err := s.store.Load()
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]Record, len(records))
+	for _, rec := range records {
+		stored[rec.ID] = rec
+	}
+
+	type change struct {
+		j       *job
+		state   string
+		message string
+	}
+	var changes []change
+	s.mu.Lock()
+	for id, j := range s.jobs {
+		rec, ok := stored[id]
+		switch {
+		case !ok:
+			if to, err := j.stopLocked(); err == nil {
+				changes = append(changes, change{j, to, "다른 인스턴스에서 삭제되어 스케줄러를 중지합니다."})
+			}
+			delete(s.jobs, id)
+		case rec.State != "" && active(j.state):
+			if to, err := j.stopLocked(); err == nil {
+				j.finalState = rec.State
+				changes = append(changes, change{j, to, "다른 인스턴스에서 중지되어 스케줄러를 중지합니다."})
+			}
+		}
+	}
+	// Only stopped schedulers that run again in the store are replaced.
+	keep := make(map[string]bool, len(s.jobs))
+	for id, j := range s.jobs {
+		keep[id] = j.state != StateStopped && j.state != StateCompleted || stored[id].State != ""
+	}
+	s.mu.Unlock()
+
+	for _, c := range changes {
+		c.j.publishState(c.state)
+		s.emit(c.j.id, c.message)
+	}
+	for _, rec := range records {
+		if keep[rec.ID] {
+			continue
+		}
+		if err := s.resume(rec); err == nil && rec.State == "" {
+			s.emit(rec.ID, "다른 인스턴스에서 등록된 스케줄러를 시작합니다.")
+		}
+	}
+	return nil
+}
+
+// validate checks config before a job is created from it.
+func (s *Scheduler) validate(config Config) error {
+	if _, ok := s.executor(config.jobType()); !ok {
+		return ErrUnknownJobType
+	}
+	if err := s.checkNotifiers(config.Notify); err != nil {
+		return err
+	}
+	if err := s.checkProfile(config); err != nil {
+		return err
+	}
+	if err := s.checkEnv(config); err != nil {
+		return err
+	}
+	if err := s.checkFiles(config); err != nil {
+		return err
+	}
+	if err := s.checkArchive(config); err != nil {
+		return err
+	}
+	return config.validate()
+}
+
+// add registers a new job under id. It fails with ErrAlreadyExists when
+// a job is registered under id, unless replace is set and that job has
+// stopped. When limited, the job must be within the limits of s.
+func (s *Scheduler) add(id string, config Config, anchor time.Time, interval time.Duration, lastFire time.Time, limited, replace bool) (*job, error) {
+	s.mu.Lock()
+	if old, ok := s.jobs[id]; ok {
+		switch {
+		case active(old.state) || old.state == StateStopping:
+			s.mu.Unlock()
+			s.emit(id, "스케줄러가 이미 실행 중입니다. 새로운 요청을 무시합니다.")
+			return nil, ErrAlreadyExists
+		case !replace:
+			s.mu.Unlock()
+			s.emit(id, "같은 ID의 스케줄러가 이미 등록되어 있습니다. 새로운 요청을 무시합니다.")
+			return nil, ErrAlreadyExists
+		}
+	}
+	if limited {
+		if err := s.checkLimitsLocked(id, config); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	config.SchemaVersion = SchemaVersion
+	j := s.newJob(id, config, anchor, interval, lastFire)
+	s.jobs[id] = j
+	s.mu.Unlock()
+	return j, nil
+}
+
+// newJob creates a job that is not registered yet.
+func (s *Scheduler) newJob(id string, config Config, anchor time.Time, interval time.Duration, lastFire time.Time) *job {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		id:       id,
+		sched:    s,
+		ctx:      ctx,
+		cancel:   cancel,
+		config:   config,
+		anchor:   anchor,
+		interval: interval,
+		lastFire: lastFire,
+		next:     anchor,
+		triggers: make(chan triggerRequest, 1),
+	}
+	j.recordState("", StatePending)
+	return j
+}
+
+// persist saves the job's current state to the store, if any.
+func (s *Scheduler) persist(j *job) {
+	if s.store == nil {
+		return
+	}
+	s.mu.Lock()
+	next := j.next
+	state := j.state
+	if state == StateStopping {
+		state = j.finalState
+	}
+	s.mu.Unlock()
+	if state != StateStopped && state != StateCompleted {
+		// Failed schedulers run again when they are restored.
+		state = ""
+	}
+	err := s.store.Save(Record{
+		ID:       j.id,
+		Config:   j.config,
+		Anchor:   j.anchor,
+		LastFire: j.lastFire,
+		NextFire: next,
+		State:    state,
+	})
+	if err != nil {
+		s.emit(j.id, "스케줄러 상태 저장 오류: %v", err)
+	}
+}
+
+// persistRunning saves j from its run goroutine unless it was stopped or
+// replaced meanwhile, so the record Stop deleted or Update saved isn't
+// overwritten.
+func (s *Scheduler) persistRunning(j *job) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.mu.Lock()
+	current := s.jobs[j.id] == j && active(j.state)
+	s.mu.Unlock()
+	if current {
+		s.persist(j)
+	}
+}
+
+// Stop stops the scheduler instance registered under the given ID and
+// reports whether it did. Stopping a scheduler that is already stopped
+// does nothing and returns false. An execution in progress is cancelled;
+// the scheduler is listed in StateStopping until its run goroutine has
+// returned, and then in StateStopped. It stays registered and stored with
+// its history until it is deleted, and Restart starts it again.
+func (s *Scheduler) Stop(id string) (bool, error) {
+	return s.stop(id, StateStopped)
+}
+
+// stop stops the scheduler registered under id, which ends in final.
+func (s *Scheduler) stop(id, final string) (bool, error) {
+	s.syncMu.Lock()
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		s.emit(id, "존재하지 않는 스케줄러 ID입니다.")
+		return false, ErrNotFound
+	}
+	to, err := j.stopLocked()
+	if err != nil {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		s.emit(id, "스케줄러가 실행 중이지 않습니다.")
+		return false, nil
+	}
+	j.finalState = final
+	s.mu.Unlock()
+	s.persist(j)
+	s.syncMu.Unlock()
+	j.publishState(to)
+	s.audit(id, "stop", "")
+
+	s.emit(id, "스케줄러가 중지되었습니다.")
+	return true, nil
+}
+
+// stopJob stops j, which completed, unless Update has already replaced it.
+func (s *Scheduler) stopJob(j *job) {
+	s.mu.Lock()
+	current := s.jobs[j.id] == j
+	s.mu.Unlock()
+	if current {
+		s.stop(j.id, StateCompleted)
+	}
+}
+
+// Restart starts the stopped, completed or failed scheduler registered
+// under id again with its configuration, from the next occurrence of its
+// start time.
+func (s *Scheduler) Restart(id string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	var config Config
+	var state string
+	if ok {
+		config, state = j.config, j.state
+	}
+	s.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	if active(state) || state == StateStopping {
+		return i18n.Errorf("%w: 중지된 스케줄러가 아닙니다 (현재 상태: %s)", ErrInvalidState, state)
+	}
+	return s.start(id, config, true)
+}
+
+// Delete stops the scheduler registered under id, if it runs, and removes
+// it with its stored record, execution history and configuration
+// versions. Archived responses are kept until their retention expires.
+func (s *Scheduler) Delete(id string) error {
+	s.syncMu.Lock()
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		return ErrNotFound
+	}
+	to, err := j.stopLocked()
+	delete(s.jobs, id)
+	s.mu.Unlock()
+	if s.store != nil {
+		if err := s.store.Delete(id); err != nil {
+			s.emit(id, "스케줄러 상태 삭제 오류: %v", err)
+		}
+	}
+	s.syncMu.Unlock()
+	if err == nil {
+		j.publishState(to)
+	}
+
+	if ps, ok := s.store.(PurgeStore); ok {
+		if err := ps.Purge(id); err != nil {
+			s.emit(id, "실행 기록 삭제 오류: %v", err)
+		}
+	}
+	s.verMu.Lock()
+	delete(s.versions, id)
+	s.verMu.Unlock()
+	s.bus.publish(Event{Type: EventDeleted, Time: s.clock.Now(), SchedulerID: id, job: j})
+	s.audit(id, "delete", "")
+
+	s.emit(id, "스케줄러가 삭제되었습니다.")
+	return nil
+}
+
+// audit saves an audit entry if the store keeps an audit trail.
+func (s *Scheduler) audit(id, action, detail string) {
+	as, ok := s.store.(AuditStore)
+	if !ok {
+		return
+	}
+	err := as.SaveAudit(AuditEntry{
+		Time:        s.clock.Now(),
+		SchedulerID: id,
+		Action:      action,
+		Detail:      detail,
+	})
+	if err != nil {
+		s.emit(id, "감사 기록 저장 오류: %v", err)
+	}
+}
+
+// List returns the status of every registered scheduler, ordered by ID,
+// with the secrets in their configs masked as Status does.
+func (s *Scheduler) List() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Status, 0, len(s.jobs))
+	for id, j := range s.jobs {
+		list = append(list, Status{
+			ID:          id,
+			State:       j.state,
+			StateSince:  j.stateSince,
+			Transitions: slices.Clone(j.transitions),
+			Running:     active(j.state),
+			NextRun:     j.next,
+			Failures:    j.failures,
+			Monitor:     j.monitorStatus(),
+			Crash:       j.crash,
+			Heartbeat:   j.heartbeat,
+			Stall:       j.stall,
+			SLA:         j.slaStatusLocked(),
+			Metrics:     j.metricsLocked(),
+			Config:      s.redactor.Config(j.config),
+		})
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].ID < list[b].ID })
+	return list
+}
+
+// Status returns the status of the scheduler registered under id. Secrets
+// in its config are masked as they are in the logs; Update keeps the
+// masked values when the config is sent back.
+func (s *Scheduler) Status(id string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	return Status{
+		ID:          id,
+		State:       j.state,
+		StateSince:  j.stateSince,
+		Transitions: slices.Clone(j.transitions),
+		Running:     active(j.state),
+		NextRun:     j.next,
+		Failures:    j.failures,
+		Monitor:     j.monitorStatus(),
+		Crash:       j.crash,
+		Heartbeat:   j.heartbeat,
+		Stall:       j.stall,
+		SLA:         j.slaStatusLocked(),
+		Metrics:     j.metricsLocked(),
+		Config:      s.redactor.Config(j.config),
+	}, nil
+}
+
+// PoolStats returns a snapshot of the execution pool.
+func (s *Scheduler) PoolStats() PoolStats {
+	return s.pool.stats()
+}
+
+// ClientStats returns a snapshot of the shared HTTP connection pools.
+func (s *Scheduler) ClientStats() ClientStats {
+	return s.clients.Stats()
+}
+
+// emit publishes a log message about the given scheduler, translated into
+// the default language.
+func (s *Scheduler) emit(id, format string, args ...any) {
+	s.bus.publish(s.logEvent(id, format, args...))
+}
+
+// logEvent returns the EventLog event of a message about the given
+// scheduler.
+func (s *Scheduler) logEvent(id, format string, args ...any) Event {
+	ev := Event{
+		Type:        EventLog,
+		Time:        s.clock.Now(),
+		SchedulerID: id,
+		Message:     i18n.T(format),
+	}
+	if len(args) > 0 {
+		ev.Message = i18n.Sprintf(i18n.Default(), format, args...)
+	}
+	ev.Message = s.redactor.Text(ev.Message)
+	return ev
+}
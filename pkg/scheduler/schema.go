@@ -0,0 +1,67 @@
+// pkg/scheduler/schema.go
+package scheduler
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// SchemaVersion is the version of the Config format written by this
+// release. Configs decoded from JSON with a lower schemaVersion, or none,
+// are migrated to it; configs with a higher one come from a newer release
+// and are rejected by Start and Restore.
+const SchemaVersion = 1
+
+// migrations[v] upgrades the fields of a version v config to version
+// v+1. Configs saved before schemaVersion existed are version 0.
+//
+// When a field is renamed or changes shape, bump SchemaVersion and append
+// a step that rewrites the old fields, so stored schedulers, templates,
+// versions and saved definitions keep working. Keys are matched exactly,
+// although encoding/json accepts any case; steps should also look for the
+// spellings clients were known to send.
+var migrations = []func(fields map[string]json.RawMessage) error{
+	// Version 1 introduced schemaVersion without changing any field.
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// UnmarshalJSON decodes a config, migrating it from an older schema
+// version first.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// plain has the fields of Config without this method.
+	type plain Config
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return json.Unmarshal(data, (*plain)(c))
+	}
+	version := 0
+	if v, ok := fields["schemaVersion"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return i18n.Errorf("schemaVersion은 정수여야 합니다: %s", v)
+		}
+	}
+	if version < 0 || version >= SchemaVersion {
+		return json.Unmarshal(data, (*plain)(c))
+	}
+	for v := version; v < SchemaVersion; v++ {
+		if err := migrations[v](fields); err != nil {
+			return i18n.Errorf("설정을 schemaVersion %d에서 %d로 변환할 수 없습니다: %w", v, v+1, err)
+		}
+	}
+	fields["schemaVersion"] = json.RawMessage(strconv.Itoa(SchemaVersion))
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(migrated, (*plain)(c))
+}
+
+// validateSchema rejects configs of an unknown schema version.
+func (c Config) validateSchema() error {
+	if c.SchemaVersion < 0 || c.SchemaVersion > SchemaVersion {
+		return i18n.Errorf("%w: schemaVersion %d은 지원하지 않습니다 (최대 %d)", ErrInvalidConfig, c.SchemaVersion, SchemaVersion)
+	}
+	return nil
+}
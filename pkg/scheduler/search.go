@@ -0,0 +1,133 @@
+// pkg/scheduler/search.go
+package scheduler
+
+import (
+	"sort"
+	"strings"
+)
+
+// Sort orders for Query.Sort. Prefix with "-" for descending order.
+const (
+	SortID       = "id"
+	SortName     = "name"
+	SortNextRun  = "nextRun"
+	SortFailures = "failures"
+)
+
+// Query selects and orders schedulers for Search. Zero fields don't filter.
+type Query struct {
+	// Text matches the ID, name, description or URL, case-insensitively.
+	Text string
+	// State is one of the State constants, or "active" for the
+	// schedulers that report Running.
+	State string
+	Group string
+	// Labels must all be present with the same values.
+	Labels map[string]string
+
+	// Sort is one of the Sort constants. Empty means SortID.
+	Sort string
+	// Offset and Limit select a page. A limit of zero or less returns
+	// every match after Offset.
+	Offset int
+	Limit  int
+}
+
+// Search returns one page of the schedulers matching q, and the number of
+// matches across all pages.
+func (s *Scheduler) Search(q Query) (page []Status, total int) {
+	var matches []Status
+	for _, st := range s.List() {
+		if q.matches(st) {
+			matches = append(matches, st)
+		}
+	}
+	q.sort(matches)
+
+	total = len(matches)
+	if q.Offset > 0 {
+		if q.Offset >= len(matches) {
+			return []Status{}, total
+		}
+		matches = matches[q.Offset:]
+	}
+	if q.Limit > 0 && q.Limit < len(matches) {
+		matches = matches[:q.Limit]
+	}
+	if matches == nil {
+		matches = []Status{}
+	}
+	return matches, total
+}
+
+// matches reports whether st passes every filter of q.
+func (q Query) matches(st Status) bool {
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		found := false
+		for _, field := range []string{st.ID, st.Config.Name, st.Config.Description, st.Config.APIURL} {
+			if strings.Contains(strings.ToLower(field), text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	switch q.State {
+	case "":
+	case "active":
+		if !st.Running {
+			return false
+		}
+	default:
+		if st.State != q.State {
+			return false
+		}
+	}
+	if q.Group != "" && st.Config.Group != q.Group {
+		return false
+	}
+	for k, v := range q.Labels {
+		if got, ok := st.Config.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// sort orders list by q.Sort. Ties are broken by ID.
+func (q Query) sort(list []Status) {
+	key, desc := strings.CutPrefix(q.Sort, "-")
+	less := func(a, b Status) bool { return a.ID < b.ID }
+	switch key {
+	case SortName:
+		less = func(a, b Status) bool {
+			if a.Config.Name != b.Config.Name {
+				return a.Config.Name < b.Config.Name
+			}
+			return a.ID < b.ID
+		}
+	case SortNextRun:
+		less = func(a, b Status) bool {
+			if !a.NextRun.Equal(b.NextRun) {
+				return a.NextRun.Before(b.NextRun)
+			}
+			return a.ID < b.ID
+		}
+	case SortFailures:
+		less = func(a, b Status) bool {
+			if a.Failures != b.Failures {
+				return a.Failures < b.Failures
+			}
+			return a.ID < b.ID
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if desc {
+			return less(list[j], list[i])
+		}
+		return less(list[i], list[j])
+	})
+}
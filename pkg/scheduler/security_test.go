@@ -0,0 +1,249 @@
+// pkg/scheduler/security_test.go
+package scheduler_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// httpConfig returns the config of an hourly GET of url, on a ManualClock
+// that isn't advanced past its start time.
+func httpConfig(url string) scheduler.Config {
+	return scheduler.Config{
+		StartTime:     "09:00:00",
+		Interval:      "1h",
+		APIURL:        url,
+		HTTPMethod:    "GET",
+		StopOnSuccess: keepRunning,
+	}
+}
+
+func TestListRedaction(t *testing.T) {
+	const secret = "s3cret-value"
+	tests := []struct {
+		name   string
+		modify func(c *scheduler.Config)
+	}{
+		{"header", func(c *scheduler.Config) {
+			c.Headers = map[string]string{"Authorization": "Bearer " + secret}
+		}},
+		{"auth password", func(c *scheduler.Config) {
+			c.Auth = &scheduler.AuthConfig{Username: "ops", Password: secret}
+		}},
+		{"hmac secret", func(c *scheduler.Config) {
+			c.Signing = &scheduler.SigningConfig{HMAC: &scheduler.HMACSigning{Secret: secret}}
+		}},
+		{"s3 secret", func(c *scheduler.Config) {
+			c.Archive = &scheduler.ArchiveConfig{S3: &scheduler.S3Archive{
+				Bucket: "archive", Region: "ap-northeast-2", AccessKeyID: "AKIA", SecretAccessKey: secret,
+			}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, _, _ := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				return respond(r, http.StatusOK), nil
+			}))
+			config := httpConfig("http://api.test/ping")
+			tt.modify(&config)
+			if err := sched.Start("secret", config); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			t.Cleanup(func() { sched.Stop("secret") })
+
+			list := sched.List()
+			if len(list) != 1 {
+				t.Fatalf("%d schedulers listed, want 1", len(list))
+			}
+			b, err := json.Marshal(list[0].Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(b), secret) {
+				t.Errorf("List returned the secret: %s", b)
+			}
+			if !strings.Contains(string(b), "[REDACTED]") {
+				t.Errorf("List didn't mask the secret: %s", b)
+			}
+		})
+	}
+}
+
+func TestPasswordEnvAllowlist(t *testing.T) {
+	t.Setenv("API_PASSWORD", "from-env")
+	tests := []struct {
+		name     string
+		allowed  []string
+		password string
+		// want is the password sent, empty when the config is rejected.
+		want string
+	}{
+		{"allowed", []string{"API_PASSWORD"}, "", "from-env"},
+		{"no allowlist", nil, "", ""},
+		{"other name allowed", []string{"OTHER"}, "", ""},
+		{"password given", nil, "inline", "inline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testContext(t)
+			sent := make(chan string, 1)
+			var clock *scheduler.ManualClock
+			sched, clock, _ := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				_, password, _ := r.BasicAuth()
+				select {
+				case sent <- password:
+				default:
+				}
+				return respond(r, http.StatusOK), nil
+			}), scheduler.WithAllowedEnv(tt.allowed))
+			config := httpConfig("http://api.test/ping")
+			config.Auth = &scheduler.AuthConfig{Username: "ops", Password: tt.password, PasswordEnv: "API_PASSWORD"}
+
+			if tt.want == "" {
+				err := sched.Start("auth", config)
+				if !errors.Is(err, scheduler.ErrInvalidConfig) {
+					t.Fatalf("Start = %v, want %v", err, scheduler.ErrInvalidConfig)
+				}
+				return
+			}
+			startJob(t, ctx, sched, clock, "auth", config)
+			if err := clock.AdvanceAndWait(ctx, time.Hour, 1); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case got := <-sent:
+				if got != tt.want {
+					t.Errorf("password %q sent, want %q", got, tt.want)
+				}
+			case <-ctx.Done():
+				t.Fatal("no request sent")
+			}
+		})
+	}
+}
+
+// selfSigned returns a self-signed certificate for 127.0.0.1 and its key.
+func selfSigned(t *testing.T, name string) ([]byte, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der, key
+}
+
+// fingerprint returns the hex SHA-256 of a certificate.
+func fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPinnedCertificate(t *testing.T) {
+	// The server presents its own certificate followed by another one,
+	// which anyone could send along.
+	leaf, key := selfSigned(t, "server")
+	other, _ := selfSigned(t, "pinned elsewhere")
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{leaf, other}, PrivateKey: key}}}
+	// The rejected handshakes are expected.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	unknown := sha256.Sum256([]byte("unknown"))
+	tests := []struct {
+		name string
+		pin  string
+		ok   bool
+	}{
+		{"leaf", fingerprint(leaf), true},
+		{"second certificate", fingerprint(other), false},
+		{"unknown", hex.EncodeToString(unknown[:]), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := scheduler.NewClientManager(0, 0)
+			resp, err := clients.Client(scheduler.TransportConfig{PinnedSHA256: []string{tt.pin}}).Get(srv.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err == nil) != tt.ok {
+				t.Errorf("request error %v, want success %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestArchiveRoot(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		root string
+		dir  string
+		ok   bool
+	}{
+		{"relative", root, "reports", true},
+		{"absolute inside", root, filepath.Join(root, "reports"), true},
+		{"parent", root, "../escape", false},
+		{"absolute outside", root, outside, false},
+		{"symbolic link out", root, "link/reports", false},
+		{"no root", "", "reports", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, _, _ := newTestScheduler(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				return respond(r, http.StatusOK), nil
+			}), scheduler.WithArchiveRoot(tt.root))
+			config := httpConfig("http://api.test/ping")
+			config.Archive = &scheduler.ArchiveConfig{Dir: tt.dir}
+			err := sched.Start("archive", config)
+			if err == nil {
+				sched.Stop("archive")
+			}
+			if tt.ok && err != nil {
+				t.Errorf("Start = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, scheduler.ErrInvalidConfig) {
+				t.Errorf("Start = %v, want %v", err, scheduler.ErrInvalidConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// pkg/scheduler/session.go
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"slices"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// SessionConfig keeps the cookies set by the target across the executions
+// of a scheduler, for APIs that authenticate with a session cookie.
+type SessionConfig struct {
+	// Login, when set, is sent before the first execution to obtain the
+	// session cookie, and again when an execution is rejected with 401 or
+	// 403, after which the execution is repeated once.
+	Login *SessionLogin `json:"login,omitempty"`
+}
+
+// SessionLogin is the login request of a session. It is sent like a
+// JobTypeHTTP job's request, with the job's Headers, Auth and Transport.
+type SessionLogin struct {
+	APIURL     string            `json:"apiURL"`
+	HTTPMethod string            `json:"httpMethod,omitempty"`
+	Payload    string            `json:"payload,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	// ExpectStatus lists the status codes of a successful login. Empty
+	// means 200 only.
+	ExpectStatus []int `json:"expectStatus,omitempty"`
+}
+
+// validate checks the session settings. A nil SessionConfig is valid.
+func (c *SessionConfig) validate() error {
+	if c == nil || c.Login == nil {
+		return nil
+	}
+	if c.Login.APIURL == "" {
+		return i18n.Errorf("%w: session.login.apiURL이 필요합니다", ErrInvalidConfig)
+	}
+	return validateFormPayload("session.login.payload", c.Login.Payload)
+}
+
+// session is the cookie jar of a job with Config.Session. It is only used
+// by the run goroutine, so it lives as long as the job: stopping or
+// editing a scheduler starts a new session.
+type session struct {
+	jar      http.CookieJar
+	loggedIn bool
+}
+
+// cookieJarKey is the context key of the cookie jar of an execution.
+type cookieJarKey struct{}
+
+// withCookieJar returns client with the cookie jar of the execution in
+// ctx, or client itself outside of a session.
+func withCookieJar(ctx context.Context, client *http.Client) *http.Client {
+	jar, ok := ctx.Value(cookieJarKey{}).(http.CookieJar)
+	if !ok {
+		return client
+	}
+	c := *client
+	c.Jar = jar
+	return &c
+}
+
+// executeInSession runs exec with config, the job's config for this
+// attempt, in the session of j, logging in first if needed. Jobs without
+// Config.Session run exec directly.
+func (j *job) executeInSession(ctx context.Context, exec Executor, config Config) (Result, error) {
+	if config.Session == nil {
+		return exec.Execute(ctx, config)
+	}
+	if j.session == nil {
+		jar, _ := cookiejar.New(nil)
+		j.session = &session{jar: jar}
+	}
+	ctx = context.WithValue(ctx, cookieJarKey{}, j.session.jar)
+
+	login := config.Session.Login
+	if login != nil && !j.session.loggedIn {
+		if err := j.login(ctx, config); err != nil {
+			return Result{}, err
+		}
+	}
+	res, err := exec.Execute(ctx, config)
+	if login == nil || err != nil || (res.StatusCode != http.StatusUnauthorized && res.StatusCode != http.StatusForbidden) {
+		return res, err
+	}
+	j.logAt(LogWarn, "세션이 만료되어 다시 로그인합니다 (상태 코드: %d).", res.StatusCode)
+	j.session.loggedIn = false
+	if err := j.login(ctx, config); err != nil {
+		return Result{}, err
+	}
+	return exec.Execute(ctx, config)
+}
+
+// login sends the login request of the session in config, whose cookies
+// go to the jar in ctx.
+func (j *job) login(ctx context.Context, config Config) error {
+	login := config.Session.Login
+	exec, ok := j.sched.executor(JobTypeHTTP)
+	if !ok {
+		return i18n.Errorf("등록되지 않은 작업 유형입니다: %s", JobTypeHTTP)
+	}
+	j.logAt(LogInfo, "로그인 요청: URL %s", j.sched.redactor.Text(login.APIURL))
+
+	req := config
+	req.Type = JobTypeHTTP
+	req.APIURL = login.APIURL
+	req.HTTPMethod = login.HTTPMethod
+	req.Payload = login.Payload
+	req.PayloadFrom = nil
+	req.PayloadType, req.ContentType, req.RawQuery = "", "", false
+	req.Files = nil
+	req.Headers = mergeMaps(config.Headers, login.Headers)
+	res, err := exec.Execute(ctx, req)
+	if err != nil {
+		return i18n.Errorf("로그인 오류: %w", err)
+	}
+	ok = res.Success
+	if len(login.ExpectStatus) > 0 {
+		ok = slices.Contains(login.ExpectStatus, res.StatusCode)
+	}
+	if !ok {
+		return i18n.Errorf("로그인 실패 - 상태 코드: %d", res.StatusCode)
+	}
+	j.session.loggedIn = true
+	j.logAt(LogInfo, "로그인 성공 - 상태 코드: %d", res.StatusCode)
+	return nil
+}
@@ -0,0 +1,132 @@
+// pkg/scheduler/settings.go
+package scheduler
+
+import "sync"
+
+// Settings are the server-wide settings of a Scheduler that may change
+// while it runs, such as when the server config is reloaded.
+type Settings struct {
+	// LogLevel is the log level of schedulers without their own
+	// Config.LogLevel. Empty means LogInfo.
+	LogLevel string
+	// DefaultHeaders are as set by WithDefaultHeaders. Nil means the
+	// built-in headers.
+	DefaultHeaders map[string]string
+	// Notify receives the notifications of schedulers without their own
+	// Config.Notify. Nil sends none.
+	Notify *NotifyConfig
+	// Blackouts are as set by WithBlackouts.
+	Blackouts []BlackoutWindow
+	// Limits are as set by WithLimits.
+	Limits Limits
+	// Profiles are the environment profiles by name, and ActiveProfile
+	// the one schedulers without their own Config.Profile run against.
+	Profiles      map[string]Profile
+	ActiveProfile string
+	// AllowedEnv are as set by WithAllowedEnv.
+	AllowedEnv []string
+}
+
+// settings holds the settings of a Scheduler read by running jobs.
+type settings struct {
+	mu             sync.RWMutex
+	logLevel       string
+	defaultHeaders map[string]string
+	notify         *NotifyConfig
+	blackouts      []BlackoutWindow
+	profiles       map[string]Profile
+	activeProfile  string
+	allowedEnv     []string
+}
+
+// WithLogLevel sets the log level of schedulers without their own
+// Config.LogLevel. Invalid levels are ignored; see Reconfigure.
+func WithLogLevel(level string) Option {
+	return func(s *Scheduler) {
+		s.settings.logLevel = level
+	}
+}
+
+// WithNotify sends the notifications of schedulers without their own
+// Config.Notify to n.
+func WithNotify(n *NotifyConfig) Option {
+	return func(s *Scheduler) {
+		s.settings.notify = n
+	}
+}
+
+// Reconfigure replaces the server-wide settings of s. Running schedulers
+// pick them up, such as a switch of the active profile, from their next
+// execution; their state and history are kept. Invalid settings are
+// rejected as a whole. Limits apply to the schedulers started or updated
+// afterwards.
+func (s *Scheduler) Reconfigure(st Settings) error {
+	if err := validateLogLevel(st.LogLevel); err != nil {
+		return err
+	}
+	if err := st.Notify.validate(); err != nil {
+		return err
+	}
+	if err := s.checkNotifiers(st.Notify); err != nil {
+		return err
+	}
+	for _, w := range st.Blackouts {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateProfiles(st.Profiles, st.ActiveProfile); err != nil {
+		return err
+	}
+	if st.DefaultHeaders == nil {
+		st.DefaultHeaders = builtinHeaders
+	}
+
+	s.settings.mu.Lock()
+	s.settings.logLevel = st.LogLevel
+	s.settings.defaultHeaders = st.DefaultHeaders
+	s.settings.notify = st.Notify
+	s.settings.blackouts = st.Blackouts
+	s.settings.profiles = st.Profiles
+	s.settings.activeProfile = st.ActiveProfile
+	s.settings.allowedEnv = st.AllowedEnv
+	s.settings.mu.Unlock()
+
+	s.mu.Lock()
+	s.limits = st.Limits
+	s.mu.Unlock()
+	return nil
+}
+
+// logLevel returns the log level of schedulers without their own.
+func (s *Scheduler) logLevel() string {
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	return s.settings.logLevel
+}
+
+// defaultHeaders returns the headers added to the requests of HTTP-based
+// jobs. The map must not be modified.
+func (s *Scheduler) defaultHeaders() map[string]string {
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	return s.settings.defaultHeaders
+}
+
+// blackouts returns the windows in which no scheduler executes.
+func (s *Scheduler) blackouts() []BlackoutWindow {
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	return s.settings.blackouts
+}
+
+// notifyConfig returns the webhook the notifications of a scheduler with
+// config c go to: its own, or else the server-wide one.
+func (s *Scheduler) notifyConfig(c Config) *NotifyConfig {
+	if c.Notify != nil {
+		return c.Notify
+	}
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	return s.settings.notify
+}
@@ -0,0 +1,288 @@
+// pkg/scheduler/signing.go
+package scheduler
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// SigningConfig signs the requests of HTTP-based job types.
+type SigningConfig struct {
+	// HMAC adds a signature header computed with a shared secret.
+	HMAC *HMACSigning `json:"hmac,omitempty"`
+	// AWS signs requests with AWS Signature Version 4.
+	AWS *AWSSigning `json:"aws,omitempty"`
+}
+
+// HMACSigning configures a generic HMAC signature header.
+type HMACSigning struct {
+	// Algorithm is "sha256" (default), "sha1" or "sha512".
+	Algorithm string `json:"algorithm,omitempty"`
+	Secret    string `json:"secret"`
+	// Header receives the signature. Empty means "X-Signature".
+	Header string `json:"header,omitempty"`
+	// Prefix is put before the signature, e.g. "sha256=".
+	Prefix string `json:"prefix,omitempty"`
+	// Encoding is "hex" (default) or "base64".
+	Encoding string `json:"encoding,omitempty"`
+	// StringToSign is the signed string, where {method}, {path}, {query},
+	// {body} and {timestamp} are replaced by the parts of the request.
+	// Empty means "{body}".
+	StringToSign string `json:"stringToSign,omitempty"`
+	// TimestampHeader, when set, receives the Unix time used as
+	// {timestamp}.
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+}
+
+// AWSSigning configures AWS Signature Version 4. Empty credentials are
+// read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// if the scheduler was created WithAWSEnvCredentials.
+type AWSSigning struct {
+	Region          string `json:"region"`
+	Service         string `json:"service"`
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+// WithAWSEnvCredentials lets requests signed with SigV4 without
+// credentials of their own use those of the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+// Without this option they fail, so that configs sent through the API
+// can't send the AWS keys of the server to hosts of their choosing.
+func WithAWSEnvCredentials() Option {
+	return func(s *Scheduler) {
+		s.awsEnvCredentials = true
+	}
+}
+
+// awsCredentials returns a, or a copy of it with the credentials of the
+// environment if it has none and s was created WithAWSEnvCredentials. S3
+// archives don't use it; they need credentials of their own.
+func (s *Scheduler) awsCredentials(a *AWSSigning) *AWSSigning {
+	if a == nil || a.AccessKeyID != "" || !s.awsEnvCredentials {
+		return a
+	}
+	env := *a
+	env.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	env.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	env.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	return &env
+}
+
+// validate checks the signing settings. A nil SigningConfig is valid.
+func (c *SigningConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if h := c.HMAC; h != nil {
+		if h.Secret == "" {
+			return i18n.Errorf("%w: signing.hmac.secret이 필요합니다", ErrInvalidConfig)
+		}
+		if _, ok := hmacHashes[h.algorithm()]; !ok {
+			return i18n.Errorf("%w: 지원하지 않는 signing.hmac.algorithm입니다: %q", ErrInvalidConfig, h.Algorithm)
+		}
+		if h.Encoding != "" && h.Encoding != "hex" && h.Encoding != "base64" {
+			return i18n.Errorf("%w: signing.hmac.encoding은 hex 또는 base64여야 합니다", ErrInvalidConfig)
+		}
+	}
+	if a := c.AWS; a != nil && (a.Region == "" || a.Service == "") {
+		return i18n.Errorf("%w: signing.aws에는 region과 service가 필요합니다", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// sign signs req as configured. It must be called after every other header
+// is set.
+func (c *SigningConfig) sign(req *http.Request) error {
+	if c == nil || (c.HMAC == nil && c.AWS == nil) {
+		return nil
+	}
+	var body []byte
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		if body, err = io.ReadAll(r); err != nil {
+			return err
+		}
+	}
+	now := time.Now().UTC()
+	if c.HMAC != nil {
+		c.HMAC.sign(req, body, now)
+	}
+	if c.AWS != nil {
+		return c.AWS.sign(req, body, now)
+	}
+	return nil
+}
+
+// hmacHashes are the supported HMAC algorithms.
+var hmacHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// algorithm returns the configured algorithm in lower case.
+func (h *HMACSigning) algorithm() string {
+	if h.Algorithm == "" {
+		return "sha256"
+	}
+	return strings.ToLower(h.Algorithm)
+}
+
+// sign sets the signature header, and the timestamp header if configured.
+func (h *HMACSigning) sign(req *http.Request, body []byte, now time.Time) {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	tmpl := h.StringToSign
+	if tmpl == "" {
+		tmpl = "{body}"
+	}
+	msg := strings.NewReplacer(
+		"{method}", req.Method,
+		"{path}", req.URL.EscapedPath(),
+		"{query}", req.URL.RawQuery,
+		"{body}", string(body),
+		"{timestamp}", ts,
+	).Replace(tmpl)
+
+	mac := hmac.New(hmacHashes[h.algorithm()], []byte(h.Secret))
+	mac.Write([]byte(msg))
+	sum := mac.Sum(nil)
+	sig := hex.EncodeToString(sum)
+	if h.Encoding == "base64" {
+		sig = base64.StdEncoding.EncodeToString(sum)
+	}
+	header := h.Header
+	if header == "" {
+		header = "X-Signature"
+	}
+	req.Header.Set(header, h.Prefix+sig)
+	if h.TimestampHeader != "" {
+		req.Header.Set(h.TimestampHeader, ts)
+	}
+}
+
+// sign adds the SigV4 Authorization header and the headers it covers.
+func (a *AWSSigning) sign(req *http.Request, body []byte, now time.Time) error {
+	return a.signHash(req, sha256Hex(body), now)
+}
+
+// signHash is sign for a body whose hex SHA-256 is payloadHash, so that
+// large bodies needn't be held in memory.
+func (a *AWSSigning) signHash(req *http.Request, payloadHash string, now time.Time) error {
+	keyID, secret, token := a.AccessKeyID, a.SecretAccessKey, a.SessionToken
+	if keyID == "" || secret == "" {
+		return i18n.Errorf("AWS 자격 증명이 없습니다")
+	}
+
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	// Canonical headers: host and every content-type and x-amz-* header.
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + a.Region + "/" + a.Service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	key := []byte("AWS4" + secret)
+	for _, part := range []string{date, a.Region, a.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+keyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+// canonicalQuery encodes q sorted by key and value, with spaces as %20.
+func canonicalQuery(q url.Values) string {
+	var pairs [][2]string
+	for key, values := range q {
+		for _, v := range values {
+			pairs = append(pairs, [2]string{awsEscape(key), awsEscape(v)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	encoded := make([]string, len(pairs))
+	for i, p := range pairs {
+		encoded[i] = p[0] + "=" + p[1]
+	}
+	return strings.Join(encoded, "&")
+}
+
+// awsEscape percent-encodes s as SigV4 requires.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// sha256Hex returns the hex SHA-256 of b.
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of msg with key.
+func hmacSHA256(key []byte, msg string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
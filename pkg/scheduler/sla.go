@@ -0,0 +1,229 @@
+// pkg/scheduler/sla.go
+package scheduler
+
+import (
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Kinds of an SLAViolation.
+const (
+	// SLADeadline is a run that didn't finish within CompleteWithin of its
+	// fire time.
+	SLADeadline = "deadline"
+	// SLASuccess is a scheduler that didn't succeed within SucceedEvery.
+	SLASuccess = "success"
+)
+
+// SLAConfig is the service level a scheduler must meet. Violations are
+// logged, counted in its Status and ExecutionStats and sent to its
+// notify.webhookURL.
+type SLAConfig struct {
+	// CompleteWithin is how long after its fire time a run must have
+	// finished, retries included, as a Go duration string.
+	CompleteWithin string `json:"completeWithin,omitempty"`
+	// SucceedEvery is how long the scheduler may go without a successful
+	// run, as a Go duration string. It is counted from the start time
+	// until the first success.
+	SucceedEvery string `json:"succeedEvery,omitempty"`
+}
+
+// validate checks the SLA settings. Nil settings are valid.
+func (c *SLAConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.CompleteWithin == "" && c.SucceedEvery == "" {
+		return i18n.Errorf("%w: sla에는 completeWithin 또는 succeedEvery가 필요합니다", ErrInvalidConfig)
+	}
+	for name, v := range map[string]string{"completeWithin": c.CompleteWithin, "succeedEvery": c.SucceedEvery} {
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			return i18n.Errorf("%w: sla.%s은 양수 Go duration이어야 합니다: %q", ErrInvalidConfig, name, v)
+		}
+	}
+	return nil
+}
+
+// completeWithin returns CompleteWithin, zero when it is unset.
+func (c *SLAConfig) completeWithin() time.Duration {
+	if c == nil {
+		return 0
+	}
+	d, _ := time.ParseDuration(c.CompleteWithin)
+	return d
+}
+
+// succeedEvery returns SucceedEvery, zero when it is unset.
+func (c *SLAConfig) succeedEvery() time.Duration {
+	if c == nil {
+		return 0
+	}
+	d, _ := time.ParseDuration(c.SucceedEvery)
+	return d
+}
+
+// SLAViolation is a breach of the SLAConfig of a scheduler.
+type SLAViolation struct {
+	// Kind is SLADeadline or SLASuccess.
+	Kind string `json:"kind"`
+	// Time is when the violation was detected.
+	Time time.Time `json:"time"`
+	// Deadline is when the run should have finished, or the scheduler
+	// should have succeeded by.
+	Deadline time.Time `json:"deadline"`
+	// FireTime is the fire time of the late run of an SLADeadline.
+	FireTime *time.Time `json:"fireTime,omitempty"`
+	// ExecutionID is the run that finished late, when it did finish.
+	ExecutionID string `json:"executionId,omitempty"`
+}
+
+// SLAStatus is how a scheduler with an SLAConfig has met it since it
+// started.
+type SLAStatus struct {
+	// DeadlineMisses counts the runs that missed CompleteWithin, and
+	// SuccessMisses the times SucceedEvery passed without a success.
+	DeadlineMisses int `json:"deadlineMisses"`
+	SuccessMisses  int `json:"successMisses"`
+	// LastSuccess is when the latest successful run finished.
+	LastSuccess   *time.Time    `json:"lastSuccess,omitempty"`
+	LastViolation *SLAViolation `json:"lastViolation,omitempty"`
+}
+
+// SLAEvent is sent to the webhook of a scheduler that violated its SLA.
+type SLAEvent struct {
+	SchedulerID string       `json:"schedulerId"`
+	Name        string       `json:"name,omitempty"`
+	Violation   SLAViolation `json:"violation"`
+}
+
+// slaState tracks the SLA of a job. It is protected by the scheduler's mu.
+type slaState struct {
+	status SLAStatus
+	// missed is the fire time whose deadline miss was reported, and gap
+	// the start of the success gap that was reported.
+	missed time.Time
+	gap    time.Time
+}
+
+// slaStatusLocked returns the SLA status of j, nil without an SLAConfig.
+// The scheduler's mu must be held.
+func (j *job) slaStatusLocked() *SLAStatus {
+	if j.config.SLA == nil {
+		return nil
+	}
+	st := j.sla.status
+	return &st
+}
+
+// violateLocked records v and returns the event to publish for it. The
+// scheduler's mu must be held.
+func (j *job) violateLocked(v SLAViolation) Event {
+	if v.Kind == SLADeadline {
+		j.sla.status.DeadlineMisses++
+	} else {
+		j.sla.status.SuccessMisses++
+	}
+	j.sla.status.LastViolation = &v
+	return Event{Type: EventSLAViolated, Time: v.Time, SchedulerID: j.id, ExecutionID: v.ExecutionID, SLA: &v, job: j}
+}
+
+// finishRun checks the SLA of j when the final execution rec of the run
+// for the fire time j waits for has finished. It must only be called from
+// the run goroutine.
+func (j *job) finishRun(rec *Execution) {
+	if j.config.SLA == nil {
+		return
+	}
+	now := j.sched.clock.Now()
+	within := j.config.SLA.completeWithin()
+	var ev *Event
+	j.sched.mu.Lock()
+	if rec.Success {
+		j.sla.status.LastSuccess = &now
+	}
+	fireTime := j.next
+	if within > 0 && now.Sub(fireTime) > within && !fireTime.Equal(j.sla.missed) {
+		j.sla.missed = fireTime
+		e := j.violateLocked(SLAViolation{Kind: SLADeadline, Time: now, Deadline: fireTime.Add(within), FireTime: &fireTime, ExecutionID: rec.ID})
+		ev = &e
+	}
+	j.sched.mu.Unlock()
+	if ev == nil {
+		return
+	}
+	j.logAt(LogWarn, "SLA 위반: %s 실행이 %s 안에 끝나지 않았습니다 (소요 %s).", fireTime.Format("15:04:05"), within, now.Sub(fireTime).Round(time.Millisecond))
+	j.sched.bus.publish(*ev)
+}
+
+// overdueLocked returns the violation of the SLA of j at now that wasn't
+// reported yet, if any: the run for its fire time didn't finish within
+// CompleteWithin, or it didn't succeed within SucceedEvery. The
+// scheduler's mu must be held.
+func (j *job) overdueLocked(now time.Time) (SLAViolation, bool) {
+	if !active(j.state) || j.state == StatePaused || j.state == StatePending {
+		return SLAViolation{}, false
+	}
+	if within := j.config.SLA.completeWithin(); within > 0 && !j.next.IsZero() {
+		fireTime := j.next
+		if now.Sub(fireTime) > within && !fireTime.Equal(j.sla.missed) {
+			j.sla.missed = fireTime
+			return SLAViolation{Kind: SLADeadline, Time: now, Deadline: fireTime.Add(within), FireTime: &fireTime}, true
+		}
+	}
+	if every := j.config.SLA.succeedEvery(); every > 0 {
+		since := j.anchor
+		if last := j.sla.status.LastSuccess; last != nil {
+			since = *last
+		}
+		if now.Sub(since) > every && !since.Equal(j.sla.gap) {
+			j.sla.gap = since
+			return SLAViolation{Kind: SLASuccess, Time: now, Deadline: since.Add(every)}, true
+		}
+	}
+	return SLAViolation{}, false
+}
+
+// CheckSLAs reports the schedulers whose SLA is violated without a run
+// finishing: a run that is still executing or waiting past CompleteWithin
+// of its fire time, or SucceedEvery passing without a success. Each
+// violation is reported once; runs that finish late are reported when
+// they finish. Violations are logged, published as EventSLAViolated
+// events and sent to the scheduler's webhook. It returns the number of
+// violations found. Nothing is checked in maintenance mode.
+//
+// Call it periodically; violations are detected up to one period late.
+func (s *Scheduler) CheckSLAs() int {
+	if s.inMaintenance() {
+		return 0
+	}
+	now := s.clock.Now()
+	var events []Event
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		if j.config.SLA == nil {
+			continue
+		}
+		if v, ok := j.overdueLocked(now); ok {
+			events = append(events, j.violateLocked(v))
+		}
+	}
+	s.mu.Unlock()
+
+	// The job's loggers belong to its run goroutine.
+	for _, ev := range events {
+		v := ev.SLA
+		switch {
+		case !ev.job.logs(LogWarn):
+		case v.Kind == SLADeadline:
+			s.emit(ev.SchedulerID, "SLA 위반: %s 실행이 %s까지 끝나지 않았습니다.", v.FireTime.Format("15:04:05"), v.Deadline.Format("15:04:05"))
+		default:
+			s.emit(ev.SchedulerID, "SLA 위반: %s 이후 성공한 실행이 없습니다.", v.Deadline.Add(-ev.job.config.SLA.succeedEvery()).Format("2006-01-02 15:04:05"))
+		}
+		s.bus.publish(ev)
+	}
+	return len(events)
+}
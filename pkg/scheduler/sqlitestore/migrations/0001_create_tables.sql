@@ -0,0 +1,45 @@
+-- The tables as of the first migration. IF NOT EXISTS keeps databases
+-- created before migrations were recorded as they are.
+CREATE TABLE IF NOT EXISTS schedulers (
+	id        TEXT PRIMARY KEY,
+	config    TEXT NOT NULL,
+	anchor    INTEGER NOT NULL,
+	last_fire INTEGER NOT NULL DEFAULT 0,
+	next_fire INTEGER NOT NULL DEFAULT 0,
+	state     TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS executions (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	scheduler_id TEXT NOT NULL,
+	started_at   INTEGER NOT NULL,
+	duration_ns  INTEGER NOT NULL,
+	success      INTEGER NOT NULL,
+	status_code  INTEGER NOT NULL,
+	output       TEXT NOT NULL,
+	error        TEXT NOT NULL,
+	changes      TEXT NOT NULL DEFAULT '',
+	timing       TEXT NOT NULL DEFAULT '',
+	execution_id TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS executions_scheduler ON executions (scheduler_id, started_at);
+CREATE INDEX IF NOT EXISTS executions_started ON executions (started_at);
+CREATE TABLE IF NOT EXISTS audit (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	time         INTEGER NOT NULL,
+	scheduler_id TEXT NOT NULL,
+	action       TEXT NOT NULL,
+	detail       TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS versions (
+	scheduler_id TEXT NOT NULL,
+	version      INTEGER NOT NULL,
+	time         INTEGER NOT NULL,
+	action       TEXT NOT NULL,
+	config       TEXT NOT NULL,
+	PRIMARY KEY (scheduler_id, version)
+);
+CREATE TABLE IF NOT EXISTS templates (
+	name        TEXT PRIMARY KEY,
+	description TEXT NOT NULL,
+	config      TEXT NOT NULL
+);
@@ -0,0 +1,6 @@
+-- Global variables referenced by schedulers as {{var "NAME"}}.
+CREATE TABLE IF NOT EXISTS variables (
+	name        TEXT PRIMARY KEY,
+	value       TEXT NOT NULL,
+	description TEXT NOT NULL
+);
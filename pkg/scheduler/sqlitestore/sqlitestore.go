@@ -0,0 +1,419 @@
+// pkg/scheduler/sqlitestore/sqlitestore.go
+package sqlitestore
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+	"go-api-scheduler/pkg/storage"
+)
+
+// migrationFiles holds the SQL migrations of the store.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// columns lists the columns added after their table was created, which
+// databases created before migrations were recorded may lack.
+var columns = []struct{ table, name, def string }{
+	{"schedulers", "next_fire", "INTEGER NOT NULL DEFAULT 0"},
+	{"schedulers", "state", "TEXT NOT NULL DEFAULT ''"},
+	{"executions", "changes", "TEXT NOT NULL DEFAULT ''"},
+	{"executions", "timing", "TEXT NOT NULL DEFAULT ''"},
+	{"executions", "execution_id", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// Store keeps schedulers, executions, audit entries, config versions,
+// templates and variables in an embedded SQLite database. It implements
+// scheduler.Store, scheduler.ExecutionStore, scheduler.ExecutionRangeStore,
+// scheduler.PurgeStore, scheduler.AuditStore, scheduler.VersionStore,
+// scheduler.TemplateStore and scheduler.VariableStore.
+type Store struct {
+	db *sql.DB
+}
+
+func init() {
+	storage.Register("sqlite", func(o storage.Options) (storage.Store, error) {
+		s, err := Open(storage.DefaultPath(o.Path, "api-scheduler.db"))
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// Open opens (creating if needed) the SQLite database at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, i18n.Errorf("SQLite 열기 오류: %w", err)
+	}
+	// SQLite allows a single writer; serializing through one connection
+	// avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, i18n.Errorf("SQLite 스키마 생성 오류: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// migrate brings the schema of db up to date: the SQL migrations, then
+// the columns older databases lack. Later changes are added as SQL
+// migrations numbered after the step for the columns.
+func migrate(db *sql.DB) error {
+	migrations, err := storage.SQLMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return err
+	}
+	migrations = append(migrations, storage.Migration{Version: 2, Name: "add_missing_columns", Up: addColumns})
+	return storage.Migrate(db, migrations)
+}
+
+// addColumns adds the missing columns.
+func addColumns(tx *sql.Tx) error {
+	for _, c := range columns {
+		var n int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, c.table, c.name).Scan(&n)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			if _, err := tx.Exec(`ALTER TABLE ` + c.table + ` ADD COLUMN ` + c.name + ` ` + c.def); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save creates or replaces the record with rec.ID.
+func (s *Store) Save(rec scheduler.Record) error {
+	config, err := json.Marshal(rec.Config)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO schedulers (id, config, anchor, last_fire, next_fire, state) VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (id) DO UPDATE SET config = excluded.config, anchor = excluded.anchor,
+		 last_fire = excluded.last_fire, next_fire = excluded.next_fire, state = excluded.state`,
+		rec.ID, string(config), unixNano(rec.Anchor), unixNano(rec.LastFire), unixNano(rec.NextFire), rec.State,
+	)
+	return err
+}
+
+// Delete removes the record with the given ID, if any.
+func (s *Store) Delete(id string) error {
+	_, err := s.db.Exec(`DELETE FROM schedulers WHERE id = ?`, id)
+	return err
+}
+
+// Load returns every stored record, ordered by ID.
+func (s *Store) Load() ([]scheduler.Record, error) {
+	rows, err := s.db.Query(`SELECT id, config, anchor, last_fire, next_fire, state FROM schedulers ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []scheduler.Record
+	for rows.Next() {
+		var (
+			rec                        scheduler.Record
+			config                     string
+			anchor, lastFire, nextFire int64
+		)
+		if err := rows.Scan(&rec.ID, &config, &anchor, &lastFire, &nextFire, &rec.State); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(config), &rec.Config); err != nil {
+			return nil, i18n.Errorf("스케줄러 %s 설정 파싱 오류: %w", rec.ID, err)
+		}
+		rec.Anchor = fromUnixNano(anchor)
+		rec.LastFire = fromUnixNano(lastFire)
+		rec.NextFire = fromUnixNano(nextFire)
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// SaveExecution appends an execution to the history.
+func (s *Store) SaveExecution(e scheduler.Execution) error {
+	var changes, timing []byte
+	var err error
+	if len(e.Changes) > 0 {
+		if changes, err = json.Marshal(e.Changes); err != nil {
+			return err
+		}
+	}
+	if e.Timing != nil {
+		if timing, err = json.Marshal(e.Timing); err != nil {
+			return err
+		}
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO executions (execution_id, scheduler_id, started_at, duration_ns, success, status_code, output, error, changes, timing)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID, e.SchedulerID, unixNano(e.StartedAt), int64(e.Duration), e.Success, e.StatusCode, e.Output, e.Error, string(changes), string(timing),
+	)
+	return err
+}
+
+// ListExecutions returns the latest executions of a scheduler, newest first.
+func (s *Store) ListExecutions(schedulerID string, limit int) ([]scheduler.Execution, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	return queryExecutions(s.db.Query(
+		`SELECT execution_id, scheduler_id, started_at, duration_ns, success, status_code, output, error, changes, timing
+		 FROM executions WHERE scheduler_id = ? ORDER BY started_at DESC, id DESC LIMIT ?`,
+		schedulerID, limit,
+	))
+}
+
+// ListExecutionsBetween returns the executions of every scheduler that
+// started in [from, to], oldest first.
+func (s *Store) ListExecutionsBetween(from, to time.Time) ([]scheduler.Execution, error) {
+	return queryExecutions(s.db.Query(
+		`SELECT execution_id, scheduler_id, started_at, duration_ns, success, status_code, output, error, changes, timing
+		 FROM executions WHERE started_at BETWEEN ? AND ? ORDER BY started_at, id`,
+		unixNano(from), unixNano(to),
+	))
+}
+
+// queryExecutions reads the executions selected by a query.
+func queryExecutions(rows *sql.Rows, err error) ([]scheduler.Execution, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []scheduler.Execution
+	for rows.Next() {
+		var (
+			e                   scheduler.Execution
+			startedAt, duration int64
+			changes, timing     string
+		)
+		if err := rows.Scan(&e.ID, &e.SchedulerID, &startedAt, &duration, &e.Success, &e.StatusCode, &e.Output, &e.Error, &changes, &timing); err != nil {
+			return nil, err
+		}
+		if changes != "" {
+			if err := json.Unmarshal([]byte(changes), &e.Changes); err != nil {
+				return nil, err
+			}
+		}
+		if timing != "" {
+			if err := json.Unmarshal([]byte(timing), &e.Timing); err != nil {
+				return nil, err
+			}
+		}
+		e.StartedAt = fromUnixNano(startedAt)
+		e.Duration = time.Duration(duration)
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+// Purge deletes the execution history and config versions of a scheduler.
+func (s *Store) Purge(schedulerID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM executions WHERE scheduler_id = ?`, schedulerID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM versions WHERE scheduler_id = ?`, schedulerID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SaveAudit appends an entry to the audit trail.
+func (s *Store) SaveAudit(e scheduler.AuditEntry) error {
+	_, err := s.db.Exec(
+		`INSERT INTO audit (time, scheduler_id, action, detail) VALUES (?, ?, ?, ?)`,
+		unixNano(e.Time), e.SchedulerID, e.Action, e.Detail,
+	)
+	return err
+}
+
+// ListAudit returns the latest audit entries, newest first.
+func (s *Store) ListAudit(limit int) ([]scheduler.AuditEntry, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.Query(
+		`SELECT time, scheduler_id, action, detail FROM audit ORDER BY time DESC, id DESC LIMIT ?`, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []scheduler.AuditEntry
+	for rows.Next() {
+		var (
+			e  scheduler.AuditEntry
+			ts int64
+		)
+		if err := rows.Scan(&ts, &e.SchedulerID, &e.Action, &e.Detail); err != nil {
+			return nil, err
+		}
+		e.Time = fromUnixNano(ts)
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+// SaveVersion adds a version to the config history of a scheduler.
+func (s *Store) SaveVersion(schedulerID string, v scheduler.Version) error {
+	config, err := json.Marshal(v.Config)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO versions (scheduler_id, version, time, action, config) VALUES (?, ?, ?, ?, ?)`,
+		schedulerID, v.Version, unixNano(v.Time), v.Action, string(config),
+	)
+	return err
+}
+
+// ListVersions returns the versions of a scheduler, oldest first.
+func (s *Store) ListVersions(schedulerID string) ([]scheduler.Version, error) {
+	rows, err := s.db.Query(
+		`SELECT version, time, action, config FROM versions WHERE scheduler_id = ? ORDER BY version`, schedulerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []scheduler.Version
+	for rows.Next() {
+		var (
+			v      scheduler.Version
+			ts     int64
+			config string
+		)
+		if err := rows.Scan(&v.Version, &ts, &v.Action, &config); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(config), &v.Config); err != nil {
+			return nil, i18n.Errorf("스케줄러 %s 버전 %d 파싱 오류: %w", schedulerID, v.Version, err)
+		}
+		v.Time = fromUnixNano(ts)
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// SaveTemplate creates or replaces the template with t.Name.
+func (s *Store) SaveTemplate(t scheduler.Template) error {
+	config, err := json.Marshal(t.Config)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO templates (name, description, config) VALUES (?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET description = excluded.description, config = excluded.config`,
+		t.Name, t.Description, string(config),
+	)
+	return err
+}
+
+// DeleteTemplate removes the template with the given name, if any.
+func (s *Store) DeleteTemplate(name string) error {
+	_, err := s.db.Exec(`DELETE FROM templates WHERE name = ?`, name)
+	return err
+}
+
+// LoadTemplates returns every stored template, ordered by name.
+func (s *Store) LoadTemplates() ([]scheduler.Template, error) {
+	rows, err := s.db.Query(`SELECT name, description, config FROM templates ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []scheduler.Template
+	for rows.Next() {
+		var (
+			t      scheduler.Template
+			config string
+		)
+		if err := rows.Scan(&t.Name, &t.Description, &config); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(config), &t.Config); err != nil {
+			return nil, i18n.Errorf("템플릿 %s 설정 파싱 오류: %w", t.Name, err)
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+// SaveVariable creates or replaces the variable with v.Name.
+func (s *Store) SaveVariable(v scheduler.Variable) error {
+	_, err := s.db.Exec(
+		`INSERT INTO variables (name, value, description) VALUES (?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET value = excluded.value, description = excluded.description`,
+		v.Name, v.Value, v.Description,
+	)
+	return err
+}
+
+// DeleteVariable removes the variable with the given name, if any.
+func (s *Store) DeleteVariable(name string) error {
+	_, err := s.db.Exec(`DELETE FROM variables WHERE name = ?`, name)
+	return err
+}
+
+// LoadVariables returns every stored variable, ordered by name.
+func (s *Store) LoadVariables() ([]scheduler.Variable, error) {
+	rows, err := s.db.Query(`SELECT name, value, description FROM variables ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []scheduler.Variable
+	for rows.Next() {
+		var v scheduler.Variable
+		if err := rows.Scan(&v.Name, &v.Value, &v.Description); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// unixNano converts t to Unix nanoseconds, mapping the zero time to 0.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano is the inverse of unixNano.
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
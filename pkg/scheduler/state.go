@@ -0,0 +1,222 @@
+// pkg/scheduler/state.go
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// ErrInvalidState is returned when a scheduler can't change to the
+// requested state, e.g. when Resume is called on a scheduler that isn't
+// paused.
+var ErrInvalidState = errors.New("scheduler: invalid state")
+
+// States of a scheduler, reported in Status.State.
+const (
+	// StatePending is a registered scheduler whose run loop hasn't
+	// started yet.
+	StatePending = "pending"
+	// StateWaiting is a scheduler waiting for its start time.
+	StateWaiting = "waiting"
+	// StateRunning is a scheduler executing at its fire times.
+	StateRunning = "running"
+	// StatePaused is a scheduler whose fire times are skipped until it is
+	// resumed.
+	StatePaused = "paused"
+	// StateStopping is a scheduler that was stopped, cancelling its
+	// in-flight execution.
+	StateStopping = "stopping"
+	// StateStopped is a scheduler whose run loop has returned.
+	StateStopped = "stopped"
+	// StateFailed is a scheduler whose run loop crashed on a panic.
+	StateFailed = "failed"
+	// StateCompleted is a run-once scheduler that stopped after its
+	// successful execution.
+	StateCompleted = "completed"
+)
+
+// stateTransitions lists the states each state may change to. Stopped and
+// completed schedulers are final.
+var stateTransitions = map[string][]string{
+	StatePending:  {StateWaiting, StateRunning, StatePaused, StateStopping, StateFailed},
+	StateWaiting:  {StateRunning, StatePaused, StateStopping, StateFailed},
+	StateRunning:  {StatePaused, StateStopping, StateFailed},
+	StatePaused:   {StatePending, StateWaiting, StateRunning, StateStopping, StateFailed},
+	StateStopping: {StateStopped, StateCompleted, StateFailed},
+	StateFailed:   {StateStopped},
+}
+
+// maxTransitions bounds the state changes kept in Status.Transitions.
+const maxTransitions = 20
+
+// Transition is a change of a scheduler's state.
+type Transition struct {
+	// From is empty for the initial StatePending.
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+	Time time.Time `json:"time"`
+}
+
+// active reports whether a scheduler in state has a run loop that can
+// still execute, which Status.Running reports.
+func active(state string) bool {
+	switch state {
+	case StatePending, StateWaiting, StateRunning, StatePaused:
+		return true
+	}
+	return false
+}
+
+// setStateLocked changes the state of j to to, if the current state allows
+// it. The scheduler's mu must be held.
+func (j *job) setStateLocked(to string) error {
+	from := j.state
+	allowed := false
+	for _, s := range stateTransitions[from] {
+		if s == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return i18n.Errorf("%w: %s 상태에서 %s 상태로 전환할 수 없습니다", ErrInvalidState, from, to)
+	}
+	j.recordState(from, to)
+	return nil
+}
+
+// recordState sets the state of j and records the transition.
+func (j *job) recordState(from, to string) {
+	now := j.sched.clock.Now()
+	j.state = to
+	j.stateSince = now
+	j.transitions = append(j.transitions, Transition{From: from, To: to, Time: now})
+	if len(j.transitions) > maxTransitions {
+		j.transitions = append([]Transition(nil), j.transitions[len(j.transitions)-maxTransitions:]...)
+	}
+}
+
+// publishState publishes an EventState event for j. The state is set
+// under the scheduler's mu, but published after releasing it, so
+// subscribers may call List.
+func (j *job) publishState(state string) {
+	j.sched.bus.publish(Event{
+		Type:        EventState,
+		Time:        j.sched.clock.Now(),
+		SchedulerID: j.id,
+		State:       state,
+		job:         j,
+	})
+}
+
+// advance moves j to to from the run goroutine. A paused job stays paused
+// and resumes to to instead; a job that was stopped meanwhile stays
+// stopping.
+func (j *job) advance(to string) {
+	j.sched.mu.Lock()
+	if j.state == StatePaused {
+		j.resumeState = to
+		j.sched.mu.Unlock()
+		return
+	}
+	err := j.setStateLocked(to)
+	j.sched.mu.Unlock()
+	if err == nil {
+		j.publishState(to)
+	}
+}
+
+// stopLocked cancels j and changes it to StateStopping, or straight to
+// StateStopped when it failed and has no run goroutine left to finish. It
+// returns the new state. The scheduler's mu must be held.
+//
+// A stopping job ends in its finalState, StateStopped by default.
+func (j *job) stopLocked() (string, error) {
+	to := StateStopping
+	if j.state == StateFailed {
+		to = StateStopped
+	}
+	if err := j.setStateLocked(to); err != nil {
+		return "", err
+	}
+	j.cancel()
+	return to, nil
+}
+
+// finish sets the final state of j when its run goroutine returns, unless
+// it crashed.
+func (j *job) finish() {
+	j.sched.mu.Lock()
+	if j.state == StateFailed {
+		j.sched.mu.Unlock()
+		return
+	}
+	to := j.finalState
+	if to == "" {
+		to = StateStopped
+	}
+	err := j.setStateLocked(to)
+	j.sched.mu.Unlock()
+	if err == nil {
+		j.publishState(to)
+	}
+}
+
+// paused reports whether j is paused.
+func (j *job) paused() bool {
+	j.sched.mu.Lock()
+	defer j.sched.mu.Unlock()
+	return j.state == StatePaused
+}
+
+// Pause makes the scheduler registered under id skip its fire times until
+// Resume is called. An execution in progress finishes. The paused state
+// is not persisted: a restored scheduler runs again.
+func (s *Scheduler) Pause(id string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	from := j.state
+	if err := j.setStateLocked(StatePaused); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	j.resumeState = from
+	s.mu.Unlock()
+	j.publishState(StatePaused)
+
+	s.audit(id, "pause", "")
+	s.emit(id, "스케줄러가 일시 중지되었습니다.")
+	return nil
+}
+
+// Resume continues the paused scheduler registered under id at its next
+// fire time.
+func (s *Scheduler) Resume(id string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	if j.state != StatePaused {
+		s.mu.Unlock()
+		return i18n.Errorf("%w: 일시 중지된 스케줄러가 아닙니다 (현재 상태: %s)", ErrInvalidState, j.state)
+	}
+	to := j.resumeState
+	if err := j.setStateLocked(to); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.mu.Unlock()
+	j.publishState(to)
+
+	s.audit(id, "resume", "")
+	s.emit(id, "스케줄러가 다시 실행됩니다.")
+	return nil
+}
@@ -0,0 +1,159 @@
+// pkg/scheduler/stats.go
+package scheduler
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// ErrNoHistory is returned when the store does not keep execution history.
+var ErrNoHistory = errors.New("scheduler: execution history not available")
+
+// ExecutionStats summarizes the executions of a scheduler in a time window.
+type ExecutionStats struct {
+	SchedulerID string    `json:"schedulerId"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+
+	Runs        int     `json:"runs"`
+	Successes   int     `json:"successes"`
+	SuccessRate float64 `json:"successRate"`
+
+	// Latencies are in milliseconds.
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	P50LatencyMs float64 `json:"p50LatencyMs"`
+	P90LatencyMs float64 `json:"p90LatencyMs"`
+	P99LatencyMs float64 `json:"p99LatencyMs"`
+
+	// LastError is the error or status code of the latest failed execution.
+	LastError   string     `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+	// FailureStreak counts the failed executions since the latest success,
+	// regardless of the window.
+	FailureStreak int `json:"failureStreak"`
+
+	// RunsPerDay counts executions by local date (YYYY-MM-DD).
+	RunsPerDay map[string]int `json:"runsPerDay"`
+
+	// SLA is how the scheduler has met its Config.SLA since it started,
+	// regardless of the window.
+	SLA *SLAStatus `json:"sla,omitempty"`
+}
+
+// ExecutionStats computes statistics over the executions of a scheduler
+// that started within window before now. It needs a store that keeps
+// execution history.
+func (s *Scheduler) ExecutionStats(id string, window time.Duration) (ExecutionStats, error) {
+	es, ok := s.store.(ExecutionStore)
+	if !ok {
+		return ExecutionStats{}, ErrNoHistory
+	}
+	history, err := es.ListExecutions(id, 0)
+	if err != nil {
+		return ExecutionStats{}, err
+	}
+	now := s.clock.Now()
+	st := computeStats(id, history, now.Add(-window), now)
+	s.mu.Lock()
+	if j, ok := s.jobs[id]; ok {
+		st.SLA = j.slaStatusLocked()
+	}
+	s.mu.Unlock()
+	return st, nil
+}
+
+// Executions returns the executions of a scheduler that started in
+// [from, to), oldest first. A zero from or to leaves that end open. It
+// needs a store that keeps execution history.
+func (s *Scheduler) Executions(id string, from, to time.Time) ([]Execution, error) {
+	es, ok := s.store.(ExecutionStore)
+	if !ok {
+		return nil, ErrNoHistory
+	}
+	history, err := es.ListExecutions(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	executions := make([]Execution, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		if (!from.IsZero() && e.StartedAt.Before(from)) || (!to.IsZero() && !e.StartedAt.Before(to)) {
+			continue
+		}
+		executions = append(executions, e)
+	}
+	return executions, nil
+}
+
+// computeStats summarizes history, which is ordered newest first.
+func computeStats(id string, history []Execution, from, to time.Time) ExecutionStats {
+	st := ExecutionStats{
+		SchedulerID: id,
+		From:        from,
+		To:          to,
+		RunsPerDay:  make(map[string]int),
+	}
+
+	streakOpen := true
+	var latencies []time.Duration
+	var total time.Duration
+	for _, e := range history {
+		if streakOpen {
+			if e.Success {
+				streakOpen = false
+			} else {
+				st.FailureStreak++
+			}
+		}
+		if e.StartedAt.Before(from) || e.StartedAt.After(to) {
+			continue
+		}
+
+		st.Runs++
+		if e.Success {
+			st.Successes++
+		} else if st.LastErrorAt == nil {
+			at := e.StartedAt
+			st.LastErrorAt = &at
+			st.LastError = e.Error
+			if st.LastError == "" {
+				st.LastError = i18n.Sprintf(i18n.Default(), "상태 코드 %d", e.StatusCode)
+			}
+		}
+		latencies = append(latencies, e.Duration)
+		total += e.Duration
+		st.RunsPerDay[e.StartedAt.Local().Format("2006-01-02")]++
+	}
+
+	if st.Runs == 0 {
+		return st
+	}
+	st.SuccessRate = float64(st.Successes) / float64(st.Runs)
+	st.AvgLatencyMs = ms(total / time.Duration(st.Runs))
+	sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+	st.P50LatencyMs = ms(percentile(latencies, 0.50))
+	st.P90LatencyMs = ms(percentile(latencies, 0.90))
+	st.P99LatencyMs = ms(percentile(latencies, 0.99))
+	return st
+}
+
+// percentile returns the nearest-rank percentile p of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(float64(len(sorted))*p)) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// ms converts d to fractional milliseconds.
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
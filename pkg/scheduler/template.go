@@ -0,0 +1,157 @@
+// pkg/scheduler/template.go
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// ErrTemplateNotFound is returned when no template is saved under the given name.
+var ErrTemplateNotFound = errors.New("scheduler: template not found")
+
+// Template is a reusable partial configuration. Schedulers reference it by
+// name through Config.Template and override any of its fields.
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Config holds the shared settings. Schedule fields such as StartTime
+	// may be left empty for the schedulers to fill in.
+	Config Config `json:"config"`
+}
+
+// TemplateStore is implemented by stores that also keep templates.
+type TemplateStore interface {
+	SaveTemplate(t Template) error
+	DeleteTemplate(name string) error
+	LoadTemplates() ([]Template, error)
+}
+
+// SaveTemplate creates or replaces the template with t.Name. Schedulers
+// already running keep the settings they were started with.
+func (s *Scheduler) SaveTemplate(t Template) error {
+	if t.Name == "" {
+		return i18n.Errorf("%w: 템플릿 이름이 필요합니다", ErrInvalidConfig)
+	}
+	if t.Config.Template != "" {
+		return i18n.Errorf("%w: 템플릿은 다른 템플릿을 참조할 수 없습니다", ErrInvalidConfig)
+	}
+	if err := t.Config.validatePartial(); err != nil {
+		return err
+	}
+
+	if ts, ok := s.store.(TemplateStore); ok {
+		if err := ts.SaveTemplate(t); err != nil {
+			return err
+		}
+	}
+	s.tmplMu.Lock()
+	s.templates[t.Name] = t
+	s.tmplMu.Unlock()
+	return nil
+}
+
+// DeleteTemplate removes the template with the given name.
+func (s *Scheduler) DeleteTemplate(name string) error {
+	s.tmplMu.Lock()
+	defer s.tmplMu.Unlock()
+	if _, ok := s.templates[name]; !ok {
+		return ErrTemplateNotFound
+	}
+	if ts, ok := s.store.(TemplateStore); ok {
+		if err := ts.DeleteTemplate(name); err != nil {
+			return err
+		}
+	}
+	delete(s.templates, name)
+	return nil
+}
+
+// Template returns the template with the given name.
+func (s *Scheduler) Template(name string) (Template, error) {
+	s.tmplMu.RLock()
+	defer s.tmplMu.RUnlock()
+	t, ok := s.templates[name]
+	if !ok {
+		return Template{}, ErrTemplateNotFound
+	}
+	return t, nil
+}
+
+// Templates returns every template, ordered by name.
+func (s *Scheduler) Templates() []Template {
+	s.tmplMu.RLock()
+	defer s.tmplMu.RUnlock()
+
+	list := make([]Template, 0, len(s.templates))
+	for _, t := range s.templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list
+}
+
+// loadTemplates reads the templates kept by the store, if it keeps any.
+func (s *Scheduler) loadTemplates() error {
+	ts, ok := s.store.(TemplateStore)
+	if !ok {
+		return nil
+	}
+	list, err := ts.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	s.tmplMu.Lock()
+	defer s.tmplMu.Unlock()
+	for _, t := range list {
+		s.templates[t.Name] = t
+	}
+	return nil
+}
+
+// applyTemplate returns config with the fields it leaves empty filled in
+// from the template it references.
+func (s *Scheduler) applyTemplate(config Config) (Config, error) {
+	if config.Template == "" {
+		return config, nil
+	}
+	t, err := s.Template(config.Template)
+	if err != nil {
+		return config, i18n.Errorf("%w: 템플릿을 찾을 수 없습니다: %q", ErrInvalidConfig, config.Template)
+	}
+	return mergeConfig(t.Config, config), nil
+}
+
+// mergeConfig returns override with every zero field taken from base.
+// Headers and labels are merged, with override winning for the same name.
+func mergeConfig(base, override Config) Config {
+	merged := override
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(base)
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	merged.Headers = mergeMaps(base.Headers, merged.Headers)
+	merged.Labels = mergeMaps(base.Labels, merged.Labels)
+	return merged
+}
+
+// mergeMaps returns the entries of base and override, with override
+// winning for the same key. It returns override as is when either is empty.
+func mergeMaps(base, override map[string]string) map[string]string {
+	if len(base) == 0 || len(override) == 0 {
+		return override
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,192 @@
+// pkg/scheduler/timeline.go
+package scheduler
+
+import (
+	"sort"
+	"time"
+)
+
+// maxTimelineRuns bounds the upcoming runs listed per scheduler, so that a
+// wide window over a short interval stays small.
+const maxTimelineRuns = 1000
+
+// Timeline run states.
+const (
+	RunSucceeded = "success"
+	RunFailed    = "failure"
+	RunUpcoming  = "upcoming"
+)
+
+// TimelineRun is an executed or upcoming run of a scheduler.
+type TimelineRun struct {
+	SchedulerID string    `json:"schedulerId"`
+	Name        string    `json:"name,omitempty"`
+	Group       string    `json:"group,omitempty"`
+	Start       time.Time `json:"start"`
+	// End is when an executed run finished. It's empty for upcoming runs.
+	End *time.Time `json:"end,omitempty"`
+	// State is RunSucceeded, RunFailed or RunUpcoming.
+	State      string `json:"state"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Timeline lists the runs of the registered schedulers in a time window.
+type Timeline struct {
+	From time.Time     `json:"from"`
+	To   time.Time     `json:"to"`
+	Runs []TimelineRun `json:"runs"`
+	// History reports whether executed runs are included, which needs a
+	// store that keeps execution history.
+	History bool `json:"history"`
+}
+
+// timelineEntry is a snapshot of a registered job.
+type timelineEntry struct {
+	id     string
+	config Config
+	fs     fireSchedule
+	next   time.Time
+}
+
+// Timeline returns the runs that start in [from, to], ordered by start
+// time: the executions kept by the store and the upcoming fire times of
+// the registered schedulers. Upcoming runs leave out the days a
+// scheduler's calendar doesn't allow and the blackout windows; at most
+// maxTimelineRuns fire times are looked at per scheduler.
+func (s *Scheduler) Timeline(from, to time.Time) (Timeline, error) {
+	tl := Timeline{From: from, To: to, Runs: []TimelineRun{}}
+
+	entries := s.timelineEntries("")
+	executions, history, err := s.executionsBetween(from, to, entries)
+	if err != nil {
+		return Timeline{}, err
+	}
+	tl.History = history
+	configs := make(map[string]Config, len(entries))
+	for _, e := range entries {
+		configs[e.id] = e.config
+	}
+	for _, x := range executions {
+		end := x.StartedAt.Add(x.Duration)
+		r := TimelineRun{
+			SchedulerID: x.SchedulerID,
+			Name:        configs[x.SchedulerID].Name,
+			Group:       configs[x.SchedulerID].Group,
+			Start:       x.StartedAt,
+			End:         &end,
+			State:       RunFailed,
+			StatusCode:  x.StatusCode,
+			Error:       x.Error,
+		}
+		if x.Success {
+			r.State = RunSucceeded
+		}
+		tl.Runs = append(tl.Runs, r)
+	}
+
+	for _, e := range entries {
+		tl.Runs = append(tl.Runs, s.upcoming(e, from, to)...)
+	}
+
+	sortRuns(tl.Runs)
+	return tl, nil
+}
+
+// UpcomingRuns returns the upcoming runs that start in [from, to] of the
+// scheduler registered under id, or of every registered scheduler when id
+// is empty, ordered by start time. They're computed as for Timeline.
+func (s *Scheduler) UpcomingRuns(id string, from, to time.Time) ([]TimelineRun, error) {
+	entries := s.timelineEntries(id)
+	if id != "" && len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+	runs := []TimelineRun{}
+	for _, e := range entries {
+		runs = append(runs, s.upcoming(e, from, to)...)
+	}
+	sortRuns(runs)
+	return runs, nil
+}
+
+// timelineEntries returns a snapshot of the job registered under id, or of
+// every job when id is empty.
+func (s *Scheduler) timelineEntries(id string) []timelineEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]timelineEntry, 0, len(s.jobs))
+	for jobID, j := range s.jobs {
+		if id != "" && jobID != id || !active(j.state) || j.state == StatePaused {
+			continue
+		}
+		entries = append(entries, timelineEntry{
+			id:     jobID,
+			config: j.config,
+			fs:     fireSchedule{anchor: j.anchor, interval: j.interval},
+			next:   j.next,
+		})
+	}
+	return entries
+}
+
+// upcoming returns the fire times of e that start in [from, to] and are
+// not in the past.
+func (s *Scheduler) upcoming(e timelineEntry, from, to time.Time) []TimelineRun {
+	run := TimelineRun{SchedulerID: e.id, Name: e.config.Name, Group: e.config.Group, State: RunUpcoming}
+	e.fs.calendar = s.newCalendar(e.id, e.config.Calendar)
+	start := s.clock.Now()
+	if from.After(start) {
+		start = from
+	}
+	t := e.next
+	if t.Before(start) {
+		t = e.fs.next(start.Add(-time.Nanosecond))
+	}
+	var runs []TimelineRun
+	for n := 0; !t.After(to) && n < maxTimelineRuns; t, n = e.fs.next(t), n+1 {
+		if _, ok := s.blackout(e.config, t); ok {
+			continue
+		}
+		r := run
+		r.Start = t
+		runs = append(runs, r)
+	}
+	return runs
+}
+
+// sortRuns orders runs by start time, then by scheduler ID.
+func sortRuns(runs []TimelineRun) {
+	sort.SliceStable(runs, func(a, b int) bool {
+		if !runs[a].Start.Equal(runs[b].Start) {
+			return runs[a].Start.Before(runs[b].Start)
+		}
+		return runs[a].SchedulerID < runs[b].SchedulerID
+	})
+}
+
+// executionsBetween returns the executions that started in [from, to] and
+// reports whether the store keeps execution history. Stores without
+// ExecutionRangeStore only give the executions of the registered jobs.
+func (s *Scheduler) executionsBetween(from, to time.Time, entries []timelineEntry) ([]Execution, bool, error) {
+	if rs, ok := s.store.(ExecutionRangeStore); ok {
+		list, err := rs.ListExecutionsBetween(from, to)
+		return list, true, err
+	}
+	es, ok := s.store.(ExecutionStore)
+	if !ok {
+		return nil, false, nil
+	}
+	var list []Execution
+	for _, e := range entries {
+		history, err := es.ListExecutions(e.id, 0)
+		if err != nil {
+			return nil, true, err
+		}
+		for _, x := range history {
+			if !x.StartedAt.Before(from) && !x.StartedAt.After(to) {
+				list = append(list, x)
+			}
+		}
+	}
+	return list, true, nil
+}
@@ -0,0 +1,55 @@
+// pkg/scheduler/tracing.go
+package scheduler
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by this package.
+const tracerName = "go-api-scheduler/pkg/scheduler"
+
+// startSpan starts the span of one execution attempt. Spans go to the
+// global OpenTelemetry tracer provider, which discards them unless the
+// application installs one.
+func (j *job) startSpan(run, attempt int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("scheduler.id", j.id),
+		attribute.String("scheduler.job_type", j.config.jobType()),
+		attribute.Int("scheduler.run", run),
+		attribute.Int("scheduler.attempt", attempt),
+	}
+	if j.config.APIURL != "" {
+		attrs = append(attrs, attribute.String("url.full", j.config.APIURL))
+	}
+	return otel.Tracer(tracerName).Start(j.ctx, "scheduler.execute",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan records the outcome of an attempt on its span and ends it.
+func endSpan(span trace.Span, rec Execution) {
+	if rec.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("scheduler.status_code", rec.StatusCode))
+	}
+	if !rec.Success {
+		msg := rec.Error
+		if msg == "" {
+			msg = "unsuccessful result"
+		}
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
+
+// injectTrace adds the W3C traceparent header of the span in ctx to req.
+func injectTrace(ctx context.Context, req *http.Request) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
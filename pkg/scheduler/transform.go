@@ -0,0 +1,143 @@
+// pkg/scheduler/transform.go
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/itchyny/gojq"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// transformTimeout bounds the transformation of a response.
+const transformTimeout = 5 * time.Second
+
+// TransformConfig reshapes each successful response before it is logged,
+// recorded, archived, forwarded or notified, so that only the relevant
+// fields are propagated. Exactly one of JQ and Template is set.
+type TransformConfig struct {
+	// JQ is a jq filter applied to the JSON response, such as
+	// "{total: .meta.total, ids: [.items[].id]}". Its results are encoded
+	// as JSON, one per line.
+	JQ string `json:"jq,omitempty"`
+	// Template is a Go text/template executed with the decoded JSON
+	// response, or the response text when it isn't JSON, as its data. The
+	// json function encodes a value as JSON.
+	Template string `json:"template,omitempty"`
+}
+
+// templateFuncs are the functions available to a Template.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// validate checks the transform settings. A nil TransformConfig is valid.
+func (c *TransformConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if (c.JQ == "") == (c.Template == "") {
+		return i18n.Errorf("%w: transform에는 jq와 template 중 하나만 지정해야 합니다", ErrInvalidConfig)
+	}
+	if c.JQ != "" {
+		if _, err := c.compileJQ(); err != nil {
+			return i18n.Errorf("%w: transform.jq 오류: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.Template != "" {
+		if _, err := c.parseTemplate(); err != nil {
+			return i18n.Errorf("%w: transform.template 오류: %v", ErrInvalidConfig, err)
+		}
+	}
+	return nil
+}
+
+// compileJQ compiles the JQ filter.
+func (c *TransformConfig) compileJQ() (*gojq.Code, error) {
+	query, err := gojq.Parse(c.JQ)
+	if err != nil {
+		return nil, err
+	}
+	return gojq.Compile(query)
+}
+
+// parseTemplate parses the Template.
+func (c *TransformConfig) parseTemplate() (*template.Template, error) {
+	return template.New("transform").Funcs(templateFuncs).Option("missingkey=zero").Parse(c.Template)
+}
+
+// apply returns the response output transformed.
+func (c *TransformConfig) apply(ctx context.Context, output string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, transformTimeout)
+	defer cancel()
+
+	var data any
+	isJSON := json.Unmarshal([]byte(output), &data) == nil
+	if c.Template != "" {
+		t, err := c.parseTemplate()
+		if err != nil {
+			return "", err
+		}
+		if !isJSON {
+			data = output
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	if !isJSON {
+		return "", i18n.Errorf("응답이 JSON이 아닙니다")
+	}
+	code, err := c.compileJQ()
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	iter := code.RunWithContext(ctx, data)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				break
+			}
+			return "", err
+		}
+		b, err := gojq.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, string(b))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// transform applies the job's TransformConfig to the output of the
+// successful execution rec and res. A transformation that fails makes the
+// execution fail with its error.
+func (j *job) transform(ctx context.Context, rec *Execution, res *Result) error {
+	out, err := j.config.Transform.apply(ctx, res.Output)
+	if err != nil {
+		err = i18n.Errorf("응답 변환 오류: %w", err)
+		rec.Success = false
+		rec.Error = j.sched.redactor.Text(err.Error())
+		rec.Timing = res.Timing
+		return err
+	}
+	res.Output = out
+	rec.Output = j.sched.redactor.Body(out)
+	return nil
+}
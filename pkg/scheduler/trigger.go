@@ -0,0 +1,122 @@
+// pkg/scheduler/trigger.go
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// maxTriggers bounds the schedulers triggered by one outcome.
+const maxTriggers = 20
+
+// triggerRequest asks a job to execute now, outside its fire times.
+type triggerRequest struct {
+	// source is the ID of the scheduler whose execution triggered it.
+	source string
+	// chain are the IDs of the schedulers triggered in a row up to and
+	// including source, to stop trigger cycles.
+	chain []string
+}
+
+// validateTriggers checks the OnSuccessTrigger and OnFailureTrigger IDs.
+func (c Config) validateTriggers() error {
+	for name, ids := range map[string][]string{"onSuccessTrigger": c.OnSuccessTrigger, "onFailureTrigger": c.OnFailureTrigger} {
+		if len(ids) > maxTriggers {
+			return i18n.Errorf("%w: %s는 %d개 이하여야 합니다", ErrInvalidConfig, name, maxTriggers)
+		}
+		for _, id := range ids {
+			if strings.TrimSpace(id) == "" {
+				return i18n.Errorf("%w: %s에 빈 스케줄러 ID가 있습니다", ErrInvalidConfig, name)
+			}
+		}
+	}
+	return nil
+}
+
+// wait blocks until the clock reaches t, a trigger arrives or j is
+// stopped. It returns the trigger, if any, and reports whether j is still
+// running.
+func (j *job) wait(t time.Time) (*triggerRequest, bool) {
+	ctx, cancel := context.WithCancel(j.ctx)
+	defer cancel()
+	reached := make(chan bool, 1)
+	go func() { reached <- j.sched.clock.WaitUntil(ctx, t) }()
+	select {
+	case ok := <-reached:
+		return nil, ok
+	case req := <-j.triggers:
+		cancel()
+		<-reached
+		return &req, j.ctx.Err() == nil
+	}
+}
+
+// runTriggered executes j once for req, unless it is paused, in a
+// blackout window or in maintenance mode. Its fire times are unchanged.
+func (j *job) runTriggered(req triggerRequest) {
+	if j.suppressed(j.sched.clock.Now()) {
+		return
+	}
+	j.logAt(LogInfo, "%s 스케줄러의 트리거로 실행합니다.", req.source)
+	j.trigger = &req
+	defer func() { j.trigger = nil }()
+	j.execute()
+}
+
+// triggerNext triggers the schedulers configured for the outcome of the
+// final execution of a run. A scheduler already triggered in the same
+// chain isn't triggered again, so cycles end after one round.
+func (j *job) triggerNext(success bool) {
+	ids := j.config.OnFailureTrigger
+	if success {
+		ids = j.config.OnSuccessTrigger
+	}
+	if len(ids) == 0 {
+		return
+	}
+	chain := []string{j.id}
+	if j.trigger != nil {
+		chain = append(slices.Clone(j.trigger.chain), j.id)
+	}
+	for _, id := range ids {
+		if slices.Contains(chain, id) {
+			j.logAt(LogWarn, "트리거가 순환하여 %s 스케줄러를 다시 실행하지 않습니다: %s", id, strings.Join(append(chain, id), " → "))
+			continue
+		}
+		switch j.sched.sendTrigger(id, triggerRequest{source: j.id, chain: chain}) {
+		case ErrNotFound:
+			j.logAt(LogWarn, "트리거할 스케줄러가 없거나 실행 중이 아닙니다: %s", id)
+		case errTriggerPending:
+			j.logAt(LogInfo, "%s 스케줄러에 이미 대기 중인 트리거가 있어 합칩니다.", id)
+		default:
+			j.logAt(LogInfo, "%s 스케줄러를 트리거했습니다.", id)
+		}
+	}
+}
+
+// errTriggerPending is returned by sendTrigger for a job that hasn't taken
+// its previous trigger yet.
+var errTriggerPending = errors.New("scheduler: trigger pending")
+
+// sendTrigger hands req to the job registered under id. A job that isn't
+// running fails with ErrNotFound; one that hasn't taken its previous
+// trigger yet fails with errTriggerPending, and executes once for both.
+func (s *Scheduler) sendTrigger(id string, req triggerRequest) error {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok || j.ctx.Err() != nil {
+		return ErrNotFound
+	}
+	select {
+	case j.triggers <- req:
+		return nil
+	default:
+		return errTriggerPending
+	}
+}
@@ -0,0 +1,313 @@
+// pkg/scheduler/variable.go
+package scheduler
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// ErrVariableNotFound is returned when no variable is saved under the given name.
+var ErrVariableNotFound = errors.New("scheduler: variable not found")
+
+// Variable is a value shared by schedulers, such as a host name, which
+// they reference as {{var "NAME"}} instead of repeating it.
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Description is free text shown to people.
+	Description string `json:"description,omitempty"`
+}
+
+// Masked returns v with its value replaced as the Redactor masks
+// secrets, for callers who may not read it.
+func (v Variable) Masked() Variable {
+	if v.Value != "" {
+		v.Value = redactedValue
+	}
+	return v
+}
+
+// VariableStore is implemented by stores that also keep variables.
+type VariableStore interface {
+	SaveVariable(v Variable) error
+	DeleteVariable(name string) error
+	LoadVariables() ([]Variable, error)
+}
+
+// variableName matches the names of variables.
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// variableRef matches a {{var "NAME"}} or {{env "NAME"}} reference, with
+// the quotes escaped as \" inside a JSON payload. The quotes keep it apart
+// from the {{name}} variables of chains and workflows.
+var variableRef = regexp.MustCompile(`\{\{\s*(var|env)\s+\\?"([^"\\]*)\\?"\s*\}\}`)
+
+// WithAllowedEnv lets {{env "NAME"}} references read the environment
+// variables with the given names. References to any other are rejected,
+// so that configs sent through the API can't read the secrets of the
+// server; without this option, none is allowed.
+func WithAllowedEnv(names []string) Option {
+	return func(s *Scheduler) {
+		s.settings.allowedEnv = names
+	}
+}
+
+// envAllowed reports whether {{env "NAME"}} references may read name.
+func (s *Scheduler) envAllowed(name string) bool {
+	s.settings.mu.RLock()
+	defer s.settings.mu.RUnlock()
+	return slices.Contains(s.settings.allowedEnv, name)
+}
+
+// SaveVariable creates or replaces the variable with v.Name. Running
+// schedulers use the new value from their next execution.
+func (s *Scheduler) SaveVariable(v Variable) error {
+	if !variableName.MatchString(v.Name) {
+		return i18n.Errorf("%w: 변수 이름은 문자 또는 '_'로 시작하고 문자, 숫자, '_', '.', '-'로 이루어져야 합니다: %q", ErrInvalidConfig, v.Name)
+	}
+	if vs, ok := s.store.(VariableStore); ok {
+		if err := vs.SaveVariable(v); err != nil {
+			return err
+		}
+	}
+	s.varMu.Lock()
+	s.variables[v.Name] = v
+	s.varMu.Unlock()
+	return nil
+}
+
+// DeleteVariable removes the variable with the given name. Schedulers
+// still referencing it fail their executions.
+func (s *Scheduler) DeleteVariable(name string) error {
+	s.varMu.Lock()
+	defer s.varMu.Unlock()
+	if _, ok := s.variables[name]; !ok {
+		return ErrVariableNotFound
+	}
+	if vs, ok := s.store.(VariableStore); ok {
+		if err := vs.DeleteVariable(name); err != nil {
+			return err
+		}
+	}
+	delete(s.variables, name)
+	return nil
+}
+
+// Variable returns the variable with the given name.
+func (s *Scheduler) Variable(name string) (Variable, error) {
+	s.varMu.RLock()
+	defer s.varMu.RUnlock()
+	v, ok := s.variables[name]
+	if !ok {
+		return Variable{}, ErrVariableNotFound
+	}
+	return v, nil
+}
+
+// Variables returns every variable, ordered by name.
+func (s *Scheduler) Variables() []Variable {
+	s.varMu.RLock()
+	defer s.varMu.RUnlock()
+	list := make([]Variable, 0, len(s.variables))
+	for _, v := range s.variables {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list
+}
+
+// loadVariables reads the variables kept by the store, if it keeps any.
+func (s *Scheduler) loadVariables() error {
+	vs, ok := s.store.(VariableStore)
+	if !ok {
+		return nil
+	}
+	list, err := vs.LoadVariables()
+	if err != nil {
+		return err
+	}
+	s.varMu.Lock()
+	defer s.varMu.Unlock()
+	for _, v := range list {
+		s.variables[v.Name] = v
+	}
+	return nil
+}
+
+// substitution replaces the variable references in the strings of a
+// config, keeping the first reference it can't resolve as err, all of
+// them, as written, in unresolved and the environment variables it isn't
+// allowed to read in denied.
+type substitution struct {
+	s          *Scheduler
+	err        error
+	unresolved []string
+	denied     []string
+}
+
+// str replaces the references in *p, escaping the values for ctx.
+func (sub *substitution) str(p *string, ctx refContext) {
+	*p = replaceRefs(*p, variableRef, ctx, func(m []string) string {
+		if m[1] == "env" {
+			if !sub.s.envAllowed(m[2]) {
+				sub.denied = append(sub.denied, m[2])
+				sub.fail(m[0], i18n.Errorf("허용되지 않은 환경 변수입니다: %s", m[2]))
+				return ""
+			}
+			v, ok := os.LookupEnv(m[2])
+			if !ok {
+				sub.fail(m[0], i18n.Errorf("환경 변수가 설정되지 않았습니다: %s", m[2]))
+			}
+			return v
+		}
+		v, err := sub.s.Variable(m[2])
+		if err != nil {
+			sub.fail(m[0], i18n.Errorf("정의되지 않은 전역 변수입니다: %s", m[2]))
+		}
+		return v.Value
+	})
+}
+
+// fail records the reference ref that couldn't be resolved for err.
+func (sub *substitution) fail(ref string, err error) {
+	if sub.err == nil {
+		sub.err = err
+	}
+	sub.unresolved = append(sub.unresolved, strings.ReplaceAll(ref, `\"`, `"`))
+}
+
+// passwordEnv sets the password of a from the environment variable named
+// by its PasswordEnv, unless it has a Password.
+func (sub *substitution) passwordEnv(a *AuthConfig) {
+	name := a.PasswordEnv
+	a.PasswordEnv = ""
+	if a.Password != "" {
+		return
+	}
+	if !sub.s.envAllowed(name) {
+		sub.denied = append(sub.denied, name)
+		sub.fail("auth.passwordEnv: "+name, i18n.Errorf("허용되지 않은 환경 변수입니다: %s", name))
+		return
+	}
+	a.Password = os.Getenv(name)
+}
+
+// strs returns a copy of list with the references replaced.
+func (sub *substitution) strs(list []string, ctx refContext) []string {
+	out := make([]string, len(list))
+	for i, v := range list {
+		sub.str(&v, ctx)
+		out[i] = v
+	}
+	return out
+}
+
+// strMap returns a copy of m with the references in its values replaced.
+func (sub *substitution) strMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		sub.str(&v, inText)
+		out[k] = v
+	}
+	return out
+}
+
+// step replaces the references in the request of a chain or workflow step.
+func (sub *substitution) step(step *ChainStep) {
+	sub.str(&step.APIURL, inURL)
+	sub.str(&step.Payload, inJSON)
+	step.Headers = sub.strMap(step.Headers)
+}
+
+// applyVariables returns config with the {{var "NAME"}} and {{env "NAME"}}
+// references in its URLs, payloads and header values replaced by the
+// global variables and the allowed environment variables of the process,
+// escaped for where they are, the password of its auth read from
+// auth.passwordEnv and the AWS credentials of the environment, if allowed,
+// put into signing.aws. The config of the job is left as is.
+func (s *Scheduler) applyVariables(config Config) (Config, error) {
+	config, sub := s.substitute(config)
+	return config, sub.err
+}
+
+// substitute is applyVariables, returning the substitution to tell the
+// references that couldn't be resolved.
+func (s *Scheduler) substitute(config Config) (Config, *substitution) {
+	sub := &substitution{s: s}
+	sub.str(&config.APIURL, inURL)
+	sub.str(&config.Payload, config.payloadContext())
+	config.Headers = sub.strMap(config.Headers)
+	if config.Auth != nil && config.Auth.PasswordEnv != "" {
+		auth := *config.Auth
+		sub.passwordEnv(&auth)
+		config.Auth = &auth
+	}
+	if config.Signing != nil && config.Signing.AWS != nil {
+		signing := *config.Signing
+		signing.AWS = s.awsCredentials(signing.AWS)
+		config.Signing = &signing
+	}
+	if config.Session != nil && config.Session.Login != nil {
+		login := *config.Session.Login
+		sub.str(&login.APIURL, inURL)
+		sub.str(&login.Payload, inJSON)
+		login.Headers = sub.strMap(login.Headers)
+		config.Session = &SessionConfig{Login: &login}
+	}
+	if config.Chain != nil {
+		chain := *config.Chain
+		chain.Vars = sub.strMap(chain.Vars)
+		chain.Steps = append([]ChainStep(nil), chain.Steps...)
+		for i := range chain.Steps {
+			sub.step(&chain.Steps[i])
+		}
+		config.Chain = &chain
+	}
+	if config.Workflow != nil {
+		wf := *config.Workflow
+		wf.Vars = sub.strMap(wf.Vars)
+		wf.Steps = append([]WorkflowStep(nil), wf.Steps...)
+		for i := range wf.Steps {
+			sub.step(&wf.Steps[i].ChainStep)
+		}
+		config.Workflow = &wf
+	}
+	if config.Fanout != nil {
+		fanout := *config.Fanout
+		fanout.Targets = sub.strs(fanout.Targets, inURL)
+		config.Fanout = &fanout
+	}
+	return config, sub
+}
+
+// checkEnv rejects the {{env "NAME"}} references and auth.passwordEnv of
+// c naming environment variables that aren't allowed by WithAllowedEnv.
+func (s *Scheduler) checkEnv(c Config) error {
+	_, sub := s.substitute(c)
+	if len(sub.denied) > 0 {
+		return i18n.Errorf("%w: 허용되지 않은 환경 변수입니다: %s", ErrInvalidConfig, strings.Join(sub.denied, ", "))
+	}
+	return nil
+}
+
+// payloadContext returns how values substituted into the payload of c
+// are escaped: per JSON for form and JSON payloads, per URL query for a
+// raw query and not at all for text and binary payloads.
+func (c Config) payloadContext() refContext {
+	switch {
+	case c.payloadType() == PayloadText || c.payloadType() == PayloadBinary:
+		return inText
+	case c.RawQuery:
+		return inQuery
+	}
+	return inJSON
+}
@@ -0,0 +1,152 @@
+// pkg/scheduler/version.go
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVersionNotFound is returned by Rollback when the scheduler has no such version.
+var ErrVersionNotFound = errors.New("scheduler: version not found")
+
+// Version is one entry of a scheduler's configuration history.
+type Version struct {
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	// Action is "start", "update" or "rollback".
+	Action string `json:"action"`
+	Config Config `json:"config"`
+}
+
+// VersionStore is implemented by stores that also keep the configuration
+// history of schedulers.
+type VersionStore interface {
+	SaveVersion(schedulerID string, v Version) error
+	// ListVersions returns the versions of a scheduler, oldest first.
+	ListVersions(schedulerID string) ([]Version, error)
+}
+
+// Update replaces the configuration of the scheduler registered under id
+// and restarts it, unless it was stopped. The previous configuration stays
+// in its version history. The new configuration must be within the limits
+// set with WithLimits.
+func (s *Scheduler) Update(id string, config Config) error {
+	return s.update(id, config, "update", "")
+}
+
+// Rollback restarts the scheduler registered under id with the
+// configuration it had at the given version, recorded as a new version.
+func (s *Scheduler) Rollback(id string, version int) error {
+	versions, err := s.Versions(id)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return s.update(id, v.Config, "rollback", fmt.Sprint(version))
+		}
+	}
+	return ErrVersionNotFound
+}
+
+// Versions returns the configuration history of a scheduler, oldest first.
+func (s *Scheduler) Versions(id string) ([]Version, error) {
+	if vs, ok := s.store.(VersionStore); ok {
+		return vs.ListVersions(id)
+	}
+	s.verMu.Lock()
+	defer s.verMu.Unlock()
+	return append([]Version(nil), s.versions[id]...), nil
+}
+
+// update restarts the scheduler registered under id with config and records
+// the change under action. Values masked by Status and the owner are kept
+// from the current config.
+func (s *Scheduler) update(id string, config Config, action, detail string) error {
+	s.mu.Lock()
+	if current, ok := s.jobs[id]; ok {
+		config = unredact(config, current.config)
+		config.Owner = current.config.Owner
+	}
+	s.mu.Unlock()
+
+	config, err := s.applyTemplate(config)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(config); err != nil {
+		return err
+	}
+	anchor, err := config.firstFire(s.clock.Now())
+	if err != nil {
+		return err
+	}
+	interval, _ := config.interval()
+
+	s.syncMu.Lock()
+	s.mu.Lock()
+	old, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		return ErrNotFound
+	}
+	if err := s.checkLimitsLocked(id, config); err != nil {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		return err
+	}
+	if to, err := old.stopLocked(); err == nil {
+		defer old.publishState(to)
+	}
+	j := s.newJob(id, config, anchor, interval, time.Time{})
+	// Stopped schedulers keep their state until they are restarted.
+	stopped := old.state == StateStopped || old.state == StateCompleted
+	if stopped {
+		j.recordState(j.state, old.state)
+	}
+	s.jobs[id] = j
+	s.mu.Unlock()
+	s.persist(j)
+	s.syncMu.Unlock()
+
+	s.audit(id, action, detail)
+	s.saveVersion(id, action, config)
+	if stopped {
+		s.emit(id, "스케줄러 설정이 변경되었습니다. 중지된 상태를 유지합니다.")
+		return nil
+	}
+	s.emit(id, "설정이 변경되어 스케줄러를 다시 시작합니다.")
+
+	go j.run()
+	return nil
+}
+
+// saveVersion appends config to the version history of the scheduler.
+func (s *Scheduler) saveVersion(id, action string, config Config) {
+	versions, err := s.Versions(id)
+	if err != nil {
+		s.emit(id, "설정 이력 조회 오류: %v", err)
+		return
+	}
+	v := Version{
+		Version: 1,
+		Time:    s.clock.Now(),
+		Action:  action,
+		Config:  config,
+	}
+	if n := len(versions); n > 0 {
+		v.Version = versions[n-1].Version + 1
+	}
+
+	if vs, ok := s.store.(VersionStore); ok {
+		if err := vs.SaveVersion(id, v); err != nil {
+			s.emit(id, "설정 이력 저장 오류: %v", err)
+		}
+		return
+	}
+	s.verMu.Lock()
+	s.versions[id] = append(s.versions[id], v)
+	s.verMu.Unlock()
+}
@@ -0,0 +1,110 @@
+// pkg/scheduler/watchdog.go
+package scheduler
+
+import (
+	"time"
+)
+
+// DefaultStallGrace is how long past its fire time a scheduler may go
+// without executing before CheckStalls flags it.
+const DefaultStallGrace = time.Minute
+
+// Stall describes a scheduler whose fire time passed without its run loop
+// executing it, e.g. because the goroutine is stuck or the execution pool
+// is blocked.
+type Stall struct {
+	// Since is when the stall was detected.
+	Since time.Time `json:"since"`
+	// NextRun is the overdue fire time, zero when the run loop never
+	// started.
+	NextRun time.Time `json:"nextRun,omitempty"`
+	// Heartbeat is the last sign of life of the run loop.
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+// StallEvent is sent to the webhook of a scheduler that stalled, and again
+// with Recovered when its run loop made progress.
+type StallEvent struct {
+	SchedulerID string `json:"schedulerId"`
+	Name        string `json:"name,omitempty"`
+	Stall       Stall  `json:"stall"`
+	Recovered   bool   `json:"recovered"`
+}
+
+// beat records a heartbeat of the run loop of j: it scheduled its next
+// fire time or an execution got its pool slot.
+func (j *job) beat() {
+	j.sched.mu.Lock()
+	j.heartbeat = j.sched.clock.Now()
+	j.sched.mu.Unlock()
+}
+
+// stalledLocked reports whether j hasn't made progress for grace past its
+// fire time, or hasn't started for grace since it was registered. The
+// scheduler's mu must be held.
+func (j *job) stalledLocked(now time.Time, grace time.Duration) bool {
+	if !active(j.state) {
+		return false
+	}
+	if j.state == StatePending {
+		return now.Sub(j.stateSince) > grace
+	}
+	return !j.next.IsZero() && now.Sub(j.next) > grace && j.heartbeat.Before(j.next)
+}
+
+// CheckStalls flags the schedulers whose fire time passed more than grace
+// ago without their run loop executing it, and clears the flag of those
+// that made progress since. Both are logged, published as EventStalled and
+// EventUnstalled events and sent to the scheduler's webhook. Stalled
+// schedulers report their Stall in Status. It returns the number of
+// stalled schedulers.
+//
+// Call it periodically, with DefaultStallGrace or more than the longest
+// expected queueing for the execution pool.
+func (s *Scheduler) CheckStalls(grace time.Duration) int {
+	now := s.clock.Now()
+	var stalled, recovered []*job
+	count := 0
+
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		switch {
+		case j.stalledLocked(now, grace):
+			count++
+			if j.stall == nil {
+				j.stall = &Stall{Since: now, NextRun: j.next, Heartbeat: j.heartbeat}
+				stalled = append(stalled, j)
+			}
+		case j.stall != nil:
+			if active(j.state) {
+				recovered = append(recovered, j)
+			} else {
+				j.stall = nil
+			}
+		}
+	}
+	events := make([]Event, 0, len(stalled)+len(recovered))
+	for _, j := range stalled {
+		events = append(events, Event{Type: EventStalled, Time: now, SchedulerID: j.id, Stall: j.stall, job: j})
+	}
+	for _, j := range recovered {
+		events = append(events, Event{Type: EventUnstalled, Time: now, SchedulerID: j.id, Stall: j.stall, job: j})
+		j.stall = nil
+	}
+	s.mu.Unlock()
+
+	// The job's loggers belong to its run goroutine.
+	for _, ev := range events {
+		switch {
+		case ev.Type == EventUnstalled:
+			s.emit(ev.SchedulerID, "멈췄던 스케줄러가 다시 진행됩니다.")
+		case !ev.job.logs(LogError):
+		case ev.Stall.NextRun.IsZero():
+			s.emit(ev.SchedulerID, "스케줄러가 %s 동안 시작되지 않았습니다. 멈춘 것으로 보입니다.", grace)
+		default:
+			s.emit(ev.SchedulerID, "실행 시각 %s 이후 %s 동안 실행되지 않았습니다. 스케줄러가 멈춘 것으로 보입니다.", ev.Stall.NextRun.Format("2006-01-02 15:04:05"), grace)
+		}
+		s.bus.publish(ev)
+	}
+	return count
+}
@@ -0,0 +1,503 @@
+// pkg/scheduler/workflow.go
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// JobTypeWorkflow runs a graph of HTTP requests on every fire time. A step
+// starts once the steps it depends on are done, so independent steps run
+// in parallel.
+const JobTypeWorkflow = "workflow"
+
+// maxWorkflowSteps bounds the steps of a workflow.
+const maxWorkflowSteps = 50
+
+// The states of a workflow step.
+const (
+	StepPending   = "pending"
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped"
+)
+
+// WorkflowConfig configures a JobTypeWorkflow job.
+type WorkflowConfig struct {
+	// Vars are the initial variables of the shared context. They are
+	// referenced as {{name}} in the URL, payload and header values of the
+	// steps, and a step's Extract adds to them for the steps after it.
+	Vars  map[string]string `json:"vars,omitempty"`
+	Steps []WorkflowStep    `json:"steps"`
+	// Concurrency bounds the steps running at once. Zero means 4.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// WorkflowStep is one HTTP request of a workflow, or an approval gate. The
+// request is sent like a ChainStep's; Name is required and unique.
+type WorkflowStep struct {
+	ChainStep
+	// Type is empty for an HTTP request, or StepTypeApproval for a step
+	// that waits for an operator's decision instead.
+	Type     string          `json:"type,omitempty"`
+	Approval *ApprovalConfig `json:"approval,omitempty"`
+	// DependsOn names the steps that must be done before this one starts.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// When is a condition on the steps before this one, such as
+	// "steps.check.statusCode == 404". Paths start at steps.<name>
+	// (status, success, statusCode, body, durationMs) or vars. Without
+	// When a step runs only if all of its dependencies succeeded; with
+	// When it runs once they are done, whatever their outcome, and the
+	// condition holds. A step is skipped otherwise.
+	When string `json:"when,omitempty"`
+	// Retry re-sends the request of a failed step.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// validate checks the workflow settings. A nil WorkflowConfig is valid.
+func (c *WorkflowConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Steps) == 0 || len(c.Steps) > maxWorkflowSteps {
+		return i18n.Errorf("%w: workflow.steps는 1개에서 %d개 사이여야 합니다", ErrInvalidConfig, maxWorkflowSteps)
+	}
+	if c.Concurrency < 0 || c.Concurrency > maxWorkflowSteps {
+		return i18n.Errorf("%w: workflow.concurrency는 0에서 %d 사이여야 합니다", ErrInvalidConfig, maxWorkflowSteps)
+	}
+	names := make(map[string]bool, len(c.Steps))
+	for _, step := range c.Steps {
+		if step.Name == "" || !isName(step.Name) {
+			return i18n.Errorf("%w: workflow 단계의 이름은 문자, 숫자, '_', '-'로 이루어져야 합니다: %q", ErrInvalidConfig, step.Name)
+		}
+		if names[step.Name] {
+			return i18n.Errorf("%w: workflow 단계의 이름이 중복됩니다: %s", ErrInvalidConfig, step.Name)
+		}
+		names[step.Name] = true
+	}
+	for _, step := range c.Steps {
+		switch step.Type {
+		case "":
+			if err := (&ChainConfig{Steps: []ChainStep{step.ChainStep}}).validate(); err != nil {
+				return err
+			}
+		case StepTypeApproval:
+			if err := step.Approval.validate(step.Name); err != nil {
+				return err
+			}
+		default:
+			return i18n.Errorf("%w: %s 단계의 type이 올바르지 않습니다: %q", ErrInvalidConfig, step.Name, step.Type)
+		}
+		for _, dep := range step.DependsOn {
+			if !names[dep] || dep == step.Name {
+				return i18n.Errorf("%w: %s 단계의 dependsOn에 알 수 없는 단계가 있습니다: %q", ErrInvalidConfig, step.Name, dep)
+			}
+		}
+		if step.When != "" {
+			if _, err := parseExpr(step.When, "steps", "vars"); err != nil {
+				return i18n.Errorf("%w: %s 단계의 when 식 오류: %v", ErrInvalidConfig, step.Name, err)
+			}
+		}
+		if err := step.Retry.validate(); err != nil {
+			return err
+		}
+	}
+	if cycle := c.cycle(); cycle != nil {
+		return i18n.Errorf("%w: workflow 단계의 의존 관계가 순환합니다: %s", ErrInvalidConfig, strings.Join(cycle, " → "))
+	}
+	return nil
+}
+
+// cycle returns the names of steps that depend on each other in a cycle,
+// with the first repeated at the end, or nil when the steps form a DAG.
+func (c *WorkflowConfig) cycle() []string {
+	deps := make(map[string][]string, len(c.Steps))
+	for _, step := range c.Steps {
+		deps[step.Name] = step.DependsOn
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make(map[string]int, len(c.Steps))
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch marks[name] {
+		case visiting:
+			i := slices.Index(path, name)
+			return append(slices.Clone(path[i:]), name)
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		return nil
+	}
+	for _, step := range c.Steps {
+		if cycle := visit(step.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// concurrency returns the number of steps run at once.
+func (c *WorkflowConfig) concurrency() int {
+	if c.Concurrency <= 0 {
+		return 4
+	}
+	return c.Concurrency
+}
+
+// WorkflowStepReport is the outcome of one step in a WorkflowReport.
+type WorkflowStepReport struct {
+	Name string `json:"name"`
+	// State is one of the Step constants.
+	State      string  `json:"state"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	Attempts   int     `json:"attempts,omitempty"`
+	DurationMs float64 `json:"durationMs"`
+	// Error is why a step failed or was skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// WorkflowReport is the JSON output of a JobTypeWorkflow execution. Steps
+// are in the order of the configuration; Output is the response body of
+// the last step in that order that ran. Variable values are left out,
+// since they often hold credentials.
+type WorkflowReport struct {
+	Steps  []WorkflowStepReport `json:"steps"`
+	Output string               `json:"output,omitempty"`
+}
+
+// WorkflowExecutor runs the steps of a JobTypeWorkflow job through
+// Executor, which is the HTTP executor when it's registered by New.
+type WorkflowExecutor struct {
+	Executor Executor
+}
+
+// workflowStepRun is the outcome of a step kept for the When conditions of
+// the steps after it.
+type workflowStepRun struct {
+	report WorkflowStepReport
+	res    Result
+	// vars are the variables the step extracted.
+	vars map[string]string
+}
+
+// Execute runs the steps in waves: every step whose dependencies are done
+// is started, up to the configured concurrency, and the variables they
+// extract are merged in the order of the configuration once the wave is
+// done. The workflow succeeds when no step failed. Steps that can never
+// start, in a cycle or after a step that doesn't exist, fail it: configs
+// are checked for them, but Execute may be called with any.
+func (e *WorkflowExecutor) Execute(ctx context.Context, config Config) (Result, error) {
+	wc := config.Workflow
+	if wc == nil || len(wc.Steps) == 0 {
+		return Result{}, i18n.Errorf("워크플로 설정(workflow)이 없습니다")
+	}
+	vars := maps.Clone(wc.Vars)
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	runs := make(map[string]*workflowStepRun, len(wc.Steps))
+	chain := &ChainExecutor{Executor: e.Executor}
+	sem := make(chan struct{}, wc.concurrency())
+
+	for len(runs) < len(wc.Steps) {
+		decided := len(runs)
+		var wave []int
+		for i, step := range wc.Steps {
+			if runs[step.Name] != nil || !depsDone(step, runs) {
+				continue
+			}
+			if reason := skipReason(step, runs, vars); reason != "" {
+				runs[step.Name] = &workflowStepRun{report: WorkflowStepReport{Name: step.Name, State: StepSkipped, Error: reason}}
+				continue
+			}
+			wave = append(wave, i)
+		}
+		if len(wave) == 0 {
+			if len(runs) > decided {
+				continue
+			}
+			var stuck []string
+			for _, step := range wc.Steps {
+				if runs[step.Name] == nil {
+					stuck = append(stuck, step.Name)
+				}
+			}
+			return Result{}, i18n.Errorf("의존 단계가 끝나지 않아 실행할 수 없는 워크플로 단계가 있습니다: %s", strings.Join(stuck, ", "))
+		}
+
+		done := make([]*workflowStepRun, len(wave))
+		var wg sync.WaitGroup
+		var p panics
+		for k, i := range wave {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() { <-sem; wg.Done() }()
+				defer p.catch()
+				done[k] = e.run(ctx, chain, config, wc.Steps[i], i, maps.Clone(vars))
+			}()
+		}
+		wg.Wait()
+		if p.err != nil {
+			return Result{}, p.err
+		}
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		for k, i := range wave {
+			runs[wc.Steps[i].Name] = done[k]
+			maps.Copy(vars, done[k].vars)
+		}
+	}
+
+	var report WorkflowReport
+	var result Result
+	var failed []string
+	for _, step := range wc.Steps {
+		run := runs[step.Name]
+		report.Steps = append(report.Steps, run.report)
+		switch run.report.State {
+		case StepFailed:
+			failed = append(failed, step.Name)
+			fallthrough
+		case StepSucceeded:
+			report.Output = run.res.Output
+			result.StatusCode = run.res.StatusCode
+			result.Timing = run.res.Timing
+		}
+	}
+	out, err := json.Marshal(report)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Output = string(out)
+	result.Success = len(failed) == 0
+	if len(failed) > 0 {
+		return result, i18n.Errorf("실패한 단계: %s", strings.Join(failed, ", "))
+	}
+	return result, nil
+}
+
+// run sends the request of the i-th step, retrying it as its Retry policy
+// allows. vars is the step's own copy of the shared context.
+func (e *WorkflowExecutor) run(ctx context.Context, chain *ChainExecutor, config Config, step WorkflowStep, i int, vars map[string]string) *workflowStepRun {
+	if step.Type == StepTypeApproval {
+		return e.approve(ctx, step)
+	}
+	run := &workflowStepRun{report: WorkflowStepReport{Name: step.Name}}
+	backoff, _ := step.Retry.backoff()
+	for attempt := 1; ; attempt++ {
+		sr, res, err := chain.step(ctx, config, step.ChainStep, i, vars)
+		if err != nil && sr.Error == "" {
+			sr.Error = err.Error()
+		}
+		run.res = res
+		run.report.StatusCode = sr.StatusCode
+		run.report.Attempts = attempt
+		run.report.DurationMs += sr.DurationMs
+		run.report.Error = sr.Error
+		if err == nil && sr.Success {
+			run.report.State = StepSucceeded
+			run.vars = make(map[string]string, len(step.Extract))
+			for name := range step.Extract {
+				run.vars[name] = vars[name]
+			}
+			return run
+		}
+		run.report.State = StepFailed
+		if attempt >= step.Retry.attempts() || ctx.Err() != nil {
+			return run
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return run
+		}
+		backoff *= 2
+	}
+}
+
+// depsDone reports whether every dependency of step has an outcome.
+func depsDone(step WorkflowStep, runs map[string]*workflowStepRun) bool {
+	for _, dep := range step.DependsOn {
+		if runs[dep] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// skipReason returns why step, whose dependencies are done, must be
+// skipped, or "" when it runs.
+func skipReason(step WorkflowStep, runs map[string]*workflowStepRun, vars map[string]string) string {
+	for _, dep := range step.DependsOn {
+		switch runs[dep].report.State {
+		case StepSkipped:
+			return i18n.Sprintf(i18n.Default(), "의존 단계 %s를 건너뛰었습니다", dep)
+		case StepFailed:
+			if step.When == "" {
+				return i18n.Sprintf(i18n.Default(), "의존 단계 %s가 실패했습니다", dep)
+			}
+		}
+	}
+	if step.When == "" {
+		return ""
+	}
+	c, err := parseExpr(step.When, "steps", "vars")
+	if err != nil {
+		return err.Error()
+	}
+	steps := make(map[string]any, len(runs))
+	for name, run := range runs {
+		var body any = run.res.Output
+		var parsed any
+		if json.Unmarshal([]byte(run.res.Output), &parsed) == nil {
+			body = parsed
+		}
+		steps[name] = map[string]any{
+			"status":     run.report.State,
+			"success":    run.report.State == StepSucceeded,
+			"statusCode": float64(run.report.StatusCode),
+			"body":       body,
+			"durationMs": run.report.DurationMs,
+		}
+	}
+	scope := make(map[string]any, len(vars))
+	for k, v := range vars {
+		scope[k] = v
+	}
+	if !evalCondition(c, map[string]any{"steps": steps, "vars": scope}) {
+		return i18n.Sprintf(i18n.Default(), "실행 조건(when)을 만족하지 않습니다: %s", step.When)
+	}
+	return ""
+}
+
+// WorkflowNode is a step in a WorkflowGraph.
+type WorkflowNode struct {
+	Name string `json:"name"`
+	// Type is the step's WorkflowStep.Type; approval steps have no
+	// request.
+	Type       string `json:"type,omitempty"`
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	APIURL     string `json:"apiURL,omitempty"`
+	When       string `json:"when,omitempty"`
+	// State is the step's state in the last execution, or StepPending
+	// before the first.
+	State      string  `json:"state"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	Attempts   int     `json:"attempts,omitempty"`
+	DurationMs float64 `json:"durationMs,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// WorkflowEdge is a dependency in a WorkflowGraph: To depends on From.
+type WorkflowEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Conditional is set when To has a When condition.
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+// WorkflowGraph is the step graph of a JobTypeWorkflow scheduler with the
+// outcome of its last execution.
+type WorkflowGraph struct {
+	SchedulerID string `json:"schedulerId"`
+	// ExecutionID and StartedAt identify the last execution, if any.
+	ExecutionID string         `json:"executionId,omitempty"`
+	StartedAt   *time.Time     `json:"startedAt,omitempty"`
+	Nodes       []WorkflowNode `json:"nodes"`
+	Edges       []WorkflowEdge `json:"edges"`
+}
+
+// workflowRun is the last execution of a JobTypeWorkflow job.
+type workflowRun struct {
+	executionID string
+	startedAt   time.Time
+	steps       map[string]WorkflowStepReport
+}
+
+// recordWorkflow keeps the step outcomes of the execution rec, whose
+// output, as the executor returned it, is a WorkflowReport.
+func (j *job) recordWorkflow(rec Execution, output string) {
+	var report WorkflowReport
+	if json.Unmarshal([]byte(output), &report) != nil || len(report.Steps) == 0 {
+		return
+	}
+	run := &workflowRun{executionID: rec.ID, startedAt: rec.StartedAt, steps: make(map[string]WorkflowStepReport, len(report.Steps))}
+	for _, sr := range report.Steps {
+		sr.Error = j.sched.redactor.Text(sr.Error)
+		run.steps[sr.Name] = sr
+	}
+	j.sched.mu.Lock()
+	j.workflow = run
+	j.sched.mu.Unlock()
+}
+
+// WorkflowGraph returns the step graph of the JobTypeWorkflow scheduler
+// registered under id, with the outcome of its last execution.
+func (s *Scheduler) WorkflowGraph(id string) (WorkflowGraph, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return WorkflowGraph{}, ErrNotFound
+	}
+	config := s.redactor.Config(j.config)
+	if config.jobType() != JobTypeWorkflow || config.Workflow == nil {
+		return WorkflowGraph{}, i18n.Errorf("%w: 워크플로(workflow) 유형의 스케줄러가 아닙니다", ErrInvalidConfig)
+	}
+	g := WorkflowGraph{SchedulerID: id, Nodes: []WorkflowNode{}, Edges: []WorkflowEdge{}}
+	if j.workflow != nil {
+		g.ExecutionID = j.workflow.executionID
+		g.StartedAt = &j.workflow.startedAt
+	}
+	for _, step := range config.Workflow.Steps {
+		node := WorkflowNode{
+			Name:  step.Name,
+			Type:  step.Type,
+			When:  step.When,
+			State: StepPending,
+		}
+		if step.Type == "" {
+			node.HTTPMethod, node.APIURL = "GET", step.APIURL
+			if strings.ToUpper(step.HTTPMethod) == "POST" {
+				node.HTTPMethod = "POST"
+			}
+		}
+		if j.workflow != nil {
+			if sr, ok := j.workflow.steps[step.Name]; ok {
+				node.State = sr.State
+				node.StatusCode = sr.StatusCode
+				node.Attempts = sr.Attempts
+				node.DurationMs = sr.DurationMs
+				node.Error = sr.Error
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+		for _, dep := range step.DependsOn {
+			g.Edges = append(g.Edges, WorkflowEdge{From: dep, To: step.Name, Conditional: step.When != ""})
+		}
+	}
+	return g, nil
+}
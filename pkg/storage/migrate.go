@@ -0,0 +1,138 @@
+// pkg/storage/migrate.go
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"go-api-scheduler/pkg/i18n"
+)
+
+// Migration changes the schema of a SQL database to Version from the
+// version before it.
+type Migration struct {
+	Version int
+	// Name describes the change, e.g. "create_tables".
+	Name string
+	// Up applies the change. It runs in a transaction with the record of
+	// the migration, so a failed migration leaves no trace.
+	Up func(tx *sql.Tx) error
+}
+
+// migrationFile matches the names of migration files, such as
+// "0001_create_tables.sql".
+var migrationFile = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// SQLMigrations reads the migrations in the files of dir in fsys, usually
+// an embed.FS, named like "0001_create_tables.sql": the version, an
+// underscore and the name. Each file is executed as one statement batch.
+func SQLMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []Migration
+	for _, e := range entries {
+		m := migrationFile.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		script, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    m[2],
+			Up: func(tx *sql.Tx) error {
+				_, err := tx.Exec(string(script))
+				return err
+			},
+		})
+	}
+	return migrations, nil
+}
+
+// Migrate applies the migrations db hasn't had yet in order of version,
+// and records them in the schema_migrations table. It fails when db was
+// migrated by a newer release, past the last of migrations, since this
+// one might misread it. Versions must be unique and positive.
+//
+// The statements of the runner itself are portable SQL without
+// placeholders, so drivers with any placeholder syntax can use it.
+func Migrate(db *sql.DB, migrations []Migration) error {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	for i, m := range migrations {
+		if m.Version <= 0 || i > 0 && migrations[i-1].Version == m.Version {
+			return i18n.Errorf("잘못된 마이그레이션 버전입니다: %d", m.Version)
+		}
+	}
+
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at INTEGER NOT NULL
+)`); err != nil {
+		return err
+	}
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+	if latest := latestVersion(migrations); current > latest {
+		return i18n.Errorf("데이터베이스 스키마 버전 %d이 지원하는 버전 %d보다 높습니다", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := apply(db, m); err != nil {
+			return i18n.Errorf("마이그레이션 %d_%s 실패: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// latestVersion returns the highest version of the sorted migrations.
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// apply runs m and records it in one transaction.
+func apply(db *sql.DB, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := m.Up(tx); err != nil {
+		return err
+	}
+	record := fmt.Sprintf(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (%d, '%s', %d)`,
+		m.Version, sanitizeName(m.Name), time.Now().Unix())
+	if _, err := tx.Exec(record); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// unsafeName matches the characters of migration names that aren't safe
+// to quote in SQL.
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9_ -]`)
+
+// sanitizeName removes the unsafe characters of a migration name.
+func sanitizeName(name string) string {
+	return unsafeName.ReplaceAllString(name, "")
+}
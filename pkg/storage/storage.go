@@ -0,0 +1,109 @@
+// pkg/storage/storage.go
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"go-api-scheduler/pkg/i18n"
+	"go-api-scheduler/pkg/scheduler"
+)
+
+// Memory is the driver that persists nothing. Open returns a nil Store for
+// it, which leaves the scheduler without a store.
+const Memory = "memory"
+
+// Store is a persistence backend of the scheduler. Besides the scheduler
+// records, a driver keeps whatever else it supports by implementing the
+// optional interfaces of the scheduler package: scheduler.ExecutionStore,
+// scheduler.ExecutionRangeStore, scheduler.PurgeStore,
+// scheduler.AuditStore, scheduler.VersionStore, scheduler.TemplateStore
+// and scheduler.Locker. The scheduler and the handlers detect them, so a
+// new backend needs no changes outside its own package.
+type Store interface {
+	scheduler.Store
+	// Close releases the connections and files of the store.
+	Close() error
+}
+
+// HistoryStore is a Store that also keeps the execution history and the
+// audit trail.
+type HistoryStore interface {
+	Store
+	scheduler.ExecutionStore
+	scheduler.AuditStore
+}
+
+// Options are the storage settings of the server config passed to a
+// driver. Each driver uses the ones it needs.
+type Options struct {
+	// Path is the database or state file. Empty means the driver's
+	// default file next to the binary; see DefaultPath.
+	Path string
+	// URL is the address of a database server.
+	URL string
+	// KeyPrefix namespaces the keys of a key-value store.
+	KeyPrefix string
+}
+
+// Driver opens a Store.
+type Driver func(o Options) (Store, error)
+
+var (
+	driversMu sync.Mutex
+	drivers   = map[string]Driver{}
+)
+
+// Register makes a driver available under name. Store packages call it
+// from their init function, so importing a package, if only for its side
+// effects, is all it takes to offer its backend. It panics when name is
+// taken, like database/sql.
+func Register(name string, d Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if _, dup := drivers[name]; dup || name == Memory {
+		panic("storage: Register called twice for driver " + name)
+	}
+	drivers[name] = d
+}
+
+// Drivers returns the names of the registered drivers, sorted.
+func Drivers() []string {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	names := []string{Memory}
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Open opens a store with the driver registered under name.
+func Open(name string, o Options) (Store, error) {
+	if name == Memory {
+		return nil, nil
+	}
+	driversMu.Lock()
+	d, ok := drivers[name]
+	driversMu.Unlock()
+	if !ok {
+		return nil, i18n.Errorf("알 수 없는 저장소 드라이버입니다: %s", name)
+	}
+	return d(o)
+}
+
+// DefaultPath returns path, or name next to the running binary when path
+// is empty.
+func DefaultPath(path, name string) string {
+	if path != "" {
+		return path
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(filepath.Dir(exe), name)
+}